@@ -0,0 +1,45 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__CAPTURE_NOW_COMMAND_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__CAPTURE_NOW_COMMAND_PB_H_INCLUDED
+#include <pb.h>
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_CaptureNowCommand {
+    char dummy_field;
+} saladineye_CaptureNowCommand;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_CaptureNowCommand_init_default {0}
+#define saladineye_CaptureNowCommand_init_zero {0}
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_CaptureNowCommand_FIELDLIST(X, a) \
+
+#define saladineye_CaptureNowCommand_CALLBACK NULL
+#define saladineye_CaptureNowCommand_DEFAULT NULL
+
+extern const pb_msgdesc_t saladineye_CaptureNowCommand_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_CaptureNowCommand_fields &saladineye_CaptureNowCommand_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_CAMERA_SERVICE__CAPTURE_NOW_COMMAND_PB_H_MAX_SIZE saladineye_CaptureNowCommand_size
+#define saladineye_CaptureNowCommand_size 0
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
@@ -0,0 +1,90 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__DEVICE_COMMAND_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__DEVICE_COMMAND_PB_H_INCLUDED
+#include <pb.h>
+#include "camera_service__capture_now_command.pb.h"
+#include "camera_service__set_capture_interval_command.pb.h"
+#include "camera_service__set_flash_led_command.pb.h"
+#include "camera_service__reboot_command.pb.h"
+#include "camera_service__resync_ntp_command.pb.h"
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_DeviceCommand {
+    char command_id[33];
+    char device_id[20];
+    int64_t issued_at;
+    int64_t expires_at;
+    pb_size_t which_command;
+    union {
+        saladineye_CaptureNowCommand capture_now;
+        saladineye_SetCaptureIntervalCommand set_capture_interval;
+        saladineye_SetFlashLedCommand set_flash_led;
+        saladineye_RebootCommand reboot;
+        saladineye_ResyncNtpCommand resync_ntp;
+    } command;
+} saladineye_DeviceCommand;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_DeviceCommand_init_default {"", "", 0, 0, 0, {saladineye_CaptureNowCommand_init_default}}
+#define saladineye_DeviceCommand_init_zero {"", "", 0, 0, 0, {saladineye_CaptureNowCommand_init_zero}}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_DeviceCommand_command_id_tag 1
+#define saladineye_DeviceCommand_device_id_tag 2
+#define saladineye_DeviceCommand_issued_at_tag 3
+#define saladineye_DeviceCommand_expires_at_tag 4
+#define saladineye_DeviceCommand_capture_now_tag 5
+#define saladineye_DeviceCommand_set_capture_interval_tag 6
+#define saladineye_DeviceCommand_set_flash_led_tag 7
+#define saladineye_DeviceCommand_reboot_tag 8
+#define saladineye_DeviceCommand_resync_ntp_tag 9
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_DeviceCommand_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, STRING,   command_id,        1) \
+X(a, STATIC,   SINGULAR, STRING,   device_id,         2) \
+X(a, STATIC,   SINGULAR, INT64,    issued_at,         3) \
+X(a, STATIC,   SINGULAR, INT64,    expires_at,        4) \
+X(a, STATIC,   ONEOF,    MESSAGE,  (command,capture_now,command.capture_now),   5) \
+X(a, STATIC,   ONEOF,    MESSAGE,  (command,set_capture_interval,command.set_capture_interval),   6) \
+X(a, STATIC,   ONEOF,    MESSAGE,  (command,set_flash_led,command.set_flash_led),   7) \
+X(a, STATIC,   ONEOF,    MESSAGE,  (command,reboot,command.reboot),   8) \
+X(a, STATIC,   ONEOF,    MESSAGE,  (command,resync_ntp,command.resync_ntp),   9)
+#define saladineye_DeviceCommand_CALLBACK NULL
+#define saladineye_DeviceCommand_DEFAULT NULL
+#define saladineye_DeviceCommand_command_capture_now_MSGTYPE saladineye_CaptureNowCommand
+#define saladineye_DeviceCommand_command_set_capture_interval_MSGTYPE saladineye_SetCaptureIntervalCommand
+#define saladineye_DeviceCommand_command_set_flash_led_MSGTYPE saladineye_SetFlashLedCommand
+#define saladineye_DeviceCommand_command_reboot_MSGTYPE saladineye_RebootCommand
+#define saladineye_DeviceCommand_command_resync_ntp_MSGTYPE saladineye_ResyncNtpCommand
+
+extern const pb_msgdesc_t saladineye_DeviceCommand_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_DeviceCommand_fields &saladineye_DeviceCommand_msg
+
+/* Maximum encoded size of messages (where known) */
+#if defined(saladineye_CaptureNowCommand_size) && defined(saladineye_SetCaptureIntervalCommand_size) && defined(saladineye_SetFlashLedCommand_size) && defined(saladineye_RebootCommand_size) && defined(saladineye_ResyncNtpCommand_size)
+union saladineye_DeviceCommand_command_size_union {char f5[(6 + saladineye_CaptureNowCommand_size)]; char f6[(6 + saladineye_SetCaptureIntervalCommand_size)]; char f7[(6 + saladineye_SetFlashLedCommand_size)]; char f8[(6 + saladineye_RebootCommand_size)]; char f9[(6 + saladineye_ResyncNtpCommand_size)];};
+#endif
+#define SALADINEYE_CAMERA_SERVICE__DEVICE_COMMAND_PB_H_MAX_SIZE saladineye_DeviceCommand_size
+#if defined(saladineye_CaptureNowCommand_size) && defined(saladineye_SetCaptureIntervalCommand_size) && defined(saladineye_SetFlashLedCommand_size) && defined(saladineye_RebootCommand_size) && defined(saladineye_ResyncNtpCommand_size)
+#define saladineye_DeviceCommand_size                (77 + sizeof(union saladineye_DeviceCommand_command_size_union))
+#endif
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
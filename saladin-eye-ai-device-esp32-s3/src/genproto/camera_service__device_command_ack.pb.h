@@ -0,0 +1,60 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__DEVICE_COMMAND_ACK_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__DEVICE_COMMAND_ACK_PB_H_INCLUDED
+#include <pb.h>
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_DeviceCommandAck {
+    char command_id[33];
+    char device_id[20];
+    char status[16];
+    char message[128];
+    int64_t acked_at;
+} saladineye_DeviceCommandAck;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_DeviceCommandAck_init_default {"", "", "", "", 0}
+#define saladineye_DeviceCommandAck_init_zero {"", "", "", "", 0}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_DeviceCommandAck_command_id_tag 1
+#define saladineye_DeviceCommandAck_device_id_tag 2
+#define saladineye_DeviceCommandAck_status_tag 3
+#define saladineye_DeviceCommandAck_message_tag 4
+#define saladineye_DeviceCommandAck_acked_at_tag 5
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_DeviceCommandAck_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, STRING,   command_id,        1) \
+X(a, STATIC,   SINGULAR, STRING,   device_id,         2) \
+X(a, STATIC,   SINGULAR, STRING,   status,            3) \
+X(a, STATIC,   SINGULAR, STRING,   message,           4) \
+X(a, STATIC,   SINGULAR, INT64,    acked_at,          5)
+#define saladineye_DeviceCommandAck_CALLBACK NULL
+#define saladineye_DeviceCommandAck_DEFAULT NULL
+
+extern const pb_msgdesc_t saladineye_DeviceCommandAck_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_DeviceCommandAck_fields &saladineye_DeviceCommandAck_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_CAMERA_SERVICE__DEVICE_COMMAND_ACK_PB_H_MAX_SIZE saladineye_DeviceCommandAck_size
+#define saladineye_DeviceCommandAck_size 212
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
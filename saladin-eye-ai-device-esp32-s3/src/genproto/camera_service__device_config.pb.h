@@ -0,0 +1,69 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__DEVICE_CONFIG_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__DEVICE_CONFIG_PB_H_INCLUDED
+#include <pb.h>
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_DeviceConfig {
+    int32_t utc_offset_seconds;
+    char ntp_server[64];
+    uint32_t ntp_port;
+    uint32_t ntp_update_interval_seconds;
+    uint32_t capture_interval_seconds;
+    bool flash_led_enabled;
+    int64_t version;
+    int64_t updated_at;
+} saladineye_DeviceConfig;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_DeviceConfig_init_default {0, "", 0, 0, 0, 0, 0, 0}
+#define saladineye_DeviceConfig_init_zero {0, "", 0, 0, 0, 0, 0, 0}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_DeviceConfig_utc_offset_seconds_tag 1
+#define saladineye_DeviceConfig_ntp_server_tag 2
+#define saladineye_DeviceConfig_ntp_port_tag 3
+#define saladineye_DeviceConfig_ntp_update_interval_seconds_tag 4
+#define saladineye_DeviceConfig_capture_interval_seconds_tag 5
+#define saladineye_DeviceConfig_flash_led_enabled_tag 6
+#define saladineye_DeviceConfig_version_tag 7
+#define saladineye_DeviceConfig_updated_at_tag 8
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_DeviceConfig_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, INT32,    utc_offset_seconds, 1) \
+X(a, STATIC,   SINGULAR, STRING,   ntp_server,        2) \
+X(a, STATIC,   SINGULAR, UINT32,   ntp_port,          3) \
+X(a, STATIC,   SINGULAR, UINT32,   ntp_update_interval_seconds, 4) \
+X(a, STATIC,   SINGULAR, UINT32,   capture_interval_seconds, 5) \
+X(a, STATIC,   SINGULAR, BOOL,     flash_led_enabled, 6) \
+X(a, STATIC,   SINGULAR, INT64,    version,           7) \
+X(a, STATIC,   SINGULAR, INT64,    updated_at,        8)
+#define saladineye_DeviceConfig_CALLBACK NULL
+#define saladineye_DeviceConfig_DEFAULT NULL
+
+extern const pb_msgdesc_t saladineye_DeviceConfig_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_DeviceConfig_fields &saladineye_DeviceConfig_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_CAMERA_SERVICE__DEVICE_CONFIG_PB_H_MAX_SIZE saladineye_DeviceConfig_size
+#define saladineye_DeviceConfig_size 118
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
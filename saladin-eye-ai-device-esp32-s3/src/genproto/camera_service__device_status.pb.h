@@ -0,0 +1,78 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__DEVICE_STATUS_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__DEVICE_STATUS_PB_H_INCLUDED
+#include <pb.h>
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_DeviceStatus {
+    char device_id[20];
+    char firmware_version[33];
+    uint32_t uptime_seconds;
+    int32_t rssi_dbm;
+    uint32_t free_heap_bytes;
+    uint32_t free_psram_bytes;
+    uint64_t sd_free_bytes;
+    float temperature_celsius;
+    char last_capture_result[16];
+    int64_t last_capture_at;
+    int64_t received_at;
+} saladineye_DeviceStatus;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_DeviceStatus_init_default {"", "", 0, 0, 0, 0, 0, 0, "", 0, 0}
+#define saladineye_DeviceStatus_init_zero {"", "", 0, 0, 0, 0, 0, 0, "", 0, 0}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_DeviceStatus_device_id_tag 1
+#define saladineye_DeviceStatus_firmware_version_tag 2
+#define saladineye_DeviceStatus_uptime_seconds_tag 3
+#define saladineye_DeviceStatus_rssi_dbm_tag 4
+#define saladineye_DeviceStatus_free_heap_bytes_tag 5
+#define saladineye_DeviceStatus_free_psram_bytes_tag 6
+#define saladineye_DeviceStatus_sd_free_bytes_tag 7
+#define saladineye_DeviceStatus_temperature_celsius_tag 8
+#define saladineye_DeviceStatus_last_capture_result_tag 9
+#define saladineye_DeviceStatus_last_capture_at_tag 10
+#define saladineye_DeviceStatus_received_at_tag 11
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_DeviceStatus_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, STRING,   device_id,         1) \
+X(a, STATIC,   SINGULAR, STRING,   firmware_version,  2) \
+X(a, STATIC,   SINGULAR, UINT32,   uptime_seconds,    3) \
+X(a, STATIC,   SINGULAR, SINT32,   rssi_dbm,          4) \
+X(a, STATIC,   SINGULAR, UINT32,   free_heap_bytes,   5) \
+X(a, STATIC,   SINGULAR, UINT32,   free_psram_bytes,  6) \
+X(a, STATIC,   SINGULAR, UINT64,   sd_free_bytes,     7) \
+X(a, STATIC,   SINGULAR, FLOAT,    temperature_celsius, 8) \
+X(a, STATIC,   SINGULAR, STRING,   last_capture_result, 9) \
+X(a, STATIC,   SINGULAR, INT64,    last_capture_at,   10) \
+X(a, STATIC,   SINGULAR, INT64,    received_at,       11)
+#define saladineye_DeviceStatus_CALLBACK NULL
+#define saladineye_DeviceStatus_DEFAULT NULL
+
+extern const pb_msgdesc_t saladineye_DeviceStatus_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_DeviceStatus_fields &saladineye_DeviceStatus_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_CAMERA_SERVICE__DEVICE_STATUS_PB_H_MAX_SIZE saladineye_DeviceStatus_size
+#define saladineye_DeviceStatus_size 134
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
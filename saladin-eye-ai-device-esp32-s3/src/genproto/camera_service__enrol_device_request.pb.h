@@ -0,0 +1,54 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__ENROL_DEVICE_REQUEST_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__ENROL_DEVICE_REQUEST_PB_H_INCLUDED
+#include <pb.h>
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_EnrolDeviceRequest {
+    char token[65];
+    pb_byte_t ed25519_public_key[32];
+    char firmware_version[33];
+} saladineye_EnrolDeviceRequest;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_EnrolDeviceRequest_init_default {"", {0}, ""}
+#define saladineye_EnrolDeviceRequest_init_zero {"", {0}, ""}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_EnrolDeviceRequest_token_tag 1
+#define saladineye_EnrolDeviceRequest_ed25519_public_key_tag 2
+#define saladineye_EnrolDeviceRequest_firmware_version_tag 3
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_EnrolDeviceRequest_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, STRING,   token,             1) \
+X(a, STATIC,   SINGULAR, FIXED_LENGTH_BYTES, ed25519_public_key, 2) \
+X(a, STATIC,   SINGULAR, STRING,   firmware_version,  3)
+#define saladineye_EnrolDeviceRequest_CALLBACK NULL
+#define saladineye_EnrolDeviceRequest_DEFAULT NULL
+
+extern const pb_msgdesc_t saladineye_EnrolDeviceRequest_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_EnrolDeviceRequest_fields &saladineye_EnrolDeviceRequest_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_CAMERA_SERVICE__ENROL_DEVICE_REQUEST_PB_H_MAX_SIZE saladineye_EnrolDeviceRequest_size
+#define saladineye_EnrolDeviceRequest_size 134
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
@@ -0,0 +1,75 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__ENROL_DEVICE_RESPONSE_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__ENROL_DEVICE_RESPONSE_PB_H_INCLUDED
+#include <pb.h>
+#include "camera_service__device_config.pb.h"
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_EnrolDeviceResponse {
+    char device_id[20];
+    char mqtt_broker[128];
+    char mqtt_client_id[64];
+    char mqtt_username[64];
+    char mqtt_password[64];
+    char key_id[17];
+    char key_algorithm[16];
+    pb_byte_t hmac_secret[32];
+    bool has_config;
+    saladineye_DeviceConfig config;
+} saladineye_EnrolDeviceResponse;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_EnrolDeviceResponse_init_default {"", "", "", "", "", "", "", {0}, false, saladineye_DeviceConfig_init_default}
+#define saladineye_EnrolDeviceResponse_init_zero {"", "", "", "", "", "", "", {0}, false, saladineye_DeviceConfig_init_zero}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_EnrolDeviceResponse_device_id_tag 1
+#define saladineye_EnrolDeviceResponse_mqtt_broker_tag 2
+#define saladineye_EnrolDeviceResponse_mqtt_client_id_tag 3
+#define saladineye_EnrolDeviceResponse_mqtt_username_tag 4
+#define saladineye_EnrolDeviceResponse_mqtt_password_tag 5
+#define saladineye_EnrolDeviceResponse_key_id_tag 6
+#define saladineye_EnrolDeviceResponse_key_algorithm_tag 7
+#define saladineye_EnrolDeviceResponse_hmac_secret_tag 8
+#define saladineye_EnrolDeviceResponse_config_tag 9
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_EnrolDeviceResponse_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, STRING,   device_id,         1) \
+X(a, STATIC,   SINGULAR, STRING,   mqtt_broker,       2) \
+X(a, STATIC,   SINGULAR, STRING,   mqtt_client_id,    3) \
+X(a, STATIC,   SINGULAR, STRING,   mqtt_username,     4) \
+X(a, STATIC,   SINGULAR, STRING,   mqtt_password,     5) \
+X(a, STATIC,   SINGULAR, STRING,   key_id,            6) \
+X(a, STATIC,   SINGULAR, STRING,   key_algorithm,     7) \
+X(a, STATIC,   SINGULAR, FIXED_LENGTH_BYTES, hmac_secret,       8) \
+X(a, STATIC,   OPTIONAL, MESSAGE,  config,            9)
+#define saladineye_EnrolDeviceResponse_CALLBACK NULL
+#define saladineye_EnrolDeviceResponse_DEFAULT NULL
+#define saladineye_EnrolDeviceResponse_config_MSGTYPE saladineye_DeviceConfig
+
+extern const pb_msgdesc_t saladineye_EnrolDeviceResponse_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_EnrolDeviceResponse_fields &saladineye_EnrolDeviceResponse_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_CAMERA_SERVICE__ENROL_DEVICE_RESPONSE_PB_H_MAX_SIZE saladineye_EnrolDeviceResponse_size
+#define saladineye_EnrolDeviceResponse_size 534
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
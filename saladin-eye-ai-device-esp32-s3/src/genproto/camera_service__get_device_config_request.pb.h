@@ -0,0 +1,48 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__GET_DEVICE_CONFIG_REQUEST_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__GET_DEVICE_CONFIG_REQUEST_PB_H_INCLUDED
+#include <pb.h>
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_GetDeviceConfigRequest {
+    char device_id[20];
+} saladineye_GetDeviceConfigRequest;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_GetDeviceConfigRequest_init_default {""}
+#define saladineye_GetDeviceConfigRequest_init_zero {""}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_GetDeviceConfigRequest_device_id_tag 1
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_GetDeviceConfigRequest_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, STRING,   device_id,         1)
+#define saladineye_GetDeviceConfigRequest_CALLBACK NULL
+#define saladineye_GetDeviceConfigRequest_DEFAULT NULL
+
+extern const pb_msgdesc_t saladineye_GetDeviceConfigRequest_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_GetDeviceConfigRequest_fields &saladineye_GetDeviceConfigRequest_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_CAMERA_SERVICE__GET_DEVICE_CONFIG_REQUEST_PB_H_MAX_SIZE saladineye_GetDeviceConfigRequest_size
+#define saladineye_GetDeviceConfigRequest_size 21
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
@@ -0,0 +1,54 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__GET_DEVICE_CONFIG_RESPONSE_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__GET_DEVICE_CONFIG_RESPONSE_PB_H_INCLUDED
+#include <pb.h>
+#include "camera_service__device_config.pb.h"
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_GetDeviceConfigResponse {
+    char device_id[20];
+    bool has_config;
+    saladineye_DeviceConfig config;
+} saladineye_GetDeviceConfigResponse;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_GetDeviceConfigResponse_init_default {"", false, saladineye_DeviceConfig_init_default}
+#define saladineye_GetDeviceConfigResponse_init_zero {"", false, saladineye_DeviceConfig_init_zero}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_GetDeviceConfigResponse_device_id_tag 1
+#define saladineye_GetDeviceConfigResponse_config_tag 2
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_GetDeviceConfigResponse_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, STRING,   device_id,         1) \
+X(a, STATIC,   OPTIONAL, MESSAGE,  config,            2)
+#define saladineye_GetDeviceConfigResponse_CALLBACK NULL
+#define saladineye_GetDeviceConfigResponse_DEFAULT NULL
+#define saladineye_GetDeviceConfigResponse_config_MSGTYPE saladineye_DeviceConfig
+
+extern const pb_msgdesc_t saladineye_GetDeviceConfigResponse_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_GetDeviceConfigResponse_fields &saladineye_GetDeviceConfigResponse_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_CAMERA_SERVICE__GET_DEVICE_CONFIG_RESPONSE_PB_H_MAX_SIZE saladineye_GetDeviceConfigResponse_size
+#define saladineye_GetDeviceConfigResponse_size 141
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
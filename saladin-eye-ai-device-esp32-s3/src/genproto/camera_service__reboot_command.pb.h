@@ -0,0 +1,48 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__REBOOT_COMMAND_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__REBOOT_COMMAND_PB_H_INCLUDED
+#include <pb.h>
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_RebootCommand {
+    uint32_t delay_seconds;
+} saladineye_RebootCommand;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_RebootCommand_init_default {0}
+#define saladineye_RebootCommand_init_zero {0}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_RebootCommand_delay_seconds_tag 1
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_RebootCommand_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, UINT32,   delay_seconds,     1)
+#define saladineye_RebootCommand_CALLBACK NULL
+#define saladineye_RebootCommand_DEFAULT NULL
+
+extern const pb_msgdesc_t saladineye_RebootCommand_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_RebootCommand_fields &saladineye_RebootCommand_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_CAMERA_SERVICE__REBOOT_COMMAND_PB_H_MAX_SIZE saladineye_RebootCommand_size
+#define saladineye_RebootCommand_size 6
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
@@ -0,0 +1,48 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_CAMERA_SERVICE__RESYNC_NTP_COMMAND_PB_H_INCLUDED
+#define PB_SALADINEYE_CAMERA_SERVICE__RESYNC_NTP_COMMAND_PB_H_INCLUDED
+#include <pb.h>
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_ResyncNtpCommand {
+    char ntp_server[64];
+} saladineye_ResyncNtpCommand;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_ResyncNtpCommand_init_default {""}
+#define saladineye_ResyncNtpCommand_init_zero {""}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_ResyncNtpCommand_ntp_server_tag 1
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_ResyncNtpCommand_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, STRING,   ntp_server,        1)
+#define saladineye_ResyncNtpCommand_CALLBACK NULL
+#define saladineye_ResyncNtpCommand_DEFAULT NULL
+
+extern const pb_msgdesc_t saladineye_ResyncNtpCommand_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_ResyncNtpCommand_fields &saladineye_ResyncNtpCommand_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_CAMERA_SERVICE__RESYNC_NTP_COMMAND_PB_H_MAX_SIZE saladineye_ResyncNtpCommand_size
+#define saladineye_ResyncNtpCommand_size 65
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
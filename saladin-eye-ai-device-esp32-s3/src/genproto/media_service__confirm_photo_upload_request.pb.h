@@ -0,0 +1,48 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_MEDIA_SERVICE__CONFIRM_PHOTO_UPLOAD_REQUEST_PB_H_INCLUDED
+#define PB_SALADINEYE_MEDIA_SERVICE__CONFIRM_PHOTO_UPLOAD_REQUEST_PB_H_INCLUDED
+#include <pb.h>
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_ConfirmPhotoUploadRequest {
+    char photo_path[200];
+} saladineye_ConfirmPhotoUploadRequest;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_ConfirmPhotoUploadRequest_init_default {""}
+#define saladineye_ConfirmPhotoUploadRequest_init_zero {""}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_ConfirmPhotoUploadRequest_photo_path_tag 1
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_ConfirmPhotoUploadRequest_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, STRING,   photo_path,        1)
+#define saladineye_ConfirmPhotoUploadRequest_CALLBACK NULL
+#define saladineye_ConfirmPhotoUploadRequest_DEFAULT NULL
+
+extern const pb_msgdesc_t saladineye_ConfirmPhotoUploadRequest_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_ConfirmPhotoUploadRequest_fields &saladineye_ConfirmPhotoUploadRequest_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_MEDIA_SERVICE__CONFIRM_PHOTO_UPLOAD_REQUEST_PB_H_MAX_SIZE saladineye_ConfirmPhotoUploadRequest_size
+#define saladineye_ConfirmPhotoUploadRequest_size 202
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
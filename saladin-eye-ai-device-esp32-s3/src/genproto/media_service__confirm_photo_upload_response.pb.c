@@ -0,0 +1,12 @@
+/* Automatically generated nanopb constant definitions */
+/* Generated by nanopb-0.4.8 */
+
+#include "media_service__confirm_photo_upload_response.pb.h"
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+PB_BIND(saladineye_ConfirmPhotoUploadResponse, saladineye_ConfirmPhotoUploadResponse, 2)
+
+
+
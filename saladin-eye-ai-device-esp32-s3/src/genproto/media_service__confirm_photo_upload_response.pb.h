@@ -0,0 +1,55 @@
+/* Automatically generated nanopb header */
+/* Generated by nanopb-0.4.8 */
+
+#ifndef PB_SALADINEYE_MEDIA_SERVICE__CONFIRM_PHOTO_UPLOAD_RESPONSE_PB_H_INCLUDED
+#define PB_SALADINEYE_MEDIA_SERVICE__CONFIRM_PHOTO_UPLOAD_RESPONSE_PB_H_INCLUDED
+#include <pb.h>
+
+#if PB_PROTO_HEADER_VERSION != 40
+#error Regenerate this file with the current version of nanopb generator.
+#endif
+
+/* Struct definitions */
+typedef struct _saladineye_ConfirmPhotoUploadResponse {
+    char device_id[20];
+    char photo_path[200];
+    pb_size_t quality_flags_count;
+    char quality_flags[8][32];
+} saladineye_ConfirmPhotoUploadResponse;
+
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Initializer values for message structs */
+#define saladineye_ConfirmPhotoUploadResponse_init_default {"", "", 0, {"", "", "", "", "", "", "", ""}}
+#define saladineye_ConfirmPhotoUploadResponse_init_zero {"", "", 0, {"", "", "", "", "", "", "", ""}}
+
+/* Field tags (for use in manual encoding/decoding) */
+#define saladineye_ConfirmPhotoUploadResponse_device_id_tag 1
+#define saladineye_ConfirmPhotoUploadResponse_photo_path_tag 2
+#define saladineye_ConfirmPhotoUploadResponse_quality_flags_tag 3
+
+/* Struct field encoding specification for nanopb */
+#define saladineye_ConfirmPhotoUploadResponse_FIELDLIST(X, a) \
+X(a, STATIC,   SINGULAR, STRING,   device_id,         1) \
+X(a, STATIC,   SINGULAR, STRING,   photo_path,        2) \
+X(a, STATIC,   REPEATED, STRING,   quality_flags,     3)
+#define saladineye_ConfirmPhotoUploadResponse_CALLBACK NULL
+#define saladineye_ConfirmPhotoUploadResponse_DEFAULT NULL
+
+extern const pb_msgdesc_t saladineye_ConfirmPhotoUploadResponse_msg;
+
+/* Defines for backwards compatibility with code written before nanopb-0.4.0 */
+#define saladineye_ConfirmPhotoUploadResponse_fields &saladineye_ConfirmPhotoUploadResponse_msg
+
+/* Maximum encoded size of messages (where known) */
+#define SALADINEYE_MEDIA_SERVICE__CONFIRM_PHOTO_UPLOAD_RESPONSE_PB_H_MAX_SIZE saladineye_ConfirmPhotoUploadResponse_size
+#define saladineye_ConfirmPhotoUploadResponse_size 487
+
+#ifdef __cplusplus
+} /* extern "C" */
+#endif
+
+#endif
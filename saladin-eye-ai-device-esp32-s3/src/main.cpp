@@ -32,6 +32,8 @@
 #include <RTClib.h>
 #include <PubSubClient.h>
 #include <HTTPClient.h>
+#include <Preferences.h>
+#include <sodium.h>
 #include <pb_encode.h>
 #include <pb_decode.h>
 #include "esp_log.h"
@@ -40,6 +42,16 @@
 #include "SD_MMC.h"
 #include "genproto/media_service__get_photo_upload_url_request.pb.h"
 #include "genproto/media_service__get_photo_upload_url_response.pb.h"
+#include "genproto/media_service__confirm_photo_upload_request.pb.h"
+#include "genproto/media_service__confirm_photo_upload_response.pb.h"
+#include "genproto/camera_service__device_command.pb.h"
+#include "genproto/camera_service__device_command_ack.pb.h"
+#include "genproto/camera_service__device_config.pb.h"
+#include "genproto/camera_service__device_status.pb.h"
+#include "genproto/camera_service__get_device_config_request.pb.h"
+#include "genproto/camera_service__get_device_config_response.pb.h"
+#include "genproto/camera_service__enrol_device_request.pb.h"
+#include "genproto/camera_service__enrol_device_response.pb.h"
 
 // GPIO pins for I2C communication with DS3231 RTC module
 #define I2C_SDA 19
@@ -74,44 +86,117 @@
 // Timer for capturing photos and publish request over MQTT
 #define MSG_INTERVAL 10000
 
-// Device ID, generate a unique ID for each device
-const char* deviceId = "B7K9F2Q4L";
+// Timer for the DeviceStatus heartbeat, well within the 180 seconds the
+// camera-service waits before taking the device as offline
+#define STATUS_INTERVAL 60000
+
+#define FIRMWARE_VERSION "0.1.0"
+
+// Time between two enrolment attempts, while the camera-service can't be
+// reached or refuses the token
+#define ENROLMENT_RETRY_INTERVAL 30000
+
+// Enrolment, a new device gets its device ID and credentials from the
+// camera-service with the one-time token an admin created for it.
+// TODO - use proper way for the token and the URL while development, for example using env file
+const char *enrolmentToken = "__REPLACE__";
+const char *enrolmentUrl = "https://__REPLACE__/enrol";
+// Root CA certificate of the enrolment URL, in PEM
+const char *enrolmentRootCa = "__REPLACE__";
+
+// Kept in the NVS once the device is enrolled, they survive a reboot and a
+// firmware update
+Preferences preferences;
+
+// Device ID, assigned by the camera-service on enrolment
+char deviceId[20] = "";
+
+// The Ed25519 secret key made on enrolment, the media-service knows its
+// public key as key_id. Stored for signing the media-service requests, they
+// are sent unsigned for now.
+char signingKeyId[17] = "";
+char signingKeyAlgorithm[16] = "";
+uint8_t signingKey[crypto_sign_SECRETKEYBYTES];
+size_t signingKeyLength = 0;
 
 String mqttTopicMediaServiceString = "saladin-eye/server/media-service";
 const char* mqttTopicMediaService = mqttTopicMediaServiceString.c_str();
 
-String mqttTopicResponseWildcardString = "saladin-eye/device/" + String(deviceId) + "/response/#";
-const char* mqttTopicResponseWildcard = mqttTopicResponseWildcardString.c_str();
+// The topics of the device, set by initDeviceTopics once the device ID is known
+String mqttTopicResponseWildcardString;
+const char* mqttTopicResponseWildcard = nullptr;
+
+// The camera-service publishes a DeviceCommand to the command topic of the
+// device, and the device answers with a DeviceCommandAck on its ack topic
+String mqttTopicCommandString;
+const char *mqttTopicCommand = nullptr;
+
+String mqttTopicCommandAckString;
+const char *mqttTopicCommandAck = nullptr;
 
-String mqttTopicCommandString = "saladin-eye/device/command";
-const char *mqttTopicCommand = mqttTopicCommandString.c_str();
+// The device publishes its DeviceStatus heartbeat on its status topic. The
+// broker publishes the Will on the last-will topic when the device drops off
+// without disconnecting, the camera-service takes it as offline right away.
+String mqttTopicStatusString;
+const char *mqttTopicStatus = nullptr;
 
-// Define NTP properties
-// TODO - make the UTC offset, NTP server, and NTP port configurable via SaladinEye.AI Nest.js command center
-const long utcOffsetInSeconds = 7 * 3600;
-const char *ntpServer = "pool.ntp.org";
-const int ntpPort = 123;
+String mqttTopicLastWillString;
+const char *mqttTopicLastWill = nullptr;
+
+// The camera-service retains the DeviceConfig of the device on its config
+// topic, the device also asks the media-service for it with get-device-config
+String mqttTopicConfigString;
+const char *mqttTopicConfig = nullptr;
+
+// NTP properties, the built-in defaults until the DeviceConfig arrives
+long utcOffsetInSeconds = 7 * 3600;
+char ntpServerBuffer[64] = "pool.ntp.org";
+unsigned long ntpUpdateIntervalMs = 60000;
 
 WiFiUDP ntpUDP;
-// TODO - make the update interval configurable via SaladinEye.AI Nest.js command center
-NTPClient timeClient(ntpUDP, ntpServer, utcOffsetInSeconds, 60000);
+NTPClient timeClient(ntpUDP, ntpServerBuffer, utcOffsetInSeconds, ntpUpdateIntervalMs);
 // Define RTC
 RTC_DS3231 rtc;
 
-// MQTT
+// MQTT, the broker and the credentials come with the enrolment
 WiFiClient wifiClient;
-const char* mqttBrokerAddress = "__REPLACE__";
-String mqttClientIdString = "SaladinEye-ESP32S3-" + String(deviceId);
-const char* mqttClientId = mqttClientIdString.c_str();
-String mqttUsernameString = "SaladinEye-ESP32S3-" + String(deviceId);
-const char* mqttUsername = mqttUsernameString.c_str();
-const char* mqttPassword = "__REPLACE__";
+char mqttBrokerAddress[128] = "";
+uint16_t mqttBrokerPort = 1883;
+char mqttClientId[64] = "";
+char mqttUsername[64] = "";
+char mqttPassword[64] = "";
 PubSubClient mqttClient(wifiClient);
 long lastMsg = 0;
 char msg[50];
 int value = 0;
 
+// Settings the DeviceConfig and the device commands change at runtime
+unsigned long captureIntervalMs = MSG_INTERVAL;
+bool flashLedEnabled = true;
+
+// Version of the DeviceConfig applied, 0 for the built-in defaults
+int64_t deviceConfigVersion = 0;
+
+// Reported on the DeviceStatus: ok, camera_error, sd_error, upload_failed,
+// or empty before the first capture
+long lastStatus = 0;
+const char *lastCaptureResult = "";
+int64_t lastCaptureAt = 0;
+
+// Set by the reboot command, the device restarts once millis() passes it
+unsigned long rebootAt = 0;
+bool rebootScheduled = false;
+
+// A command delivered again by the broker is acknowledged again, not run twice
+char lastCommandId[33] = "";
+char lastCommandStatus[16] = "";
+char lastCommandMessage[128] = "";
+
 // Functions declaration
+bool loadEnrolment();
+bool enrolDevice();
+void setMqttBroker(const char *broker);
+void initDeviceTopics();
 void updateRtcFromNtp();
 String getFormattedRtcTime();
 bool initCamera();
@@ -120,6 +205,14 @@ bool capturePhoto(String targetFullPath);
 bool capturePhotoContinuously();
 void mqttCallback(char *topic, byte *message, unsigned int length);
 void mqttReconnect();
+void handleDeviceCommand(byte *mqttMessage, unsigned int length);
+void applyDeviceConfig(const saladineye_DeviceConfig *config);
+bool requestDeviceConfig();
+bool publishDeviceStatus();
+void uploadPhoto(const saladineye_GetPhotoUploadUrlResponse *response);
+bool confirmPhotoUpload(const char *photoPath);
+bool publishMediaServiceRequest(const char *method, const uint8_t *payload, size_t length);
+bool publishDeviceCommandAck(const char *commandId, const char *status, const char *message);
 bool encode_string(pb_ostream_t* stream, const pb_field_t* field, void* const* arg);
 bool decode_string(pb_istream_t *stream, const pb_field_t *field, void **arg);
 
@@ -186,11 +279,194 @@ void setup()
     }
   }
 
+  // Libsodium makes the signing key of the enrolment
+  if (sodium_init() < 0)
+  {
+    while (1)
+    {
+      log_e("Couldn't initialize libsodium");
+      delay(1000);
+    }
+  }
+
+  // A new device enrols once, until it gets its device ID and credentials
+  if (!loadEnrolment())
+  {
+    while (!enrolDevice())
+    {
+      log_e("enrolment failed, trying again in %d seconds", ENROLMENT_RETRY_INTERVAL / 1000);
+      delay(ENROLMENT_RETRY_INTERVAL);
+    }
+  }
+  log_i("device_id %s", deviceId);
+
+  initDeviceTopics();
+
   mqttClient.setBufferSize(2048);
-  mqttClient.setServer(mqttBrokerAddress, 1883);
+  mqttClient.setServer(mqttBrokerAddress, mqttBrokerPort);
   mqttClient.setCallback(mqttCallback);
 }
 
+/**
+ * Load the device ID, the MQTT credentials and the signing key stored on
+ * enrolment. Returns false when the device is not enrolled yet.
+ */
+bool loadEnrolment()
+{
+  // The namespace is only there once the device has enrolled
+  if (!preferences.begin("enrolment", true)) {
+    return false;
+  }
+
+  // The device ID is stored last, only a complete enrolment has it
+  bool enrolled = preferences.getString("device_id", deviceId, sizeof(deviceId)) > 0;
+  if (enrolled) {
+    char broker[sizeof(mqttBrokerAddress)] = "";
+    preferences.getString("mqtt_broker", broker, sizeof(broker));
+    setMqttBroker(broker);
+
+    preferences.getString("mqtt_client_id", mqttClientId, sizeof(mqttClientId));
+    preferences.getString("mqtt_username", mqttUsername, sizeof(mqttUsername));
+    preferences.getString("mqtt_password", mqttPassword, sizeof(mqttPassword));
+    preferences.getString("key_id", signingKeyId, sizeof(signingKeyId));
+    preferences.getString("key_algorithm", signingKeyAlgorithm, sizeof(signingKeyAlgorithm));
+    signingKeyLength = preferences.getBytes("signing_key", signingKey, sizeof(signingKey));
+  }
+
+  preferences.end();
+
+  return enrolled;
+}
+
+/**
+ * Enrol the device with the camera-service: POST an EnrolDeviceRequest with
+ * the enrolment token and the public key of a new Ed25519 key pair, over
+ * HTTPS, and store the EnrolDeviceResponse. The secret key never leaves the
+ * device.
+ *
+ * The token is used once, a device that fails to store the response needs a
+ * new one.
+ */
+bool enrolDevice()
+{
+  uint8_t publicKey[crypto_sign_PUBLICKEYBYTES];
+  uint8_t secretKey[crypto_sign_SECRETKEYBYTES];
+  crypto_sign_keypair(publicKey, secretKey);
+
+  saladineye_EnrolDeviceRequest request = saladineye_EnrolDeviceRequest_init_zero;
+  strncpy(request.token, enrolmentToken, sizeof(request.token) - 1);
+  memcpy(request.ed25519_public_key, publicKey, sizeof(request.ed25519_public_key));
+  strncpy(request.firmware_version, FIRMWARE_VERSION, sizeof(request.firmware_version) - 1);
+
+  uint8_t requestBuffer[saladineye_EnrolDeviceRequest_size];
+  pb_ostream_t ostream = pb_ostream_from_buffer(requestBuffer, sizeof(requestBuffer));
+  if (!pb_encode(&ostream, saladineye_EnrolDeviceRequest_fields, &request)) {
+    log_e("encoding protobuf saladineye_EnrolDeviceRequest failed");
+    return false;
+  }
+
+  HTTPClient http;
+  http.begin(enrolmentUrl, enrolmentRootCa);
+  http.addHeader("Content-Type", "application/x-protobuf");
+
+  int httpResponseCode = http.POST(requestBuffer, ostream.bytes_written);
+  if (httpResponseCode != 200) {
+    log_e("enrolment refused, HTTP response code %d: %s", httpResponseCode, http.getString().c_str());
+    http.end();
+    return false;
+  }
+
+  static uint8_t responseBuffer[saladineye_EnrolDeviceResponse_size];
+  int responseLength = http.getSize();
+  if (responseLength <= 0 || responseLength > (int)sizeof(responseBuffer)) {
+    log_e("invalid EnrolDeviceResponse length %d", responseLength);
+    http.end();
+    return false;
+  }
+  size_t read = http.getStreamPtr()->readBytes(responseBuffer, responseLength);
+  http.end();
+
+  static saladineye_EnrolDeviceResponse response = saladineye_EnrolDeviceResponse_init_zero;
+  pb_istream_t istream = pb_istream_from_buffer(responseBuffer, read);
+  if (read != (size_t)responseLength || !pb_decode(&istream, saladineye_EnrolDeviceResponse_fields, &response)) {
+    log_e("decoding protobuf saladineye_EnrolDeviceResponse failed");
+    return false;
+  }
+
+  if (strlen(response.device_id) == 0 || strcmp(response.key_algorithm, "ed25519") != 0) {
+    log_e("invalid EnrolDeviceResponse, device_id %s key algorithm %s", response.device_id, response.key_algorithm);
+    return false;
+  }
+
+  preferences.begin("enrolment", false);
+  preferences.putString("mqtt_broker", response.mqtt_broker);
+  preferences.putString("mqtt_client_id", response.mqtt_client_id);
+  preferences.putString("mqtt_username", response.mqtt_username);
+  preferences.putString("mqtt_password", response.mqtt_password);
+  preferences.putString("key_id", response.key_id);
+  preferences.putString("key_algorithm", response.key_algorithm);
+  preferences.putBytes("signing_key", secretKey, sizeof(secretKey));
+  preferences.putString("device_id", response.device_id);
+  preferences.end();
+
+  // The secret key is in the NVS now, and in signingKey once loaded
+  sodium_memzero(secretKey, sizeof(secretKey));
+
+  if (!loadEnrolment()) {
+    log_e("failed to store the enrolment");
+    return false;
+  }
+
+  log_i("enrolled as device_id %s", deviceId);
+
+  if (response.has_config) {
+    applyDeviceConfig(&response.config);
+  }
+
+  return true;
+}
+
+/**
+ * Set the MQTT broker of the enrolment, a host name with an optional port:
+ *   [scheme://]host[:port]
+ */
+void setMqttBroker(const char *broker)
+{
+  const char *host = strstr(broker, "://");
+  host = host ? host + 3 : broker;
+
+  strncpy(mqttBrokerAddress, host, sizeof(mqttBrokerAddress) - 1);
+  mqttBrokerAddress[sizeof(mqttBrokerAddress) - 1] = '\0';
+  mqttBrokerPort = 1883;
+
+  char *port = strrchr(mqttBrokerAddress, ':');
+  if (port) {
+    *port = '\0';
+    mqttBrokerPort = atoi(port + 1);
+  }
+}
+
+void initDeviceTopics()
+{
+  mqttTopicResponseWildcardString = "saladin-eye/device/" + String(deviceId) + "/response/#";
+  mqttTopicResponseWildcard = mqttTopicResponseWildcardString.c_str();
+
+  mqttTopicCommandString = "saladin-eye/device/" + String(deviceId) + "/command";
+  mqttTopicCommand = mqttTopicCommandString.c_str();
+
+  mqttTopicCommandAckString = "saladin-eye/server/camera-service/command-ack/" + String(deviceId);
+  mqttTopicCommandAck = mqttTopicCommandAckString.c_str();
+
+  mqttTopicStatusString = "saladin-eye/device/" + String(deviceId) + "/status";
+  mqttTopicStatus = mqttTopicStatusString.c_str();
+
+  mqttTopicLastWillString = "saladin-eye/device/" + String(deviceId) + "/last-will";
+  mqttTopicLastWill = mqttTopicLastWillString.c_str();
+
+  mqttTopicConfigString = "saladin-eye/device/" + String(deviceId) + "/config";
+  mqttTopicConfig = mqttTopicConfigString.c_str();
+}
+
 // Loop code, to run repeatedly
 void loop()
 {
@@ -199,8 +475,25 @@ void loop()
   }
   mqttClient.loop();
 
+  // Only queries the NTP server once the update interval of the DeviceConfig
+  // has passed
+  if (timeClient.update()) {
+    rtc.adjust(DateTime(timeClient.getEpochTime()));
+    log_i("RTC updated with NTP time");
+  }
+
+  if (rebootScheduled && (long)(millis() - rebootAt) >= 0) {
+    log_i("Rebooting as commanded");
+    ESP.restart();
+  }
+
   unsigned long now = millis();
-  if (now - lastMsg > MSG_INTERVAL) {
+  if (now - lastStatus > STATUS_INTERVAL) {
+    lastStatus = now;
+    publishDeviceStatus();
+  }
+
+  if (now - lastMsg > captureIntervalMs) {
     lastMsg = now;
     bool captureResult = capturePhotoContinuously();
     if (!captureResult)
@@ -324,11 +617,24 @@ bool initSdCard()
 // Function to capture and save a photo
 bool capturePhoto(String targetFullPath)
 {
-  // Turn on flash LED
-  digitalWrite(FLASH_LED_GPIO_NUM, HIGH);
+  // Turn on flash LED, unless turned off with the set flash LED command
+  if (flashLedEnabled)
+  {
+    digitalWrite(FLASH_LED_GPIO_NUM, HIGH);
+  }
+
+  // The RTC is in local time
+  lastCaptureAt = (int64_t)rtc.now().unixtime() - utcOffsetInSeconds;
 
   // Open file handler
   File photoFile = SD_MMC.open(targetFullPath, FILE_WRITE);
+  if (!photoFile)
+  {
+    digitalWrite(FLASH_LED_GPIO_NUM, LOW);
+    log_e("failed to open %s on the SD card", targetFullPath.c_str());
+    lastCaptureResult = "sd_error";
+    return false;
+  }
 
   // Create frame-buffer variable
   camera_fb_t *fb = NULL;
@@ -337,8 +643,10 @@ bool capturePhoto(String targetFullPath)
   fb = esp_camera_fb_get();
   if (!fb)
   {
+    photoFile.close();
     digitalWrite(FLASH_LED_GPIO_NUM, LOW);
     log_e("Camera capture failed");
+    lastCaptureResult = "camera_error";
     return false;
   }
 
@@ -355,6 +663,7 @@ bool capturePhoto(String targetFullPath)
   digitalWrite(FLASH_LED_GPIO_NUM, LOW);
 
   log_i("Photo captured and saved: %s", targetFullPath.c_str());
+  lastCaptureResult = "ok";
 
   return true;
 }
@@ -433,19 +742,32 @@ bool capturePhotoContinuously()
   }
 
   // Publish the message to MQTT
+  return publishMediaServiceRequest("get-photo-upload-url", buffer, message_length);
+}
 
+/**
+ * Publish a request to a method of the media-service, with a random
+ * idempotency key:
+ *   saladin-eye/server/media-service/request/[method]/[deviceId]/[idempotency-key]
+ */
+bool publishMediaServiceRequest(const char *method, const uint8_t *payload, size_t length)
+{
   // Create random string with length 10
   char randomString[11];
   for (int i = 0; i < 10; i++) {
     randomString[i] = (char)random(65, 90);
   }
+  randomString[10] = '\0';
 
-  String publishTopicString = "saladin-eye/server/media-service/request/get-photo-upload-url/" + String(deviceId) + "/" + String(randomString);
+  String publishTopicString = "saladin-eye/server/media-service/request/" + String(method) + "/" + String(deviceId) + "/" + String(randomString);
   const char* publishTopic = publishTopicString.c_str();
 
-  mqttClient.publish(publishTopic, buffer, message_length);
-  Serial.println("FromDevice message sent to media-service over MQTT!");
-  Serial.println(publishTopic);
+  if (!mqttClient.publish(publishTopic, payload, length)) {
+    log_e("failed to publish %s request", method);
+    return false;
+  }
+
+  log_i("request sent to media-service over MQTT: %s", publishTopic);
 
   return true;
 }
@@ -455,6 +777,24 @@ void mqttCallback(char *topic, byte *mqttMessage, unsigned int length)
   // Convert topic to String for easier manipulation
   String topicString = String(topic);
 
+  if (topicString == mqttTopicCommandString) {
+    handleDeviceCommand(mqttMessage, length);
+    return;
+  }
+
+  if (topicString == mqttTopicConfigString) {
+    saladineye_DeviceConfig config = saladineye_DeviceConfig_init_zero;
+    pb_istream_t istream = pb_istream_from_buffer(mqttMessage, length);
+
+    if (!pb_decode(&istream, saladineye_DeviceConfig_fields, &config)) {
+      log_e("decoding protobuf saladineye_DeviceConfig failed");
+      return;
+    }
+
+    applyDeviceConfig(&config);
+    return;
+  }
+
   // Find the position of "/response/"
   int startIndex = topicString.indexOf("/response/");
   if (startIndex != -1) {
@@ -476,37 +816,33 @@ void mqttCallback(char *topic, byte *mqttMessage, unsigned int length)
             return;
         }
 
-        // Open the JPG file
-        File jpgFile = SD_MMC.open(response.original_photo_path);
-        if (!jpgFile) {
-          log_e("failed to open JPG file to be uploaded");
+        uploadPhoto(&response);
+      } else if (methodName == "media-service/get-device-config") {
+        saladineye_GetDeviceConfigResponse response = saladineye_GetDeviceConfigResponse_init_zero;
+        pb_istream_t istream = pb_istream_from_buffer(mqttMessage, length);
+
+        if (!pb_decode(&istream, saladineye_GetDeviceConfigResponse_fields, &response)) {
+          log_e("decoding protobuf saladineye_GetDeviceConfigResponse failed");
           return;
         }
-        
-        // Get the file size
-        size_t fileSize = jpgFile.size();
-        
-        // Create an HTTP client
-        HTTPClient http;
-        
-        // Begin the HTTP request
-        http.begin(response.upload_url);
-        http.addHeader("Content-Type", "image/jpeg");
-        http.addHeader("Content-Length", String(fileSize));
-        
-        // Start the PUT request
-        int httpResponseCode = http.sendRequest("PUT", &jpgFile, fileSize);
-        
-        if (httpResponseCode > 0) {
-          String response = http.getString();
-          log_i("OK HTTP Response: %s", response.c_str());
-        } else {
-          log_e("Error HTTP response code: %d", httpResponseCode);
+
+        // Without configuration the device keeps its built-in defaults
+        if (response.has_config) {
+          applyDeviceConfig(&response.config);
+        }
+      } else if (methodName == "media-service/confirm-photo-upload") {
+        saladineye_ConfirmPhotoUploadResponse response = saladineye_ConfirmPhotoUploadResponse_init_zero;
+        pb_istream_t istream = pb_istream_from_buffer(mqttMessage, length);
+
+        if (!pb_decode(&istream, saladineye_ConfirmPhotoUploadResponse_fields, &response)) {
+          log_e("decoding protobuf saladineye_ConfirmPhotoUploadResponse failed");
+          return;
+        }
+
+        log_i("photo upload confirmed: %s", response.photo_path);
+        for (pb_size_t i = 0; i < response.quality_flags_count; i++) {
+          log_w("photo %s quality flag: %s", response.photo_path, response.quality_flags[i]);
         }
-        
-        // Close the file and HTTP connection
-        jpgFile.close();
-        http.end();
       } else {
         log_e("unknown MQTT method name");
       }
@@ -515,6 +851,67 @@ void mqttCallback(char *topic, byte *mqttMessage, unsigned int length)
   }
 }
 
+/**
+ * Upload the JPG file to the presigned URL, and once the object storage has
+ * it, confirm the upload to the media-service so the photo is checked and
+ * recorded.
+ */
+void uploadPhoto(const saladineye_GetPhotoUploadUrlResponse *response)
+{
+  // Open the JPG file
+  File jpgFile = SD_MMC.open(response->original_photo_path);
+  if (!jpgFile) {
+    log_e("failed to open JPG file to be uploaded");
+    lastCaptureResult = "sd_error";
+    return;
+  }
+
+  // Get the file size
+  size_t fileSize = jpgFile.size();
+
+  // Create an HTTP client
+  HTTPClient http;
+
+  // Begin the HTTP request
+  http.begin(response->upload_url);
+  http.addHeader("Content-Type", "image/jpeg");
+  http.addHeader("Content-Length", String(fileSize));
+
+  // Start the PUT request
+  int httpResponseCode = http.sendRequest("PUT", &jpgFile, fileSize);
+
+  // Close the file and HTTP connection
+  jpgFile.close();
+  http.end();
+
+  if (httpResponseCode < 200 || httpResponseCode >= 300) {
+    log_e("Error HTTP response code: %d", httpResponseCode);
+    lastCaptureResult = "upload_failed";
+    return;
+  }
+
+  log_i("OK HTTP response code: %d", httpResponseCode);
+
+  if (!confirmPhotoUpload(response->photo_path)) {
+    log_e("failed to confirm upload of %s", response->photo_path);
+  }
+}
+
+bool confirmPhotoUpload(const char *photoPath)
+{
+  saladineye_ConfirmPhotoUploadRequest request = saladineye_ConfirmPhotoUploadRequest_init_zero;
+  strncpy(request.photo_path, photoPath, sizeof(request.photo_path) - 1);
+
+  uint8_t buffer[saladineye_ConfirmPhotoUploadRequest_size];
+  pb_ostream_t stream = pb_ostream_from_buffer(buffer, sizeof(buffer));
+  if (!pb_encode(&stream, saladineye_ConfirmPhotoUploadRequest_fields, &request)) {
+    log_e("encoding protobuf saladineye_ConfirmPhotoUploadRequest failed");
+    return false;
+  }
+
+  return publishMediaServiceRequest("confirm-photo-upload", buffer, stream.bytes_written);
+}
+
 void mqttReconnect()
 {
   // Loop until we're reconnected
@@ -522,17 +919,41 @@ void mqttReconnect()
   {
     log_i("Attempting MQTT connection...");
 
-    // Attempt to connect
-    if (mqttClient.connect(mqttClientId, mqttUsername, mqttPassword))
+    // Attempt to connect, with the Will the broker publishes when the device
+    // drops off. Not retained, a retained Will would take the device as
+    // offline again once it is back.
+    if (mqttClient.connect(mqttClientId, mqttUsername, mqttPassword, mqttTopicLastWill, 1, false, ""))
     {
       log_i("MQTT connected");
 
-      // Subscribe to the command topic
+      // Subscribe to the responses of the services
       if (mqttClient.subscribe(mqttTopicResponseWildcard, 1)) {
         log_i("Subscribed to topic successfully");
       } else {
         log_e("Subscription failed");
       }
+
+      // Subscribe to the command topic
+      if (mqttClient.subscribe(mqttTopicCommand, 1)) {
+        log_i("Subscribed to command topic successfully");
+      } else {
+        log_e("Command topic subscription failed");
+      }
+
+      // The retained DeviceConfig arrives on subscribing, and every change
+      // after it
+      if (mqttClient.subscribe(mqttTopicConfig, 1)) {
+        log_i("Subscribed to config topic successfully");
+      } else {
+        log_e("Config topic subscription failed");
+      }
+
+      // In case the broker lost the retained DeviceConfig
+      requestDeviceConfig();
+
+      // Online right away, not only at the next heartbeat
+      lastStatus = millis();
+      publishDeviceStatus();
     }
     else
     {
@@ -545,6 +966,213 @@ void mqttReconnect()
   }
 }
 
+/**
+ * Apply the DeviceConfig of the camera-service, unless the device already
+ * has a newer one: the retained one and the get-device-config response can
+ * arrive in any order.
+ *
+ * The NTP client always queries port 123, the ntp_port of the config is not
+ * used.
+ */
+void applyDeviceConfig(const saladineye_DeviceConfig *config)
+{
+  // Version 0 is no configuration, like an empty retained message
+  if (config->version <= 0 || config->version < deviceConfigVersion) {
+    log_i("ignoring DeviceConfig version %lld, version %lld applied", config->version, deviceConfigVersion);
+    return;
+  }
+
+  utcOffsetInSeconds = config->utc_offset_seconds;
+  timeClient.setTimeOffset(utcOffsetInSeconds);
+
+  if (strlen(config->ntp_server) > 0) {
+    strncpy(ntpServerBuffer, config->ntp_server, sizeof(ntpServerBuffer) - 1);
+    ntpServerBuffer[sizeof(ntpServerBuffer) - 1] = '\0';
+    timeClient.setPoolServerName(ntpServerBuffer);
+  }
+
+  if (config->ntp_update_interval_seconds > 0) {
+    ntpUpdateIntervalMs = (unsigned long)config->ntp_update_interval_seconds * 1000;
+    timeClient.setUpdateInterval(ntpUpdateIntervalMs);
+  }
+
+  if (config->capture_interval_seconds > 0) {
+    captureIntervalMs = (unsigned long)config->capture_interval_seconds * 1000;
+  }
+
+  flashLedEnabled = config->flash_led_enabled;
+
+  bool changed = config->version != deviceConfigVersion;
+  deviceConfigVersion = config->version;
+
+  log_i("DeviceConfig version %lld applied", deviceConfigVersion);
+
+  // The RTC keeps local time, it is set again for a new UTC offset or NTP
+  // server
+  if (changed && timeClient.forceUpdate()) {
+    rtc.adjust(DateTime(timeClient.getEpochTime()));
+    log_i("RTC updated with NTP time");
+  }
+}
+
+/**
+ * Publish the DeviceStatus heartbeat, the camera-service takes the device as
+ * online as long as they keep coming.
+ */
+bool publishDeviceStatus()
+{
+  saladineye_DeviceStatus deviceStatus = saladineye_DeviceStatus_init_zero;
+  strncpy(deviceStatus.device_id, deviceId, sizeof(deviceStatus.device_id) - 1);
+  strncpy(deviceStatus.firmware_version, FIRMWARE_VERSION, sizeof(deviceStatus.firmware_version) - 1);
+  deviceStatus.uptime_seconds = millis() / 1000;
+  deviceStatus.rssi_dbm = WiFi.RSSI();
+  deviceStatus.free_heap_bytes = ESP.getFreeHeap();
+  deviceStatus.free_psram_bytes = ESP.getFreePsram();
+  deviceStatus.sd_free_bytes = SD_MMC.totalBytes() - SD_MMC.usedBytes();
+  deviceStatus.temperature_celsius = temperatureRead();
+  strncpy(deviceStatus.last_capture_result, lastCaptureResult, sizeof(deviceStatus.last_capture_result) - 1);
+  deviceStatus.last_capture_at = lastCaptureAt;
+
+  uint8_t buffer[saladineye_DeviceStatus_size];
+  pb_ostream_t stream = pb_ostream_from_buffer(buffer, sizeof(buffer));
+  if (!pb_encode(&stream, saladineye_DeviceStatus_fields, &deviceStatus)) {
+    log_e("encoding protobuf saladineye_DeviceStatus failed");
+    return false;
+  }
+
+  if (!mqttClient.publish(mqttTopicStatus, buffer, stream.bytes_written)) {
+    log_e("failed to publish device status");
+    return false;
+  }
+
+  return true;
+}
+
+bool requestDeviceConfig()
+{
+  saladineye_GetDeviceConfigRequest request = saladineye_GetDeviceConfigRequest_init_zero;
+  strncpy(request.device_id, deviceId, sizeof(request.device_id) - 1);
+
+  uint8_t buffer[saladineye_GetDeviceConfigRequest_size];
+  pb_ostream_t stream = pb_ostream_from_buffer(buffer, sizeof(buffer));
+  if (!pb_encode(&stream, saladineye_GetDeviceConfigRequest_fields, &request)) {
+    log_e("encoding protobuf saladineye_GetDeviceConfigRequest failed");
+    return false;
+  }
+
+  return publishMediaServiceRequest("get-device-config", buffer, stream.bytes_written);
+}
+
+/**
+ * Run a DeviceCommand of the camera-service, and acknowledge it with its
+ * status: succeeded, failed, rejected, or expired when it arrived after its
+ * expires_at.
+ */
+void handleDeviceCommand(byte *mqttMessage, unsigned int length)
+{
+  saladineye_DeviceCommand command = saladineye_DeviceCommand_init_zero;
+  pb_istream_t istream = pb_istream_from_buffer(mqttMessage, length);
+
+  if (!pb_decode(&istream, saladineye_DeviceCommand_fields, &command)) {
+    log_e("decoding protobuf saladineye_DeviceCommand failed");
+    return;
+  }
+
+  if (strcmp(command.device_id, deviceId) != 0) {
+    log_e("ignoring command %s of another device", command.command_id);
+    return;
+  }
+
+  // QoS 1, the broker may deliver the command again
+  if (lastCommandId[0] != '\0' && strcmp(command.command_id, lastCommandId) == 0) {
+    log_i("command %s already handled", command.command_id);
+    publishDeviceCommandAck(lastCommandId, lastCommandStatus, lastCommandMessage);
+    return;
+  }
+
+  // The RTC is in local time
+  int64_t nowUnix = (int64_t)rtc.now().unixtime() - utcOffsetInSeconds;
+
+  const char *status = "succeeded";
+  char message[128] = "";
+
+  if (command.expires_at > 0 && nowUnix > command.expires_at) {
+    status = "expired";
+  } else {
+    switch (command.which_command) {
+      case saladineye_DeviceCommand_capture_now_tag:
+        if (!capturePhotoContinuously()) {
+          status = "failed";
+          snprintf(message, sizeof(message), "failed to capture photo");
+        }
+        break;
+      case saladineye_DeviceCommand_set_capture_interval_tag:
+        captureIntervalMs = (unsigned long)command.command.set_capture_interval.interval_seconds * 1000;
+        snprintf(message, sizeof(message), "capture interval %u seconds", (unsigned)command.command.set_capture_interval.interval_seconds);
+        break;
+      case saladineye_DeviceCommand_set_flash_led_tag:
+        flashLedEnabled = command.command.set_flash_led.enabled;
+        snprintf(message, sizeof(message), "flash LED %s", flashLedEnabled ? "enabled" : "disabled");
+        break;
+      case saladineye_DeviceCommand_reboot_tag:
+        // Acknowledged first, the loop restarts the device after the delay
+        rebootAt = millis() + (unsigned long)command.command.reboot.delay_seconds * 1000;
+        rebootScheduled = true;
+        snprintf(message, sizeof(message), "rebooting in %u seconds", (unsigned)command.command.reboot.delay_seconds);
+        break;
+      case saladineye_DeviceCommand_resync_ntp_tag:
+        if (strlen(command.command.resync_ntp.ntp_server) > 0) {
+          strncpy(ntpServerBuffer, command.command.resync_ntp.ntp_server, sizeof(ntpServerBuffer) - 1);
+          ntpServerBuffer[sizeof(ntpServerBuffer) - 1] = '\0';
+          timeClient.setPoolServerName(ntpServerBuffer);
+        }
+        if (timeClient.forceUpdate()) {
+          rtc.adjust(DateTime(timeClient.getEpochTime()));
+          log_i("RTC updated with NTP time");
+        } else {
+          status = "failed";
+          snprintf(message, sizeof(message), "failed to get NTP time");
+        }
+        break;
+      default:
+        status = "rejected";
+        snprintf(message, sizeof(message), "unknown command");
+        break;
+    }
+  }
+
+  strncpy(lastCommandId, command.command_id, sizeof(lastCommandId) - 1);
+  strncpy(lastCommandStatus, status, sizeof(lastCommandStatus) - 1);
+  strncpy(lastCommandMessage, message, sizeof(lastCommandMessage) - 1);
+
+  log_i("command %s %s %s", command.command_id, status, message);
+  publishDeviceCommandAck(command.command_id, status, message);
+}
+
+bool publishDeviceCommandAck(const char *commandId, const char *status, const char *message)
+{
+  saladineye_DeviceCommandAck ack = saladineye_DeviceCommandAck_init_zero;
+  strncpy(ack.command_id, commandId, sizeof(ack.command_id) - 1);
+  strncpy(ack.device_id, deviceId, sizeof(ack.device_id) - 1);
+  strncpy(ack.status, status, sizeof(ack.status) - 1);
+  strncpy(ack.message, message, sizeof(ack.message) - 1);
+  ack.acked_at = (int64_t)rtc.now().unixtime() - utcOffsetInSeconds;
+
+  uint8_t buffer[saladineye_DeviceCommandAck_size];
+  pb_ostream_t stream = pb_ostream_from_buffer(buffer, sizeof(buffer));
+  if (!pb_encode(&stream, saladineye_DeviceCommandAck_fields, &ack)) {
+    log_e("encoding protobuf saladineye_DeviceCommandAck failed");
+    return false;
+  }
+
+  if (!mqttClient.publish(mqttTopicCommandAck, buffer, stream.bytes_written)) {
+    log_e("failed to publish command acknowledgement");
+    return false;
+  }
+
+  return true;
+}
+
 bool encode_string(pb_ostream_t* stream, const pb_field_t* field, void* const* arg)
 {
     const char* str = (const char*)(*arg);
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Connection state of the MQTT broker, served on /healthz
+type connectionState struct {
+	Connected  bool      `json:"connected"`
+	Since      time.Time `json:"since"`
+	LastError  string    `json:"last_error,omitempty"`
+	Reconnects int64     `json:"reconnects"`
+}
+
+var (
+	stateMu         sync.Mutex
+	state           = connectionState{Since: time.Now()}
+	connectedBefore bool
+)
+
+func setConnected() {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	if connectedBefore {
+		state.Reconnects++
+	}
+	connectedBefore = true
+	state.Connected = true
+	state.Since = time.Now()
+}
+
+func setDisconnected(err error) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	if state.Connected {
+		state.Since = time.Now()
+	}
+	state.Connected = false
+	if err != nil {
+		state.LastError = err.Error()
+	}
+}
+
+// Serve /healthz on HEALTH_PORT, 503 while disconnected from the broker
+func startHealthServer(port string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		stateMu.Lock()
+		current := state
+		stateMu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if !current.Connected {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(current)
+	})
+
+	go func() {
+		log.Fatal(http.ListenAndServe(port, mux))
+	}()
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"os"
 	"strings"
@@ -10,6 +9,8 @@ import (
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 	"github.com/go-redis/redis/v8"
+
+	"github.com/andypmw/saladin-eye-ai/mqttconn"
 )
 
 var (
@@ -42,28 +43,80 @@ func main() {
 		log.Fatal("MQTT credentials or broker address are not set in the environment variables")
 	}
 
+	clientId := os.Getenv("MQTT_CLIENT_ID")
+	if clientId == "" {
+		clientId = "saladin-eye-camera-mqtt-listener"
+	}
+
+	tlsConfig, err := mqttconn.TLSConfigFromEnv()
+	if err != nil {
+		log.Fatal("Invalid MQTT TLS config: ", err)
+	}
+
+	if healthPort := os.Getenv("HEALTH_PORT"); healthPort != "" {
+		startHealthServer(healthPort)
+	}
+
 	// MQTT broker connection options
 	opts := mqtt.NewClientOptions().AddBroker(mqttBroker)
-	opts.SetClientID("saladin-eye-camera-mqtt-listener")
+	opts.SetClientID(clientId)
 	opts.SetUsername(mqttUsername) // Add your username here
 	opts.SetPassword(mqttPassword) // Add your password here
 	opts.SetDefaultPublishHandler(messageHandler)
+	opts.SetTLSConfig(tlsConfig)
+	opts.SetKeepAlive(30 * time.Second)
 
-	// Create and start an MQTT client
-	client := mqtt.NewClient(opts)
-	if token := client.Connect(); token.Wait() && token.Error() != nil {
-		log.Fatal(token.Error())
-	}
+	// Persistent session, the subscription survives a reconnect of the
+	// listener. The client id must stay the same for it.
+	opts.SetCleanSession(os.Getenv("MQTT_CLEAN_SESSION") == "true")
 
-	// Subscribe to the topic
-	if token := client.Subscribe("saladin-eye/device/+/status", 0, nil); token.Wait() && token.Error() != nil {
-		log.Fatal(token.Error())
-	}
+	// Keep retrying the first connect and reconnect when the connection is
+	// lost, paho backs off exponentially up to the max interval
+	opts.SetConnectRetry(true)
+	opts.SetConnectRetryInterval(1 * time.Second)
+	opts.SetAutoReconnect(true)
+	opts.SetMaxReconnectInterval(2 * time.Minute)
+
+	// Subscribe on every connect, the broker may have dropped the session.
+	// The status is a heartbeat, a stale one queued by the broker is worth
+	// nothing, so QoS 0 is enough.
+	opts.SetOnConnectHandler(func(client mqtt.Client) {
+		log.Println("Connected to MQTT broker:", mqttBroker)
+		setConnected()
+
+		go subscribe(client, "saladin-eye/device/+/status")
+	})
+	opts.SetConnectionLostHandler(func(client mqtt.Client, err error) {
+		log.Println("MQTT connection lost:", err)
+		setDisconnected(err)
+	})
+	opts.SetReconnectingHandler(func(client mqtt.Client, opts *mqtt.ClientOptions) {
+		log.Println("Reconnecting to MQTT broker:", mqttBroker)
+	})
+
+	// Create and start an MQTT client, the token only completes once connected
+	client := mqtt.NewClient(opts)
+	client.Connect()
 
 	// Keep the program running
 	select {}
 }
 
+// Retry until subscribed, or the connection is lost and the next connect
+// subscribes again
+func subscribe(client mqtt.Client, topic string) {
+	for delay := time.Second; client.IsConnectionOpen(); delay = min(2*delay, time.Minute) {
+		token := client.Subscribe(topic, 0, nil)
+		if token.Wait() && token.Error() == nil {
+			log.Println("Subscribed to topic:", topic)
+			return
+		}
+
+		log.Println("Failed to subscribe, retrying:", token.Error())
+		time.Sleep(delay)
+	}
+}
+
 func messageHandler(client mqtt.Client, msg mqtt.Message) {
 	// Extract deviceId from the topic
 	topicParts := strings.Split(msg.Topic(), "/")
@@ -75,9 +128,16 @@ func messageHandler(client mqtt.Client, msg mqtt.Message) {
 	log.Println("Set online presence for device:", deviceId)
 
 	// Set the Redis key with TTL
-	key := fmt.Sprintf("saladin-eye:camera-service:device-online-presence:%s", deviceId)
-	err := rdb.Set(ctx, key, "1", 3*time.Minute).Err()
-	if err != nil {
-		log.Println("Failed to set Redis key:", err)
+	if err := setPresence(deviceId, time.Now()); err != nil {
+		log.Println("Failed to set online presence:", err)
+	}
+
+	// Older firmware sends an empty heartbeat, only the presence is set then
+	if len(msg.Payload()) == 0 {
+		return
+	}
+
+	if err := storeDeviceStatus(deviceId, msg.Payload()); err != nil {
+		log.Println("Failed to store device status:", err)
 	}
 }
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Read by camera-service, the keys and the field must stay the same on both
+// sides
+const (
+	presenceKeyFormat             = "saladin-eye:camera-service:device-online-presence:%s"
+	deviceKeyFormat               = "saladin-eye:camera-service:device:%s"
+	deviceOfflineThresholdField   = "offline_threshold_seconds"
+	defaultDeviceOfflineThreshold = 3 * time.Minute
+)
+
+/**
+ * Set the presence key of the device to the time of the heartbeat, in unix
+ * seconds. It expires after the offline threshold of the device in the
+ * registry, about three of its heartbeats, the camera is offline then.
+ */
+func setPresence(deviceId string, seenAt time.Time) error {
+	threshold := defaultDeviceOfflineThreshold
+
+	thresholdSeconds, err := rdb.HGet(ctx, fmt.Sprintf(deviceKeyFormat, deviceId), deviceOfflineThresholdField).Int64()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to get offline threshold from Redis: %w", err)
+	}
+	if thresholdSeconds > 0 {
+		threshold = time.Duration(thresholdSeconds) * time.Second
+	}
+
+	key := fmt.Sprintf(presenceKeyFormat, deviceId)
+	if err := rdb.Set(ctx, key, strconv.FormatInt(seenAt.Unix(), 10), threshold).Err(); err != nil {
+		return fmt.Errorf("failed to set Redis key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/camera-mqtt-listener/common/genproto"
+)
+
+// Read by camera-service, the keys must stay the same on both sides
+const (
+	deviceStatusKeyFormat        = "saladin-eye:camera-service:device-status:%s"
+	deviceStatusHistoryKeyFormat = "saladin-eye:camera-service:device-status-history:%s"
+)
+
+// How long the time series goes back. A device sends its status about every
+// minute, a week of it is about 10000 entries per device.
+const deviceStatusRetention = 7 * 24 * time.Hour
+
+/**
+ * Store the DeviceStatus in the payload as the latest status of the device,
+ * and add it to the device's time series, a sorted set by the time it
+ * arrived in milliseconds. Both expire when the device stops sending.
+ */
+func storeDeviceStatus(deviceId string, payload []byte) error {
+	deviceStatus := &genproto.DeviceStatus{}
+	if err := proto.Unmarshal(payload, deviceStatus); err != nil {
+		return fmt.Errorf("failed to unmarshal DeviceStatus: %w", err)
+	}
+
+	// The topic tells which device it is, not the payload
+	receivedAt := time.Now()
+	deviceStatus.DeviceId = deviceId
+	deviceStatus.ReceivedAt = receivedAt.Unix()
+
+	statusByteArr, err := proto.Marshal(deviceStatus)
+	if err != nil {
+		return fmt.Errorf("failed to marshal DeviceStatus: %w", err)
+	}
+
+	statusKey := fmt.Sprintf(deviceStatusKeyFormat, deviceId)
+	historyKey := fmt.Sprintf(deviceStatusHistoryKeyFormat, deviceId)
+	oldest := receivedAt.Add(-deviceStatusRetention).UnixMilli()
+
+	_, err = rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, statusKey, statusByteArr, deviceStatusRetention)
+		pipe.ZAdd(ctx, historyKey, &redis.Z{Score: float64(receivedAt.UnixMilli()), Member: statusByteArr})
+		pipe.ZRemRangeByScore(ctx, historyKey, "-inf", "("+strconv.FormatInt(oldest, 10))
+		pipe.Expire(ctx, historyKey, deviceStatusRetention)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store DeviceStatus in Redis: %w", err)
+	}
+
+	log.Printf("Stored status of device %s, firmware %s, uptime %ds, RSSI %ddBm", deviceId, deviceStatus.FirmwareVersion, deviceStatus.UptimeSeconds, deviceStatus.RssiDbm)
+
+	return nil
+}
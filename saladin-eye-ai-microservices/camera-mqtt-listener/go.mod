@@ -3,8 +3,10 @@ module github.com/andypmw/saladin-eye-ai/camera-mqtt-listener
 go 1.22
 
 require (
+	github.com/andypmw/saladin-eye-ai/mqttconn v0.0.0
 	github.com/eclipse/paho.mqtt.golang v1.5.0
 	github.com/go-redis/redis/v8 v8.11.5
+	google.golang.org/protobuf v1.34.2
 )
 
 require (
@@ -14,3 +16,5 @@ require (
 	golang.org/x/net v0.27.0 // indirect
 	golang.org/x/sync v0.7.0 // indirect
 )
+
+replace github.com/andypmw/saladin-eye-ai/mqttconn => ../mqttconn
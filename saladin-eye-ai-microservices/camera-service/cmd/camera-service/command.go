@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+/**
+ * Send a command to one device or a group. With wait_for_ack the response
+ * has the status the devices acknowledged, else the commands are just sent.
+ */
+func (handler CameraService) SendDeviceCommand(ctx context.Context, req *genproto.SendDeviceCommandRequest) (*genproto.SendDeviceCommandResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_SEND_DEVICE_COMMAND) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_SEND_DEVICE_COMMAND)
+	}
+
+	timeout := time.Duration(req.TimeoutSeconds) * time.Second
+	records, err := handler.commandService.Send(ctx, req.DeviceIds, req.Command, timeout, req.WaitForAck)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to send device command: %v", err)
+	}
+
+	return &genproto.SendDeviceCommandResponse{
+		Commands: records,
+	}, nil
+}
+
+func (handler CameraService) GetDeviceCommand(ctx context.Context, req *genproto.GetDeviceCommandRequest) (*genproto.GetDeviceCommandResponse, error) {
+	record, err := handler.commandService.Get(ctx, req.CommandId)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get device command: %v", err)
+	}
+
+	return &genproto.GetDeviceCommandResponse{
+		Command: record,
+	}, nil
+}
+
+func (handler CameraService) ListDeviceCommands(ctx context.Context, req *genproto.ListDeviceCommandsRequest) (*genproto.ListDeviceCommandsResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	records, err := handler.commandService.List(ctx, deviceId, int(req.Limit))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list device commands: %v", err)
+	}
+
+	return &genproto.ListDeviceCommandsResponse{
+		DeviceId: deviceId,
+		Commands: records,
+	}, nil
+}
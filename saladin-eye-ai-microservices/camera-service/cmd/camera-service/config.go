@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+func (handler CameraService) GetDeviceConfig(ctx context.Context, req *genproto.GetDeviceConfigRequest) (*genproto.GetDeviceConfigResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	config, err := handler.deviceConfigService.Get(ctx, deviceId)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get device config: %v", err)
+	}
+
+	return &genproto.GetDeviceConfigResponse{
+		DeviceId: deviceId,
+		Config:   config,
+	}, nil
+}
+
+func (handler CameraService) SetDeviceConfig(ctx context.Context, req *genproto.SetDeviceConfigRequest) (*genproto.SetDeviceConfigResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_DEVICE_CONFIG) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_DEVICE_CONFIG)
+	}
+
+	config, err := handler.deviceConfigService.Set(ctx, deviceId, req.Config)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to set device config: %v", err)
+	}
+
+	return &genproto.SetDeviceConfigResponse{
+		DeviceId: deviceId,
+		Config:   config,
+	}, nil
+}
+
+func (handler CameraService) ListDeviceConfigHistory(ctx context.Context, req *genproto.ListDeviceConfigHistoryRequest) (*genproto.ListDeviceConfigHistoryResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	configs, err := handler.deviceConfigService.History(ctx, deviceId, int(req.Limit))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list device config history: %v", err)
+	}
+
+	return &genproto.ListDeviceConfigHistoryResponse{
+		DeviceId: deviceId,
+		Configs:  configs,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+func (handler CameraService) CreateDevice(ctx context.Context, req *genproto.CreateDeviceRequest) (*genproto.CreateDeviceResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_DEVICES) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_DEVICES)
+	}
+
+	device, err := handler.registryService.Create(ctx, req.Device)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create device: %v", err)
+	}
+
+	return &genproto.CreateDeviceResponse{
+		Device: device,
+	}, nil
+}
+
+func (handler CameraService) GetDevice(ctx context.Context, req *genproto.GetDeviceRequest) (*genproto.GetDeviceResponse, error) {
+	device, err := handler.registryService.Get(ctx, strings.TrimSpace(req.DeviceId))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get device: %v", err)
+	}
+
+	return &genproto.GetDeviceResponse{
+		Device: device,
+	}, nil
+}
+
+func (handler CameraService) UpdateDevice(ctx context.Context, req *genproto.UpdateDeviceRequest) (*genproto.UpdateDeviceResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_DEVICES) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_DEVICES)
+	}
+
+	device, err := handler.registryService.Update(ctx, req.Device)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update device: %v", err)
+	}
+
+	return &genproto.UpdateDeviceResponse{
+		Device: device,
+	}, nil
+}
+
+func (handler CameraService) DeleteDevice(ctx context.Context, req *genproto.DeleteDeviceRequest) (*genproto.DeleteDeviceResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_DEVICES) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_DEVICES)
+	}
+
+	if err := handler.registryService.Delete(ctx, deviceId); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete device: %v", err)
+	}
+
+	return &genproto.DeleteDeviceResponse{
+		DeviceId: deviceId,
+	}, nil
+}
+
+func (handler CameraService) ListDevices(ctx context.Context, req *genproto.ListDevicesRequest) (*genproto.ListDevicesResponse, error) {
+	devices, nextPageToken, err := handler.registryService.List(ctx, strings.TrimSpace(req.Owner), strings.TrimSpace(req.Tag), int(req.PageSize), strings.TrimSpace(req.PageToken))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list devices: %v", err)
+	}
+
+	return &genproto.ListDevicesResponse{
+		Devices:       devices,
+		NextPageToken: nextPageToken,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+func (handler CameraService) CreateEnrolmentToken(ctx context.Context, req *genproto.CreateEnrolmentTokenRequest) (*genproto.CreateEnrolmentTokenResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_ENROL_DEVICES) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_ENROL_DEVICES)
+	}
+
+	token, enrolmentToken, err := handler.enrolmentService.CreateToken(ctx, req.Device, time.Duration(req.TtlSeconds)*time.Second)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create enrolment token: %v", err)
+	}
+
+	return &genproto.CreateEnrolmentTokenResponse{
+		Token:          token,
+		EnrolmentToken: enrolmentToken,
+	}, nil
+}
+
+func (handler CameraService) RevokeEnrolmentToken(ctx context.Context, req *genproto.RevokeEnrolmentTokenRequest) (*genproto.RevokeEnrolmentTokenResponse, error) {
+	tokenId := strings.TrimSpace(req.TokenId)
+
+	if !hasPermission(ctx, constants.PERMISSION_ENROL_DEVICES) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_ENROL_DEVICES)
+	}
+
+	if err := handler.enrolmentService.RevokeToken(ctx, tokenId); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke enrolment token: %v", err)
+	}
+
+	return &genproto.RevokeEnrolmentTokenResponse{
+		TokenId: tokenId,
+	}, nil
+}
+
+/**
+ * POST /enrol, a new device enrols with an EnrolDeviceRequest and gets an
+ * EnrolDeviceResponse, both protobuf. The error is in the status code and a
+ * plain text body.
+ */
+func (handler CameraService) handleEnrol(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, constants.ENROLMENT_REQUEST_MAX_BYTES))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	request := &genproto.EnrolDeviceRequest{}
+	if err := proto.Unmarshal(body, request); err != nil {
+		http.Error(w, "invalid EnrolDeviceRequest", http.StatusBadRequest)
+		return
+	}
+
+	response, err := handler.enrolmentService.Enrol(r.Context(), request)
+	if err != nil {
+		log.Warn().Msgf("enrolment from %s failed: %v", r.RemoteAddr, err)
+
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		case codes.Unauthenticated:
+			http.Error(w, status.Convert(err).Message(), http.StatusUnauthorized)
+		default:
+			http.Error(w, "enrolment failed, try again later", http.StatusServiceUnavailable)
+		}
+		return
+	}
+
+	responseByteArr, err := proto.Marshal(response)
+	if err != nil {
+		log.Error().Msgf("failed to marshal EnrolDeviceResponse: %v", err)
+		http.Error(w, "enrolment failed, try again later", http.StatusInternalServerError)
+		return
+	}
+
+	// The credentials must not end up in a cache
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(responseByteArr)
+}
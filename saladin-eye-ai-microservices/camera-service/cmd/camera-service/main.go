@@ -2,11 +2,23 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"net"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/cache"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
 	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/mediaservice"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/mqtt"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/camerastatus"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/command"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/deviceconfig"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/devicestatus"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/enrolment"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/registry"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/uptime"
 	"github.com/go-redis/redis/v8"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -15,44 +27,37 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-var (
-	ctx = context.Background()
-	rdb *redis.Client
-)
-
-func init() {
-	// Initialize Redis client
-	redisAddr := os.Getenv("REDIS_ADDR")
-	if redisAddr == "" {
-		log.Fatal().Msg("redis address is not set in the environment variables")
-	}
-
-	rdb = redis.NewClient(&redis.Options{
-		Addr: redisAddr,
-	})
-}
-
 type CameraService struct {
 	genproto.UnimplementedCameraServiceServer
+	rdb                 redis.Cmdable
+	mqttClient          *mqtt.Client
+	commandService      command.CommandServiceIface
+	cameraStatusService camerastatus.CameraStatusServiceIface
+	deviceConfigService deviceconfig.DeviceConfigServiceIface
+	deviceStatusService devicestatus.DeviceStatusServiceIface
+	registryService     registry.RegistryServiceIface
+	enrolmentService    enrolment.EnrolmentServiceIface
+	uptimeService       uptime.UptimeServiceIface
 }
 
-func (CameraService) GetCameraStatus(_ context.Context, req *genproto.GetCameraStatusRequest) (*genproto.GetCameraStatusResponse, error) {
+func (handler CameraService) GetCameraStatus(ctx context.Context, req *genproto.GetCameraStatusRequest) (*genproto.GetCameraStatusResponse, error) {
 	deviceId := req.DeviceId
 
 	log.Debug().Msgf("GetCameraStatus for device_id %s", deviceId)
 
-	// Get the camera online presence status from Redis
-	key := fmt.Sprintf("saladin-eye:camera-service:device-online-presence:%s", deviceId)
-	cameraStatus, err := rdb.Get(ctx, key).Result()
+	statuses, err := handler.cameraStatusService.Statuses(ctx, []string{deviceId})
 	if err != nil {
-		if err != redis.Nil {
-			return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+		if _, ok := status.FromError(err); ok {
+			return nil, err
 		}
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
 	}
 
 	response := genproto.GetCameraStatusResponse{
 		DeviceId: deviceId,
-		IsOnline: cameraStatus == "1",
+		IsOnline: statuses[0].IsOnline,
+		LastSeen: statuses[0].LastSeen,
+		Status:   statuses[0].Status,
 	}
 
 	return &response, nil
@@ -62,9 +67,56 @@ func main() {
 	// Log setup
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
+	mqttClient := mqtt.New()
+	deviceConfigService := deviceconfig.New(cache.New(), mqttClient)
+	registryService := registry.New(cache.New())
+
+	// MQTT_DEVICE_BROKER is the broker address the enrolled devices get, their
+	// signing keys are registered with the media-service at MEDIA_SERVICE_ADDR
+	cameraService := CameraService{
+		rdb:                 cache.New(),
+		mqttClient:          mqttClient,
+		commandService:      command.New(cache.New(), mqttClient),
+		cameraStatusService: camerastatus.New(cache.New()),
+		deviceConfigService: deviceConfigService,
+		deviceStatusService: devicestatus.New(cache.New()),
+		registryService:     registryService,
+		enrolmentService:    enrolment.New(cache.New(), registryService, deviceConfigService, mediaservice.New(), os.Getenv("MQTT_DEVICE_BROKER")),
+		uptimeService:       uptime.New(cache.New(), registryService),
+	}
+
+	// The devices acknowledge the commands on their ack topic
+	mqttClient.Subscribe(sharedTopic(constants.MQTT_TOPIC_COMMAND_ACK_SUBSCRIBE), func(topic string, payload []byte) {
+		if err := cameraService.commandService.HandleAck(context.Background(), topic, payload); err != nil {
+			log.Error().Msgf("failed to handle command acknowledgement on %s: %v", topic, err)
+		}
+	})
+	mqttClient.Subscribe(sharedTopic(constants.MQTT_TOPIC_DEVICE_LAST_WILL_SUBSCRIBE), cameraService.handleLastWill)
+
+	// The online/offline transitions of the cameras, from the presence keys
+	go cameraService.cameraStatusService.Watch(context.Background())
+
+	// Optional, the enrolment of new devices over HTTP. Behind a TLS
+	// terminating proxy, the response has the device credentials.
+	if httpPort := os.Getenv("HTTP_PORT"); httpPort != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /enrol", cameraService.handleEnrol)
+
+		httpServer := &http.Server{
+			Addr:              httpPort,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+		}
+
+		go func() {
+			log.Fatal().Err(httpServer.ListenAndServe()).Msg("HTTP server stopped")
+		}()
+	}
+
 	// Start gRPC server
 	server := grpc.NewServer()
-	var cameraService CameraService
 	genproto.RegisterCameraServiceServer(server, cameraService)
 
 	log.Info().Msg("SaladinEye.AI - gRPC Server - Camera Service")
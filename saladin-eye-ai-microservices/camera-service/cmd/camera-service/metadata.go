@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+)
+
+func hasPermission(ctx context.Context, permission string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, value := range md.Get(constants.GRPC_METADATA_PERMISSIONS) {
+		for _, granted := range strings.Split(value, ",") {
+			if strings.TrimSpace(granted) == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
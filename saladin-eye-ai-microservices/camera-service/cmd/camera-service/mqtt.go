@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+)
+
+// With MQTT_SHARED_SUBSCRIPTION_GROUP set, only one instance gets a message
+func sharedTopic(topicFilter string) string {
+	if sharedGroup := os.Getenv("MQTT_SHARED_SUBSCRIPTION_GROUP"); sharedGroup != "" {
+		return fmt.Sprintf(constants.MQTT_SHARED_SUBSCRIPTION_FORMAT, sharedGroup, topicFilter)
+	}
+
+	return topicFilter
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+func (handler CameraService) GetDeviceStatus(ctx context.Context, req *genproto.GetDeviceStatusRequest) (*genproto.GetDeviceStatusResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	online, deviceStatus, err := handler.deviceStatusService.Get(ctx, deviceId)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get device status: %v", err)
+	}
+
+	return &genproto.GetDeviceStatusResponse{
+		DeviceId: deviceId,
+		IsOnline: online,
+		Status:   deviceStatus,
+	}, nil
+}
+
+func (handler CameraService) ListDeviceStatusHistory(ctx context.Context, req *genproto.ListDeviceStatusHistoryRequest) (*genproto.ListDeviceStatusHistoryResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	var from, to time.Time
+	if req.From != 0 {
+		from = time.Unix(req.From, 0)
+	}
+	if req.To != 0 {
+		to = time.Unix(req.To, 0)
+	}
+
+	statuses, err := handler.deviceStatusService.History(ctx, deviceId, from, to, int(req.Limit))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list device status history: %v", err)
+	}
+
+	return &genproto.ListDeviceStatusHistoryResponse{
+		DeviceId: deviceId,
+		Statuses: statuses,
+	}, nil
+}
+
+func (handler CameraService) GetCameraStatusHistory(ctx context.Context, req *genproto.GetCameraStatusHistoryRequest) (*genproto.GetCameraStatusHistoryResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	var from, to time.Time
+	if req.From != 0 {
+		from = time.Unix(req.From, 0)
+	}
+	if req.To != 0 {
+		to = time.Unix(req.To, 0)
+	}
+
+	current, periods, err := handler.cameraStatusService.History(ctx, deviceId, from, to, int(req.Limit))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get camera status history: %v", err)
+	}
+
+	return &genproto.GetCameraStatusHistoryResponse{
+		DeviceId: deviceId,
+		Current:  current,
+		Periods:  periods,
+	}, nil
+}
+
+// The broker publishes the Last Will of a device on:
+//
+//	saladin-eye/device/[device-id]/last-will
+func (handler CameraService) handleLastWill(topic string, payload []byte) {
+	topicParts := strings.Split(topic, "/")
+	if len(topicParts) != 4 {
+		log.Error().Msgf("invalid last will topic format: %s", topic)
+		return
+	}
+
+	if err := handler.cameraStatusService.LastWill(context.Background(), topicParts[2]); err != nil {
+		log.Error().Msgf("failed to handle last will on %s: %v", topic, err)
+	}
+}
+
+func (handler CameraService) GetCameraStatuses(ctx context.Context, req *genproto.GetCameraStatusesRequest) (*genproto.GetCameraStatusesResponse, error) {
+	deviceIds := make([]string, len(req.DeviceIds))
+	for i, deviceId := range req.DeviceIds {
+		deviceIds[i] = strings.TrimSpace(deviceId)
+	}
+
+	statuses, err := handler.cameraStatusService.Statuses(ctx, deviceIds)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get camera statuses: %v", err)
+	}
+
+	return &genproto.GetCameraStatusesResponse{
+		Statuses: statuses,
+	}, nil
+}
+
+func (handler CameraService) WatchCameraStatus(req *genproto.WatchCameraStatusRequest, stream grpc.ServerStreamingServer[genproto.WatchCameraStatusResponse]) error {
+	deviceIds := make([]string, len(req.DeviceIds))
+	for i, deviceId := range req.DeviceIds {
+		deviceIds[i] = strings.TrimSpace(deviceId)
+	}
+
+	err := handler.cameraStatusService.Stream(stream.Context(), deviceIds, func(cameraStatus *genproto.CameraStatus) error {
+		return stream.Send(&genproto.WatchCameraStatusResponse{Status: cameraStatus})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		if stream.Context().Err() != nil {
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+		return status.Errorf(codes.Internal, "failed to watch camera status: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/uptime"
+)
+
+func (handler CameraService) GetUptimeReport(ctx context.Context, req *genproto.GetUptimeReportRequest) (*genproto.GetUptimeReportResponse, error) {
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format != "" && format != uptime.FORMAT_JSON && format != uptime.FORMAT_CSV {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported format: %s", req.Format)
+	}
+
+	deviceIds := make([]string, len(req.DeviceIds))
+	for i, deviceId := range req.DeviceIds {
+		deviceIds[i] = strings.TrimSpace(deviceId)
+	}
+
+	var from, to time.Time
+	if req.From != 0 {
+		from = time.Unix(req.From, 0)
+	}
+	if req.To != 0 {
+		to = time.Unix(req.To, 0)
+	}
+
+	report, err := handler.uptimeService.Report(ctx, deviceIds, strings.TrimSpace(req.Owner), strings.TrimSpace(req.Site), from, to)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get uptime report: %v", err)
+	}
+
+	contentType, content, err := handler.uptimeService.Render(report, format)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to render uptime report: %v", err)
+	}
+
+	return &genproto.GetUptimeReportResponse{
+		Report:      report,
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+)
+
+// Singleton
+var (
+	redisClient *redis.Client
+	once        sync.Once
+)
+
+func New() redis.Cmdable {
+	once.Do(func() {
+		redisAddr := os.Getenv("REDIS_ADDR")
+		if redisAddr == "" {
+			log.Fatal().Msg("redis address is not set in the environment variables")
+		}
+
+		redisClient = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+	})
+	return redisClient
+}
+
+// Subscribe subscribes to the Pub/Sub channels, on its own connection
+func Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	New()
+	return redisClient.Subscribe(ctx, channels...)
+}
+
+// PSubscribe subscribes to the Pub/Sub channels matching the patterns, on its
+// own connection
+func PSubscribe(ctx context.Context, patterns ...string) *redis.PubSub {
+	New()
+	return redisClient.PSubscribe(ctx, patterns...)
+}
@@ -0,0 +1,23 @@
+package constants
+
+const (
+	DEVICE_COMMAND_DEFAULT_TIMEOUT_SECONDS = 30
+	DEVICE_COMMAND_MAX_TIMEOUT_SECONDS     = 3600
+	DEVICE_COMMAND_MAX_DEVICES             = 100
+)
+
+// The command history, per device
+const (
+	DEVICE_COMMAND_HISTORY_TTL_DAYS    = 30
+	DEVICE_COMMAND_HISTORY_MAX_ENTRIES = 100
+	DEVICE_COMMAND_LIST_DEFAULT_LIMIT  = 20
+	DEVICE_COMMAND_LIST_MAX_LIMIT      = 100
+)
+
+// Accepted command parameters
+const (
+	CAPTURE_INTERVAL_MIN_SECONDS = 5
+	CAPTURE_INTERVAL_MAX_SECONDS = 86400
+	REBOOT_MAX_DELAY_SECONDS     = 3600
+	NTP_SERVER_MAX_LENGTH        = 63
+)
@@ -0,0 +1,29 @@
+package constants
+
+// Defaults of the device configuration, what the firmware has compiled in
+const (
+	DEVICE_CONFIG_DEFAULT_UTC_OFFSET_SECONDS          = 7 * 3600
+	DEVICE_CONFIG_DEFAULT_NTP_SERVER                  = "pool.ntp.org"
+	DEVICE_CONFIG_DEFAULT_NTP_PORT                    = 123
+	DEVICE_CONFIG_DEFAULT_NTP_UPDATE_INTERVAL_SECONDS = 60
+	DEVICE_CONFIG_DEFAULT_CAPTURE_INTERVAL_SECONDS    = 10
+)
+
+// Accepted configuration values
+const (
+	DEVICE_CONFIG_MIN_UTC_OFFSET_SECONDS          = -12 * 3600
+	DEVICE_CONFIG_MAX_UTC_OFFSET_SECONDS          = 14 * 3600
+	DEVICE_CONFIG_UTC_OFFSET_STEP_SECONDS         = 15 * 60
+	DEVICE_CONFIG_MIN_NTP_UPDATE_INTERVAL_SECONDS = 60
+	DEVICE_CONFIG_MAX_NTP_UPDATE_INTERVAL_SECONDS = 86400
+)
+
+// The previous configurations of every device
+const (
+	DEVICE_CONFIG_HISTORY_MAX_ENTRIES = 50
+	DEVICE_CONFIG_LIST_DEFAULT_LIMIT  = 20
+)
+
+// Attempts of a configuration change that other changes keep taking the
+// version of
+const DEVICE_CONFIG_SET_MAX_ATTEMPTS = 5
@@ -0,0 +1,12 @@
+package constants
+
+// Device registry limits
+const (
+	DEVICE_NAME_MAX_LENGTH        = 64
+	DEVICE_LOCATION_MAX_LENGTH    = 128
+	DEVICE_OWNER_MAX_LENGTH       = 64
+	DEVICE_MAX_TAGS               = 10
+	DEVICE_TAG_MAX_LENGTH         = 32
+	DEVICE_LIST_DEFAULT_PAGE_SIZE = 50
+	DEVICE_LIST_MAX_PAGE_SIZE     = 500
+)
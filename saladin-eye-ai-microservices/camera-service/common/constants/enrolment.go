@@ -0,0 +1,31 @@
+package constants
+
+// Device enrolment
+const (
+	ENROLMENT_TOKEN_DEFAULT_TTL_SECONDS = 24 * 3600
+	ENROLMENT_TOKEN_MAX_TTL_SECONDS     = 30 * 24 * 3600
+	ENROLMENT_REQUEST_MAX_BYTES         = 4096
+	ENROLMENT_DEVICE_ID_ATTEMPTS        = 5
+	ENROLMENT_MQTT_PASSWORD_BYTES       = 32
+)
+
+// The device ids are 9 of these characters
+const DEVICE_ID_ALPHABET = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// The MQTT username and client id of a device are the prefix and its id, as
+// the firmware has them
+const MQTT_DEVICE_USERNAME_PREFIX = "SaladinEye-ESP32S3-"
+
+// The MQTT password hash of a device, checked by the broker with the pbkdf2
+// hasher of mosquitto-go-auth:
+//
+//	PBKDF2$sha512$[iterations]$[base64 salt]$[base64 hash]
+//
+// The broker reads the iterations and the key length from the hash, only
+// auth_opt_hasher pbkdf2 and auth_opt_hasher_salt_encoding base64 must be set
+const (
+	MQTT_PASSWORD_PBKDF2_ALGORITHM  = "sha512"
+	MQTT_PASSWORD_PBKDF2_ITERATIONS = 100000
+	MQTT_PASSWORD_PBKDF2_SALT_BYTES = 16
+	MQTT_PASSWORD_PBKDF2_KEY_BYTES  = 64
+)
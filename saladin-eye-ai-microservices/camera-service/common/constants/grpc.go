@@ -0,0 +1,16 @@
+package constants
+
+// The caller (the back-end) passes the permissions of the signed in user
+// as a comma separated list in this gRPC metadata
+const GRPC_METADATA_PERMISSIONS = "x-saladin-eye-permissions"
+
+const (
+	PERMISSION_SEND_DEVICE_COMMAND  = "camera:send-command"
+	PERMISSION_MANAGE_DEVICE_CONFIG = "camera:manage-config"
+	PERMISSION_MANAGE_DEVICES       = "camera:manage-devices"
+	PERMISSION_ENROL_DEVICES        = "camera:enrol-devices"
+)
+
+// The permission camera-service calls media-service with, to register the
+// signing key of an enrolled device
+const PERMISSION_MEDIA_MANAGE_DEVICE_KEYS = "media:manage-device-keys"
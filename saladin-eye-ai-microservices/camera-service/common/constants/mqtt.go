@@ -0,0 +1,29 @@
+package constants
+
+// Commands are published to the device, which answers on the ack topic
+const (
+	MQTT_TOPIC_DEVICE_COMMAND_FORMAT = "saladin-eye/device/%s/command"
+	MQTT_TOPIC_COMMAND_ACK_PREFIX    = "saladin-eye/server/camera-service/command-ack"
+	MQTT_TOPIC_COMMAND_ACK_SUBSCRIBE = MQTT_TOPIC_COMMAND_ACK_PREFIX + "/+"
+)
+
+// With MQTT_SHARED_SUBSCRIPTION_GROUP set, the instances subscribe to
+// $share/[group]/[topic] and the broker hands every message to one of them
+const MQTT_SHARED_SUBSCRIPTION_FORMAT = "$share/%s/%s"
+
+// The broker connection, reconnects back off exponentially up to the max
+// interval
+const (
+	MQTT_KEEP_ALIVE_SECONDS      = 30
+	MQTT_RECONNECT_MIN_SECONDS   = 1
+	MQTT_RECONNECT_MAX_SECONDS   = 120
+	MQTT_PUBLISH_TIMEOUT_SECONDS = 10
+)
+
+// The configuration of the device is retained on its config topic, so the
+// device gets it when it subscribes on boot, and every change live
+const MQTT_TOPIC_DEVICE_CONFIG_FORMAT = "saladin-eye/device/%s/config"
+
+// The Last Will of the device, set when it connects, the broker publishes it
+// when the device drops off without disconnecting
+const MQTT_TOPIC_DEVICE_LAST_WILL_SUBSCRIBE = "saladin-eye/device/+/last-will"
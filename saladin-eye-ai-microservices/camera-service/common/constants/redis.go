@@ -0,0 +1,62 @@
+package constants
+
+// Set by the camera-mqtt-listener on every status message of the device, to
+// its unix time in seconds, and expires after the device's offline threshold
+const REDIS_KEY_DEVICE_ONLINE_PRESENCE_FORMAT = "saladin-eye:camera-service:device-online-presence:%s"
+
+// Device commands, the record of every command and the command ids of every
+// device by the time they were sent
+const (
+	REDIS_KEY_DEVICE_COMMAND_FORMAT         = "saladin-eye:camera-service:device-command:%s"
+	REDIS_KEY_DEVICE_COMMAND_HISTORY_FORMAT = "saladin-eye:camera-service:device-commands:%s"
+	REDIS_CHANNEL_DEVICE_COMMAND_ACK        = "saladin-eye:camera-service:device-command-ack"
+)
+
+// Device configuration, the current one, its version counter and the
+// previous ones, newest first
+const (
+	REDIS_KEY_DEVICE_CONFIG_FORMAT         = "saladin-eye:camera-service:device-config:%s"
+	REDIS_KEY_DEVICE_CONFIG_VERSION_FORMAT = "saladin-eye:camera-service:device-config:version:%s"
+	REDIS_KEY_DEVICE_CONFIG_HISTORY_FORMAT = "saladin-eye:camera-service:device-config-history:%s"
+)
+
+// Device status, the last heartbeat of the device and its time series by the
+// time it arrived in milliseconds. Set by the camera-mqtt-listener.
+const (
+	REDIS_KEY_DEVICE_STATUS_FORMAT         = "saladin-eye:camera-service:device-status:%s"
+	REDIS_KEY_DEVICE_STATUS_HISTORY_FORMAT = "saladin-eye:camera-service:device-status-history:%s"
+)
+
+// Device registry, a hash of every registered device, also read by
+// media-service, and the device ids sorted for listing
+const (
+	REDIS_KEY_DEVICE_FORMAT = "saladin-eye:camera-service:device:%s"
+	REDIS_KEY_DEVICES       = "saladin-eye:camera-service:devices"
+)
+
+// Enrolment tokens, by token id, until they are used or expire
+const REDIS_KEY_ENROLMENT_TOKEN_FORMAT = "saladin-eye:camera-service:enrolment-token:%s"
+
+// MQTT credentials of the enrolled devices, in the layout of the Redis backend
+// of mosquitto-go-auth: the password hash under the username, and the topics
+// the device may subscribe to, read and write in the sets of its ACLs
+const (
+	REDIS_KEY_MQTT_PASSWORD_FORMAT       = "%s"
+	REDIS_KEY_MQTT_SUBSCRIBE_ACLS_FORMAT = "%s:sacls"
+	REDIS_KEY_MQTT_READ_ACLS_FORMAT      = "%s:racls"
+	REDIS_KEY_MQTT_WRITE_ACLS_FORMAT     = "%s:wacls"
+)
+
+// Camera status, the current status of every camera seen, the online ones,
+// and the periods it was online or offline by the time they started. The
+// transitions are published on the channel.
+const (
+	REDIS_KEY_CAMERA_STATUS_FORMAT         = "saladin-eye:camera-service:camera-status:%s"
+	REDIS_KEY_CAMERA_STATUS_HISTORY_FORMAT = "saladin-eye:camera-service:camera-status-history:%s"
+	REDIS_KEY_CAMERAS_ONLINE               = "saladin-eye:camera-service:cameras-online"
+	REDIS_CHANNEL_CAMERA_STATUS            = "saladin-eye:camera-service:camera-status"
+)
+
+// Keyspace notifications of the presence keys, only sent when Redis has
+// notify-keyspace-events with at least K, $ and x
+const REDIS_KEYSPACE_DEVICE_ONLINE_PRESENCE_PATTERN = "__keyspace@*__:saladin-eye:camera-service:device-online-presence:*"
@@ -0,0 +1,39 @@
+package constants
+
+// The device status time series, kept for a week by the camera-mqtt-listener
+const (
+	DEVICE_STATUS_HISTORY_DEFAULT_HOURS = 24
+	DEVICE_STATUS_LIST_DEFAULT_LIMIT    = 1000
+	DEVICE_STATUS_LIST_MAX_LIMIT        = 10000
+)
+
+// Camera status history. The sweeper catches the transitions the keyspace
+// notifications missed, or all of them when Redis doesn't send any.
+const (
+	CAMERA_STATUS_SWEEP_INTERVAL_SECONDS = 30
+	CAMERA_STATUS_HISTORY_RETENTION_DAYS = 90
+	CAMERA_STATUS_HISTORY_DEFAULT_DAYS   = 7
+	CAMERA_STATUS_HISTORY_DEFAULT_LIMIT  = 100
+	CAMERA_STATUS_HISTORY_MAX_LIMIT      = 1000
+)
+
+// The camera is offline when no heartbeat came for its offline threshold, the
+// TTL the camera-mqtt-listener sets on the presence key. Past half of it the
+// camera missed a heartbeat, it is degraded.
+const (
+	DEVICE_OFFLINE_THRESHOLD_DEFAULT_SECONDS = 180
+	DEVICE_OFFLINE_THRESHOLD_MIN_SECONDS     = 10
+	DEVICE_OFFLINE_THRESHOLD_MAX_SECONDS     = 24 * 60 * 60
+
+	// Degraded is not an event, the watched cameras are checked for it
+	CAMERA_STATUS_STREAM_RECHECK_SECONDS = 5
+)
+
+// The cameras of one GetCameraStatuses or WatchCameraStatus call
+const CAMERA_STATUS_MAX_DEVICE_IDS = 500
+
+// Uptime reports, from the camera status history
+const (
+	UPTIME_REPORT_DEFAULT_DAYS = 30
+	UPTIME_REPORT_MAX_DEVICES  = 1000
+)
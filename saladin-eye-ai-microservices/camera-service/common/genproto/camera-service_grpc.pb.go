@@ -1,121 +0,0 @@
-// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
-// versions:
-// - protoc-gen-go-grpc v1.5.1
-// - protoc             v5.27.3
-// source: camera-service.proto
-
-package genproto
-
-import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-)
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.64.0 or later.
-const _ = grpc.SupportPackageIsVersion9
-
-const (
-	CameraService_GetCameraStatus_FullMethodName = "/saladineye.CameraService/GetCameraStatus"
-)
-
-// CameraServiceClient is the client API for CameraService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type CameraServiceClient interface {
-	GetCameraStatus(ctx context.Context, in *GetCameraStatusRequest, opts ...grpc.CallOption) (*GetCameraStatusResponse, error)
-}
-
-type cameraServiceClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewCameraServiceClient(cc grpc.ClientConnInterface) CameraServiceClient {
-	return &cameraServiceClient{cc}
-}
-
-func (c *cameraServiceClient) GetCameraStatus(ctx context.Context, in *GetCameraStatusRequest, opts ...grpc.CallOption) (*GetCameraStatusResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetCameraStatusResponse)
-	err := c.cc.Invoke(ctx, CameraService_GetCameraStatus_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-// CameraServiceServer is the server API for CameraService service.
-// All implementations must embed UnimplementedCameraServiceServer
-// for forward compatibility.
-type CameraServiceServer interface {
-	GetCameraStatus(context.Context, *GetCameraStatusRequest) (*GetCameraStatusResponse, error)
-	mustEmbedUnimplementedCameraServiceServer()
-}
-
-// UnimplementedCameraServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedCameraServiceServer struct{}
-
-func (UnimplementedCameraServiceServer) GetCameraStatus(context.Context, *GetCameraStatusRequest) (*GetCameraStatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCameraStatus not implemented")
-}
-func (UnimplementedCameraServiceServer) mustEmbedUnimplementedCameraServiceServer() {}
-func (UnimplementedCameraServiceServer) testEmbeddedByValue()                       {}
-
-// UnsafeCameraServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to CameraServiceServer will
-// result in compilation errors.
-type UnsafeCameraServiceServer interface {
-	mustEmbedUnimplementedCameraServiceServer()
-}
-
-func RegisterCameraServiceServer(s grpc.ServiceRegistrar, srv CameraServiceServer) {
-	// If the following call pancis, it indicates UnimplementedCameraServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
-	}
-	s.RegisterService(&CameraService_ServiceDesc, srv)
-}
-
-func _CameraService_GetCameraStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetCameraStatusRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(CameraServiceServer).GetCameraStatus(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: CameraService_GetCameraStatus_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CameraServiceServer).GetCameraStatus(ctx, req.(*GetCameraStatusRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-// CameraService_ServiceDesc is the grpc.ServiceDesc for CameraService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var CameraService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "saladineye.CameraService",
-	HandlerType: (*CameraServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetCameraStatus",
-			Handler:    _CameraService_GetCameraStatus_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "camera-service.proto",
-}
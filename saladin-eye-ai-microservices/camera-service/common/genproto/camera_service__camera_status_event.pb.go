@@ -0,0 +1,196 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__camera_status_event.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Published on the Redis channel saladin-eye:camera-service:camera-status
+// when a camera goes online or offline
+type CameraStatusEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// online or offline
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// heartbeat, heartbeat_timeout or last_will
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Unix time in seconds
+	At int64 `protobuf:"varint,4,opt,name=at,proto3" json:"at,omitempty"`
+	// The period that ended, unset for the first status of the camera
+	Previous *CameraStatusPeriod `protobuf:"bytes,5,opt,name=previous,proto3" json:"previous,omitempty"`
+}
+
+func (x *CameraStatusEvent) Reset() {
+	*x = CameraStatusEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__camera_status_event_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CameraStatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CameraStatusEvent) ProtoMessage() {}
+
+func (x *CameraStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__camera_status_event_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CameraStatusEvent.ProtoReflect.Descriptor instead.
+func (*CameraStatusEvent) Descriptor() ([]byte, []int) {
+	return file_camera_service__camera_status_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CameraStatusEvent) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *CameraStatusEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CameraStatusEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CameraStatusEvent) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+func (x *CameraStatusEvent) GetPrevious() *CameraStatusPeriod {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+var File_camera_service__camera_status_event_proto protoreflect.FileDescriptor
+
+var file_camera_service__camera_status_event_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c,
+	0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x1a, 0x2a, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x5f, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xac, 0x01, 0x0a, 0x11, 0x43, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x61, 0x74, 0x12, 0x3a, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64,
+	0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x43, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67,
+	0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__camera_status_event_proto_rawDescOnce sync.Once
+	file_camera_service__camera_status_event_proto_rawDescData = file_camera_service__camera_status_event_proto_rawDesc
+)
+
+func file_camera_service__camera_status_event_proto_rawDescGZIP() []byte {
+	file_camera_service__camera_status_event_proto_rawDescOnce.Do(func() {
+		file_camera_service__camera_status_event_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__camera_status_event_proto_rawDescData)
+	})
+	return file_camera_service__camera_status_event_proto_rawDescData
+}
+
+var file_camera_service__camera_status_event_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__camera_status_event_proto_goTypes = []any{
+	(*CameraStatusEvent)(nil),  // 0: saladineye.CameraStatusEvent
+	(*CameraStatusPeriod)(nil), // 1: saladineye.CameraStatusPeriod
+}
+var file_camera_service__camera_status_event_proto_depIdxs = []int32{
+	1, // 0: saladineye.CameraStatusEvent.previous:type_name -> saladineye.CameraStatusPeriod
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__camera_status_event_proto_init() }
+func file_camera_service__camera_status_event_proto_init() {
+	if File_camera_service__camera_status_event_proto != nil {
+		return
+	}
+	file_camera_service__camera_status_period_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__camera_status_event_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*CameraStatusEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__camera_status_event_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__camera_status_event_proto_goTypes,
+		DependencyIndexes: file_camera_service__camera_status_event_proto_depIdxs,
+		MessageInfos:      file_camera_service__camera_status_event_proto_msgTypes,
+	}.Build()
+	File_camera_service__camera_status_event_proto = out.File
+	file_camera_service__camera_status_event_proto_rawDesc = nil
+	file_camera_service__camera_status_event_proto_goTypes = nil
+	file_camera_service__camera_status_event_proto_depIdxs = nil
+}
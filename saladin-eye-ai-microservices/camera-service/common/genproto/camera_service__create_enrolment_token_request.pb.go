@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__create_enrolment_token_request.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateEnrolmentTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The device to register, but its device_id
+	Device *Device `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	// 24 hours when 0, at most 30 days
+	TtlSeconds uint32 `protobuf:"varint,2,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+}
+
+func (x *CreateEnrolmentTokenRequest) Reset() {
+	*x = CreateEnrolmentTokenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__create_enrolment_token_request_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateEnrolmentTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEnrolmentTokenRequest) ProtoMessage() {}
+
+func (x *CreateEnrolmentTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__create_enrolment_token_request_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEnrolmentTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateEnrolmentTokenRequest) Descriptor() ([]byte, []int) {
+	return file_camera_service__create_enrolment_token_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateEnrolmentTokenRequest) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *CreateEnrolmentTokenRequest) GetTtlSeconds() uint32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+var File_camera_service__create_enrolment_token_request_proto protoreflect.FileDescriptor
+
+var file_camera_service__create_enrolment_token_request_proto_rawDesc = []byte{
+	0x0a, 0x34, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x65, 0x6e, 0x72, 0x6f, 0x6c, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65,
+	0x79, 0x65, 0x1a, 0x1c, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x6a, 0x0a, 0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6d,
+	0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2a, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
+	0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x42, 0x13, 0x5a, 0x11,
+	0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__create_enrolment_token_request_proto_rawDescOnce sync.Once
+	file_camera_service__create_enrolment_token_request_proto_rawDescData = file_camera_service__create_enrolment_token_request_proto_rawDesc
+)
+
+func file_camera_service__create_enrolment_token_request_proto_rawDescGZIP() []byte {
+	file_camera_service__create_enrolment_token_request_proto_rawDescOnce.Do(func() {
+		file_camera_service__create_enrolment_token_request_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__create_enrolment_token_request_proto_rawDescData)
+	})
+	return file_camera_service__create_enrolment_token_request_proto_rawDescData
+}
+
+var file_camera_service__create_enrolment_token_request_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__create_enrolment_token_request_proto_goTypes = []any{
+	(*CreateEnrolmentTokenRequest)(nil), // 0: saladineye.CreateEnrolmentTokenRequest
+	(*Device)(nil),                      // 1: saladineye.Device
+}
+var file_camera_service__create_enrolment_token_request_proto_depIdxs = []int32{
+	1, // 0: saladineye.CreateEnrolmentTokenRequest.device:type_name -> saladineye.Device
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__create_enrolment_token_request_proto_init() }
+func file_camera_service__create_enrolment_token_request_proto_init() {
+	if File_camera_service__create_enrolment_token_request_proto != nil {
+		return
+	}
+	file_camera_service__device_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__create_enrolment_token_request_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateEnrolmentTokenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__create_enrolment_token_request_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__create_enrolment_token_request_proto_goTypes,
+		DependencyIndexes: file_camera_service__create_enrolment_token_request_proto_depIdxs,
+		MessageInfos:      file_camera_service__create_enrolment_token_request_proto_msgTypes,
+	}.Build()
+	File_camera_service__create_enrolment_token_request_proto = out.File
+	file_camera_service__create_enrolment_token_request_proto_rawDesc = nil
+	file_camera_service__create_enrolment_token_request_proto_goTypes = nil
+	file_camera_service__create_enrolment_token_request_proto_depIdxs = nil
+}
@@ -0,0 +1,319 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__device_command.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Published to saladin-eye/device/[device-id]/command, the device answers
+// with a DeviceCommandAck
+type DeviceCommand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommandId string `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	DeviceId  string `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Unix time in seconds
+	IssuedAt int64 `protobuf:"varint,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	// The device ignores the command after this, unix time in seconds
+	ExpiresAt int64 `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Types that are assignable to Command:
+	//	*DeviceCommand_CaptureNow
+	//	*DeviceCommand_SetCaptureInterval
+	//	*DeviceCommand_SetFlashLed
+	//	*DeviceCommand_Reboot
+	//	*DeviceCommand_ResyncNtp
+	Command isDeviceCommand_Command `protobuf_oneof:"command"`
+}
+
+func (x *DeviceCommand) Reset() {
+	*x = DeviceCommand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__device_command_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceCommand) ProtoMessage() {}
+
+func (x *DeviceCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__device_command_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceCommand.ProtoReflect.Descriptor instead.
+func (*DeviceCommand) Descriptor() ([]byte, []int) {
+	return file_camera_service__device_command_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeviceCommand) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *DeviceCommand) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceCommand) GetIssuedAt() int64 {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return 0
+}
+
+func (x *DeviceCommand) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (m *DeviceCommand) GetCommand() isDeviceCommand_Command {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+func (x *DeviceCommand) GetCaptureNow() *CaptureNowCommand {
+	if x, ok := x.GetCommand().(*DeviceCommand_CaptureNow); ok {
+		return x.CaptureNow
+	}
+	return nil
+}
+
+func (x *DeviceCommand) GetSetCaptureInterval() *SetCaptureIntervalCommand {
+	if x, ok := x.GetCommand().(*DeviceCommand_SetCaptureInterval); ok {
+		return x.SetCaptureInterval
+	}
+	return nil
+}
+
+func (x *DeviceCommand) GetSetFlashLed() *SetFlashLedCommand {
+	if x, ok := x.GetCommand().(*DeviceCommand_SetFlashLed); ok {
+		return x.SetFlashLed
+	}
+	return nil
+}
+
+func (x *DeviceCommand) GetReboot() *RebootCommand {
+	if x, ok := x.GetCommand().(*DeviceCommand_Reboot); ok {
+		return x.Reboot
+	}
+	return nil
+}
+
+func (x *DeviceCommand) GetResyncNtp() *ResyncNtpCommand {
+	if x, ok := x.GetCommand().(*DeviceCommand_ResyncNtp); ok {
+		return x.ResyncNtp
+	}
+	return nil
+}
+
+type isDeviceCommand_Command interface {
+	isDeviceCommand_Command()
+}
+
+type DeviceCommand_CaptureNow struct {
+	CaptureNow *CaptureNowCommand `protobuf:"bytes,5,opt,name=capture_now,json=captureNow,proto3,oneof"`
+}
+
+type DeviceCommand_SetCaptureInterval struct {
+	SetCaptureInterval *SetCaptureIntervalCommand `protobuf:"bytes,6,opt,name=set_capture_interval,json=setCaptureInterval,proto3,oneof"`
+}
+
+type DeviceCommand_SetFlashLed struct {
+	SetFlashLed *SetFlashLedCommand `protobuf:"bytes,7,opt,name=set_flash_led,json=setFlashLed,proto3,oneof"`
+}
+
+type DeviceCommand_Reboot struct {
+	Reboot *RebootCommand `protobuf:"bytes,8,opt,name=reboot,proto3,oneof"`
+}
+
+type DeviceCommand_ResyncNtp struct {
+	ResyncNtp *ResyncNtpCommand `protobuf:"bytes,9,opt,name=resync_ntp,json=resyncNtp,proto3,oneof"`
+}
+
+func (*DeviceCommand_CaptureNow) isDeviceCommand_Command() {}
+
+func (*DeviceCommand_SetCaptureInterval) isDeviceCommand_Command() {}
+
+func (*DeviceCommand_SetFlashLed) isDeviceCommand_Command() {}
+
+func (*DeviceCommand_Reboot) isDeviceCommand_Command() {}
+
+func (*DeviceCommand_ResyncNtp) isDeviceCommand_Command() {}
+
+var File_camera_service__device_command_proto protoreflect.FileDescriptor
+
+var file_camera_service__device_command_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65,
+	0x79, 0x65, 0x1a, 0x29, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x5f, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6e, 0x6f, 0x77, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x32, 0x63,
+	0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x5f, 0x73,
+	0x65, 0x74, 0x5f, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x2b, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x5f, 0x6c, 0x65, 0x64,
+	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x24,
+	0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x5f,
+	0x72, 0x65, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x28, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x5f, 0x5f, 0x72, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x6e, 0x74, 0x70,
+	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe9,
+	0x03, 0x0a, 0x0d, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x69, 0x73, 0x73, 0x75, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x69, 0x73, 0x73, 0x75, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x40, 0x0a, 0x0b, 0x63, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x5f, 0x6e, 0x6f, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x4e, 0x6f, 0x77, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0a,
+	0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x4e, 0x6f, 0x77, 0x12, 0x59, 0x0a, 0x14, 0x73, 0x65,
+	0x74, 0x5f, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64,
+	0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48,
+	0x00, 0x52, 0x12, 0x73, 0x65, 0x74, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x44, 0x0a, 0x0d, 0x73, 0x65, 0x74, 0x5f, 0x66, 0x6c, 0x61,
+	0x73, 0x68, 0x5f, 0x6c, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73,
+	0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x46, 0x6c, 0x61,
+	0x73, 0x68, 0x4c, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0b,
+	0x73, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x4c, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x06, 0x72,
+	0x65, 0x62, 0x6f, 0x6f, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x61,
+	0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x52, 0x65, 0x62, 0x6f, 0x6f, 0x74, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x62, 0x6f, 0x6f, 0x74,
+	0x12, 0x3d, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x6e, 0x74, 0x70, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79,
+	0x65, 0x2e, 0x52, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x4e, 0x74, 0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x4e, 0x74, 0x70, 0x42,
+	0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__device_command_proto_rawDescOnce sync.Once
+	file_camera_service__device_command_proto_rawDescData = file_camera_service__device_command_proto_rawDesc
+)
+
+func file_camera_service__device_command_proto_rawDescGZIP() []byte {
+	file_camera_service__device_command_proto_rawDescOnce.Do(func() {
+		file_camera_service__device_command_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__device_command_proto_rawDescData)
+	})
+	return file_camera_service__device_command_proto_rawDescData
+}
+
+var file_camera_service__device_command_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__device_command_proto_goTypes = []any{
+	(*DeviceCommand)(nil),             // 0: saladineye.DeviceCommand
+	(*CaptureNowCommand)(nil),         // 1: saladineye.CaptureNowCommand
+	(*SetCaptureIntervalCommand)(nil), // 2: saladineye.SetCaptureIntervalCommand
+	(*SetFlashLedCommand)(nil),        // 3: saladineye.SetFlashLedCommand
+	(*RebootCommand)(nil),             // 4: saladineye.RebootCommand
+	(*ResyncNtpCommand)(nil),          // 5: saladineye.ResyncNtpCommand
+}
+var file_camera_service__device_command_proto_depIdxs = []int32{
+	1, // 0: saladineye.DeviceCommand.capture_now:type_name -> saladineye.CaptureNowCommand
+	2, // 1: saladineye.DeviceCommand.set_capture_interval:type_name -> saladineye.SetCaptureIntervalCommand
+	3, // 2: saladineye.DeviceCommand.set_flash_led:type_name -> saladineye.SetFlashLedCommand
+	4, // 3: saladineye.DeviceCommand.reboot:type_name -> saladineye.RebootCommand
+	5, // 4: saladineye.DeviceCommand.resync_ntp:type_name -> saladineye.ResyncNtpCommand
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__device_command_proto_init() }
+func file_camera_service__device_command_proto_init() {
+	if File_camera_service__device_command_proto != nil {
+		return
+	}
+	file_camera_service__capture_now_command_proto_init()
+	file_camera_service__set_capture_interval_command_proto_init()
+	file_camera_service__set_flash_led_command_proto_init()
+	file_camera_service__reboot_command_proto_init()
+	file_camera_service__resync_ntp_command_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__device_command_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*DeviceCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_camera_service__device_command_proto_msgTypes[0].OneofWrappers = []any{
+		(*DeviceCommand_CaptureNow)(nil),
+		(*DeviceCommand_SetCaptureInterval)(nil),
+		(*DeviceCommand_SetFlashLed)(nil),
+		(*DeviceCommand_Reboot)(nil),
+		(*DeviceCommand_ResyncNtp)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__device_command_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__device_command_proto_goTypes,
+		DependencyIndexes: file_camera_service__device_command_proto_depIdxs,
+		MessageInfos:      file_camera_service__device_command_proto_msgTypes,
+	}.Build()
+	File_camera_service__device_command_proto = out.File
+	file_camera_service__device_command_proto_rawDesc = nil
+	file_camera_service__device_command_proto_goTypes = nil
+	file_camera_service__device_command_proto_depIdxs = nil
+}
@@ -0,0 +1,227 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__device_config.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The configuration of a device, retained on saladin-eye/device/[device-id]/config.
+// A device without configuration gets the defaults with version 0.
+type DeviceConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Offset of the local time from UTC, in quarter hours
+	UtcOffsetSeconds int32 `protobuf:"varint,1,opt,name=utc_offset_seconds,json=utcOffsetSeconds,proto3" json:"utc_offset_seconds,omitempty"`
+	// pool.ntp.org when empty
+	NtpServer string `protobuf:"bytes,2,opt,name=ntp_server,json=ntpServer,proto3" json:"ntp_server,omitempty"`
+	// 123 when 0
+	NtpPort uint32 `protobuf:"varint,3,opt,name=ntp_port,json=ntpPort,proto3" json:"ntp_port,omitempty"`
+	// 60 when 0
+	NtpUpdateIntervalSeconds uint32 `protobuf:"varint,4,opt,name=ntp_update_interval_seconds,json=ntpUpdateIntervalSeconds,proto3" json:"ntp_update_interval_seconds,omitempty"`
+	// 10 when 0
+	CaptureIntervalSeconds uint32 `protobuf:"varint,5,opt,name=capture_interval_seconds,json=captureIntervalSeconds,proto3" json:"capture_interval_seconds,omitempty"`
+	FlashLedEnabled        bool   `protobuf:"varint,6,opt,name=flash_led_enabled,json=flashLedEnabled,proto3" json:"flash_led_enabled,omitempty"`
+	Version                int64  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	// Unix time in seconds
+	UpdatedAt int64 `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *DeviceConfig) Reset() {
+	*x = DeviceConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__device_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceConfig) ProtoMessage() {}
+
+func (x *DeviceConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__device_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceConfig.ProtoReflect.Descriptor instead.
+func (*DeviceConfig) Descriptor() ([]byte, []int) {
+	return file_camera_service__device_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeviceConfig) GetUtcOffsetSeconds() int32 {
+	if x != nil {
+		return x.UtcOffsetSeconds
+	}
+	return 0
+}
+
+func (x *DeviceConfig) GetNtpServer() string {
+	if x != nil {
+		return x.NtpServer
+	}
+	return ""
+}
+
+func (x *DeviceConfig) GetNtpPort() uint32 {
+	if x != nil {
+		return x.NtpPort
+	}
+	return 0
+}
+
+func (x *DeviceConfig) GetNtpUpdateIntervalSeconds() uint32 {
+	if x != nil {
+		return x.NtpUpdateIntervalSeconds
+	}
+	return 0
+}
+
+func (x *DeviceConfig) GetCaptureIntervalSeconds() uint32 {
+	if x != nil {
+		return x.CaptureIntervalSeconds
+	}
+	return 0
+}
+
+func (x *DeviceConfig) GetFlashLedEnabled() bool {
+	if x != nil {
+		return x.FlashLedEnabled
+	}
+	return false
+}
+
+func (x *DeviceConfig) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DeviceConfig) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+var File_camera_service__device_config_proto protoreflect.FileDescriptor
+
+var file_camera_service__device_config_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79,
+	0x65, 0x22, 0xd4, 0x02, 0x0a, 0x0c, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x2c, 0x0a, 0x12, 0x75, 0x74, 0x63, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10,
+	0x75, 0x74, 0x63, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x74, 0x70, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x19, 0x0a, 0x08, 0x6e, 0x74, 0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x6e, 0x74, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x3d, 0x0a, 0x1b, 0x6e, 0x74,
+	0x70, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x18, 0x6e, 0x74, 0x70, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x63, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x63, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x5f, 0x6c, 0x65, 0x64,
+	0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f,
+	0x66, 0x6c, 0x61, 0x73, 0x68, 0x4c, 0x65, 0x64, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__device_config_proto_rawDescOnce sync.Once
+	file_camera_service__device_config_proto_rawDescData = file_camera_service__device_config_proto_rawDesc
+)
+
+func file_camera_service__device_config_proto_rawDescGZIP() []byte {
+	file_camera_service__device_config_proto_rawDescOnce.Do(func() {
+		file_camera_service__device_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__device_config_proto_rawDescData)
+	})
+	return file_camera_service__device_config_proto_rawDescData
+}
+
+var file_camera_service__device_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__device_config_proto_goTypes = []any{
+	(*DeviceConfig)(nil), // 0: saladineye.DeviceConfig
+}
+var file_camera_service__device_config_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__device_config_proto_init() }
+func file_camera_service__device_config_proto_init() {
+	if File_camera_service__device_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__device_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*DeviceConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__device_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__device_config_proto_goTypes,
+		DependencyIndexes: file_camera_service__device_config_proto_depIdxs,
+		MessageInfos:      file_camera_service__device_config_proto_msgTypes,
+	}.Build()
+	File_camera_service__device_config_proto = out.File
+	file_camera_service__device_config_proto_rawDesc = nil
+	file_camera_service__device_config_proto_goTypes = nil
+	file_camera_service__device_config_proto_depIdxs = nil
+}
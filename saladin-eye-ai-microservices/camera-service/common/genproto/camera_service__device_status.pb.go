@@ -0,0 +1,256 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__device_status.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Heartbeat published by the device to saladin-eye/device/[device-id]/status,
+// stored by camera-mqtt-listener as the latest status and a time series
+type DeviceStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId        string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	FirmwareVersion string `protobuf:"bytes,2,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+	UptimeSeconds   uint32 `protobuf:"varint,3,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	// WiFi signal strength
+	RssiDbm            int32   `protobuf:"zigzag32,4,opt,name=rssi_dbm,json=rssiDbm,proto3" json:"rssi_dbm,omitempty"`
+	FreeHeapBytes      uint32  `protobuf:"varint,5,opt,name=free_heap_bytes,json=freeHeapBytes,proto3" json:"free_heap_bytes,omitempty"`
+	FreePsramBytes     uint32  `protobuf:"varint,6,opt,name=free_psram_bytes,json=freePsramBytes,proto3" json:"free_psram_bytes,omitempty"`
+	SdFreeBytes        uint64  `protobuf:"varint,7,opt,name=sd_free_bytes,json=sdFreeBytes,proto3" json:"sd_free_bytes,omitempty"`
+	TemperatureCelsius float32 `protobuf:"fixed32,8,opt,name=temperature_celsius,json=temperatureCelsius,proto3" json:"temperature_celsius,omitempty"`
+	// ok, camera_error, sd_error, upload_failed, or empty before the first
+	// capture
+	LastCaptureResult string `protobuf:"bytes,9,opt,name=last_capture_result,json=lastCaptureResult,proto3" json:"last_capture_result,omitempty"`
+	// Unix time in seconds by the device clock
+	LastCaptureAt int64 `protobuf:"varint,10,opt,name=last_capture_at,json=lastCaptureAt,proto3" json:"last_capture_at,omitempty"`
+	// Unix time in seconds, set by the server when the status arrives
+	ReceivedAt int64 `protobuf:"varint,11,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+}
+
+func (x *DeviceStatus) Reset() {
+	*x = DeviceStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__device_status_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceStatus) ProtoMessage() {}
+
+func (x *DeviceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__device_status_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceStatus.ProtoReflect.Descriptor instead.
+func (*DeviceStatus) Descriptor() ([]byte, []int) {
+	return file_camera_service__device_status_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeviceStatus) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceStatus) GetFirmwareVersion() string {
+	if x != nil {
+		return x.FirmwareVersion
+	}
+	return ""
+}
+
+func (x *DeviceStatus) GetUptimeSeconds() uint32 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *DeviceStatus) GetRssiDbm() int32 {
+	if x != nil {
+		return x.RssiDbm
+	}
+	return 0
+}
+
+func (x *DeviceStatus) GetFreeHeapBytes() uint32 {
+	if x != nil {
+		return x.FreeHeapBytes
+	}
+	return 0
+}
+
+func (x *DeviceStatus) GetFreePsramBytes() uint32 {
+	if x != nil {
+		return x.FreePsramBytes
+	}
+	return 0
+}
+
+func (x *DeviceStatus) GetSdFreeBytes() uint64 {
+	if x != nil {
+		return x.SdFreeBytes
+	}
+	return 0
+}
+
+func (x *DeviceStatus) GetTemperatureCelsius() float32 {
+	if x != nil {
+		return x.TemperatureCelsius
+	}
+	return 0
+}
+
+func (x *DeviceStatus) GetLastCaptureResult() string {
+	if x != nil {
+		return x.LastCaptureResult
+	}
+	return ""
+}
+
+func (x *DeviceStatus) GetLastCaptureAt() int64 {
+	if x != nil {
+		return x.LastCaptureAt
+	}
+	return 0
+}
+
+func (x *DeviceStatus) GetReceivedAt() int64 {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return 0
+}
+
+var File_camera_service__device_status_proto protoreflect.FileDescriptor
+
+var file_camera_service__device_status_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79,
+	0x65, 0x22, 0xb8, 0x03, 0x0a, 0x0c, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x29, 0x0a, 0x10, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x66, 0x69, 0x72, 0x6d, 0x77,
+	0x61, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x70,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x73, 0x73, 0x69, 0x5f, 0x64, 0x62, 0x6d, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x11, 0x52, 0x07, 0x72, 0x73, 0x73, 0x69, 0x44, 0x62, 0x6d, 0x12, 0x26, 0x0a, 0x0f,
+	0x66, 0x72, 0x65, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x70, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x66, 0x72, 0x65, 0x65, 0x48, 0x65, 0x61, 0x70, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x70, 0x73, 0x72,
+	0x61, 0x6d, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e,
+	0x66, 0x72, 0x65, 0x65, 0x50, 0x73, 0x72, 0x61, 0x6d, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22,
+	0x0a, 0x0d, 0x73, 0x64, 0x5f, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x64, 0x46, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x5f, 0x63, 0x65, 0x6c, 0x73, 0x69, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x65, 0x6c, 0x73,
+	0x69, 0x75, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6c, 0x61,
+	0x73, 0x74, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x41, 0x74, 0x42, 0x13, 0x5a, 0x11,
+	0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__device_status_proto_rawDescOnce sync.Once
+	file_camera_service__device_status_proto_rawDescData = file_camera_service__device_status_proto_rawDesc
+)
+
+func file_camera_service__device_status_proto_rawDescGZIP() []byte {
+	file_camera_service__device_status_proto_rawDescOnce.Do(func() {
+		file_camera_service__device_status_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__device_status_proto_rawDescData)
+	})
+	return file_camera_service__device_status_proto_rawDescData
+}
+
+var file_camera_service__device_status_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__device_status_proto_goTypes = []any{
+	(*DeviceStatus)(nil), // 0: saladineye.DeviceStatus
+}
+var file_camera_service__device_status_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__device_status_proto_init() }
+func file_camera_service__device_status_proto_init() {
+	if File_camera_service__device_status_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__device_status_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*DeviceStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__device_status_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__device_status_proto_goTypes,
+		DependencyIndexes: file_camera_service__device_status_proto_depIdxs,
+		MessageInfos:      file_camera_service__device_status_proto_msgTypes,
+	}.Build()
+	File_camera_service__device_status_proto = out.File
+	file_camera_service__device_status_proto_rawDesc = nil
+	file_camera_service__device_status_proto_goTypes = nil
+	file_camera_service__device_status_proto_depIdxs = nil
+}
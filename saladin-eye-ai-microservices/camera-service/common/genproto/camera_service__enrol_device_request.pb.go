@@ -0,0 +1,172 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__enrol_device_request.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// POSTed by a new device to /enrol of camera-service over HTTPS, with
+// Content-Type application/x-protobuf. Not over MQTT, every device on a shared
+// bootstrap topic could read the credentials in the response.
+type EnrolDeviceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// The public key of an Ed25519 key pair made on the device, the device signs
+	// its media-service requests with it. When empty an HMAC secret is
+	// generated and returned instead.
+	Ed25519PublicKey []byte `protobuf:"bytes,2,opt,name=ed25519_public_key,json=ed25519PublicKey,proto3" json:"ed25519_public_key,omitempty"`
+	FirmwareVersion  string `protobuf:"bytes,3,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+}
+
+func (x *EnrolDeviceRequest) Reset() {
+	*x = EnrolDeviceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__enrol_device_request_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnrolDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrolDeviceRequest) ProtoMessage() {}
+
+func (x *EnrolDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__enrol_device_request_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrolDeviceRequest.ProtoReflect.Descriptor instead.
+func (*EnrolDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_camera_service__enrol_device_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EnrolDeviceRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *EnrolDeviceRequest) GetEd25519PublicKey() []byte {
+	if x != nil {
+		return x.Ed25519PublicKey
+	}
+	return nil
+}
+
+func (x *EnrolDeviceRequest) GetFirmwareVersion() string {
+	if x != nil {
+		return x.FirmwareVersion
+	}
+	return ""
+}
+
+var File_camera_service__enrol_device_request_proto protoreflect.FileDescriptor
+
+var file_camera_service__enrol_device_request_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x65, 0x6e, 0x72, 0x6f, 0x6c, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61,
+	0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x12, 0x45, 0x6e, 0x72,
+	0x6f, 0x6c, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x65, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39,
+	0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x10, 0x65, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x66,
+	0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x13,
+	0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__enrol_device_request_proto_rawDescOnce sync.Once
+	file_camera_service__enrol_device_request_proto_rawDescData = file_camera_service__enrol_device_request_proto_rawDesc
+)
+
+func file_camera_service__enrol_device_request_proto_rawDescGZIP() []byte {
+	file_camera_service__enrol_device_request_proto_rawDescOnce.Do(func() {
+		file_camera_service__enrol_device_request_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__enrol_device_request_proto_rawDescData)
+	})
+	return file_camera_service__enrol_device_request_proto_rawDescData
+}
+
+var file_camera_service__enrol_device_request_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__enrol_device_request_proto_goTypes = []any{
+	(*EnrolDeviceRequest)(nil), // 0: saladineye.EnrolDeviceRequest
+}
+var file_camera_service__enrol_device_request_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__enrol_device_request_proto_init() }
+func file_camera_service__enrol_device_request_proto_init() {
+	if File_camera_service__enrol_device_request_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__enrol_device_request_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*EnrolDeviceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__enrol_device_request_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__enrol_device_request_proto_goTypes,
+		DependencyIndexes: file_camera_service__enrol_device_request_proto_depIdxs,
+		MessageInfos:      file_camera_service__enrol_device_request_proto_msgTypes,
+	}.Build()
+	File_camera_service__enrol_device_request_proto = out.File
+	file_camera_service__enrol_device_request_proto_rawDesc = nil
+	file_camera_service__enrol_device_request_proto_goTypes = nil
+	file_camera_service__enrol_device_request_proto_depIdxs = nil
+}
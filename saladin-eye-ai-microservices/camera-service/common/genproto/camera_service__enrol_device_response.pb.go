@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__enrol_device_response.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EnrolDeviceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId     string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	MqttBroker   string `protobuf:"bytes,2,opt,name=mqtt_broker,json=mqttBroker,proto3" json:"mqtt_broker,omitempty"`
+	MqttClientId string `protobuf:"bytes,3,opt,name=mqtt_client_id,json=mqttClientId,proto3" json:"mqtt_client_id,omitempty"`
+	MqttUsername string `protobuf:"bytes,4,opt,name=mqtt_username,json=mqttUsername,proto3" json:"mqtt_username,omitempty"`
+	MqttPassword string `protobuf:"bytes,5,opt,name=mqtt_password,json=mqttPassword,proto3" json:"mqtt_password,omitempty"`
+	// The key the device signs its media-service requests with
+	KeyId string `protobuf:"bytes,6,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// ed25519 or hmac-sha256
+	KeyAlgorithm string `protobuf:"bytes,7,opt,name=key_algorithm,json=keyAlgorithm,proto3" json:"key_algorithm,omitempty"`
+	// Only for hmac-sha256
+	HmacSecret []byte        `protobuf:"bytes,8,opt,name=hmac_secret,json=hmacSecret,proto3" json:"hmac_secret,omitempty"`
+	Config     *DeviceConfig `protobuf:"bytes,9,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *EnrolDeviceResponse) Reset() {
+	*x = EnrolDeviceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__enrol_device_response_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnrolDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrolDeviceResponse) ProtoMessage() {}
+
+func (x *EnrolDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__enrol_device_response_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrolDeviceResponse.ProtoReflect.Descriptor instead.
+func (*EnrolDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_camera_service__enrol_device_response_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EnrolDeviceResponse) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *EnrolDeviceResponse) GetMqttBroker() string {
+	if x != nil {
+		return x.MqttBroker
+	}
+	return ""
+}
+
+func (x *EnrolDeviceResponse) GetMqttClientId() string {
+	if x != nil {
+		return x.MqttClientId
+	}
+	return ""
+}
+
+func (x *EnrolDeviceResponse) GetMqttUsername() string {
+	if x != nil {
+		return x.MqttUsername
+	}
+	return ""
+}
+
+func (x *EnrolDeviceResponse) GetMqttPassword() string {
+	if x != nil {
+		return x.MqttPassword
+	}
+	return ""
+}
+
+func (x *EnrolDeviceResponse) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *EnrolDeviceResponse) GetKeyAlgorithm() string {
+	if x != nil {
+		return x.KeyAlgorithm
+	}
+	return ""
+}
+
+func (x *EnrolDeviceResponse) GetHmacSecret() []byte {
+	if x != nil {
+		return x.HmacSecret
+	}
+	return nil
+}
+
+func (x *EnrolDeviceResponse) GetConfig() *DeviceConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+var File_camera_service__enrol_device_response_proto protoreflect.FileDescriptor
+
+var file_camera_service__enrol_device_response_proto_rawDesc = []byte{
+	0x0a, 0x2b, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x65, 0x6e, 0x72, 0x6f, 0x6c, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73,
+	0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x1a, 0x23, 0x63, 0x61, 0x6d, 0x65, 0x72,
+	0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd2,
+	0x02, 0x0a, 0x13, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x71, 0x74, 0x74, 0x5f, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x71, 0x74, 0x74, 0x42, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x71, 0x74, 0x74, 0x5f, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x71,
+	0x74, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x71,
+	0x74, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x6d, 0x71, 0x74, 0x74, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x6d, 0x71, 0x74, 0x74, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x71, 0x74, 0x74, 0x50, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x6b,
+	0x65, 0x79, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x6b, 0x65, 0x79, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d,
+	0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x6d, 0x61, 0x63, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x68, 0x6d, 0x61, 0x63, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x12, 0x30, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f,
+	0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__enrol_device_response_proto_rawDescOnce sync.Once
+	file_camera_service__enrol_device_response_proto_rawDescData = file_camera_service__enrol_device_response_proto_rawDesc
+)
+
+func file_camera_service__enrol_device_response_proto_rawDescGZIP() []byte {
+	file_camera_service__enrol_device_response_proto_rawDescOnce.Do(func() {
+		file_camera_service__enrol_device_response_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__enrol_device_response_proto_rawDescData)
+	})
+	return file_camera_service__enrol_device_response_proto_rawDescData
+}
+
+var file_camera_service__enrol_device_response_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__enrol_device_response_proto_goTypes = []any{
+	(*EnrolDeviceResponse)(nil), // 0: saladineye.EnrolDeviceResponse
+	(*DeviceConfig)(nil),        // 1: saladineye.DeviceConfig
+}
+var file_camera_service__enrol_device_response_proto_depIdxs = []int32{
+	1, // 0: saladineye.EnrolDeviceResponse.config:type_name -> saladineye.DeviceConfig
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__enrol_device_response_proto_init() }
+func file_camera_service__enrol_device_response_proto_init() {
+	if File_camera_service__enrol_device_response_proto != nil {
+		return
+	}
+	file_camera_service__device_config_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__enrol_device_response_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*EnrolDeviceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__enrol_device_response_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__enrol_device_response_proto_goTypes,
+		DependencyIndexes: file_camera_service__enrol_device_response_proto_depIdxs,
+		MessageInfos:      file_camera_service__enrol_device_response_proto_msgTypes,
+	}.Build()
+	File_camera_service__enrol_device_response_proto = out.File
+	file_camera_service__enrol_device_response_proto_rawDesc = nil
+	file_camera_service__enrol_device_response_proto_goTypes = nil
+	file_camera_service__enrol_device_response_proto_depIdxs = nil
+}
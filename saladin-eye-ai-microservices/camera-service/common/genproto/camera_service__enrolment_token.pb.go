@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__enrolment_token.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A one-time token a new device enrols with, see EnrolDeviceRequest
+type EnrolmentToken struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenId string `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// The device registered on enrolment, its device_id is assigned then
+	Device *Device `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
+	// Unix time in seconds
+	CreatedAt int64 `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt int64 `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *EnrolmentToken) Reset() {
+	*x = EnrolmentToken{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__enrolment_token_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnrolmentToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrolmentToken) ProtoMessage() {}
+
+func (x *EnrolmentToken) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__enrolment_token_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrolmentToken.ProtoReflect.Descriptor instead.
+func (*EnrolmentToken) Descriptor() ([]byte, []int) {
+	return file_camera_service__enrolment_token_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EnrolmentToken) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *EnrolmentToken) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *EnrolmentToken) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *EnrolmentToken) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+var File_camera_service__enrolment_token_proto protoreflect.FileDescriptor
+
+var file_camera_service__enrolment_token_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x65, 0x6e, 0x72, 0x6f, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e,
+	0x65, 0x79, 0x65, 0x1a, 0x1c, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x95, 0x01, 0x0a, 0x0e, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x12,
+	0x2a, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__enrolment_token_proto_rawDescOnce sync.Once
+	file_camera_service__enrolment_token_proto_rawDescData = file_camera_service__enrolment_token_proto_rawDesc
+)
+
+func file_camera_service__enrolment_token_proto_rawDescGZIP() []byte {
+	file_camera_service__enrolment_token_proto_rawDescOnce.Do(func() {
+		file_camera_service__enrolment_token_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__enrolment_token_proto_rawDescData)
+	})
+	return file_camera_service__enrolment_token_proto_rawDescData
+}
+
+var file_camera_service__enrolment_token_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__enrolment_token_proto_goTypes = []any{
+	(*EnrolmentToken)(nil), // 0: saladineye.EnrolmentToken
+	(*Device)(nil),         // 1: saladineye.Device
+}
+var file_camera_service__enrolment_token_proto_depIdxs = []int32{
+	1, // 0: saladineye.EnrolmentToken.device:type_name -> saladineye.Device
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__enrolment_token_proto_init() }
+func file_camera_service__enrolment_token_proto_init() {
+	if File_camera_service__enrolment_token_proto != nil {
+		return
+	}
+	file_camera_service__device_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__enrolment_token_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*EnrolmentToken); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__enrolment_token_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__enrolment_token_proto_goTypes,
+		DependencyIndexes: file_camera_service__enrolment_token_proto_depIdxs,
+		MessageInfos:      file_camera_service__enrolment_token_proto_msgTypes,
+	}.Build()
+	File_camera_service__enrolment_token_proto = out.File
+	file_camera_service__enrolment_token_proto_rawDesc = nil
+	file_camera_service__enrolment_token_proto_goTypes = nil
+	file_camera_service__enrolment_token_proto_depIdxs = nil
+}
@@ -0,0 +1,187 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__send_device_command_request.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SendDeviceCommandRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One device, or a group of up to 100
+	DeviceIds []string `protobuf:"bytes,1,rep,name=device_ids,json=deviceIds,proto3" json:"device_ids,omitempty"`
+	// Only the command is used, the rest is set for every device
+	Command *DeviceCommand `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	// Seconds to wait for the acknowledgement, 30 when 0
+	TimeoutSeconds uint32 `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// Return once every device has acknowledged, or the timeout
+	WaitForAck bool `protobuf:"varint,4,opt,name=wait_for_ack,json=waitForAck,proto3" json:"wait_for_ack,omitempty"`
+}
+
+func (x *SendDeviceCommandRequest) Reset() {
+	*x = SendDeviceCommandRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__send_device_command_request_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendDeviceCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendDeviceCommandRequest) ProtoMessage() {}
+
+func (x *SendDeviceCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__send_device_command_request_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendDeviceCommandRequest.ProtoReflect.Descriptor instead.
+func (*SendDeviceCommandRequest) Descriptor() ([]byte, []int) {
+	return file_camera_service__send_device_command_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SendDeviceCommandRequest) GetDeviceIds() []string {
+	if x != nil {
+		return x.DeviceIds
+	}
+	return nil
+}
+
+func (x *SendDeviceCommandRequest) GetCommand() *DeviceCommand {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *SendDeviceCommandRequest) GetTimeoutSeconds() uint32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *SendDeviceCommandRequest) GetWaitForAck() bool {
+	if x != nil {
+		return x.WaitForAck
+	}
+	return false
+}
+
+var File_camera_service__send_device_command_request_proto protoreflect.FileDescriptor
+
+var file_camera_service__send_device_command_request_proto_rawDesc = []byte{
+	0x0a, 0x31, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x1a,
+	0x24, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb9, 0x01, 0x0a, 0x18, 0x53, 0x65, 0x6e, 0x64, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64,
+	0x73, 0x12, 0x33, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12,
+	0x20, 0x0a, 0x0c, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x61, 0x63, 0x6b, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x77, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x41, 0x63,
+	0x6b, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65,
+	0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__send_device_command_request_proto_rawDescOnce sync.Once
+	file_camera_service__send_device_command_request_proto_rawDescData = file_camera_service__send_device_command_request_proto_rawDesc
+)
+
+func file_camera_service__send_device_command_request_proto_rawDescGZIP() []byte {
+	file_camera_service__send_device_command_request_proto_rawDescOnce.Do(func() {
+		file_camera_service__send_device_command_request_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__send_device_command_request_proto_rawDescData)
+	})
+	return file_camera_service__send_device_command_request_proto_rawDescData
+}
+
+var file_camera_service__send_device_command_request_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__send_device_command_request_proto_goTypes = []any{
+	(*SendDeviceCommandRequest)(nil), // 0: saladineye.SendDeviceCommandRequest
+	(*DeviceCommand)(nil),            // 1: saladineye.DeviceCommand
+}
+var file_camera_service__send_device_command_request_proto_depIdxs = []int32{
+	1, // 0: saladineye.SendDeviceCommandRequest.command:type_name -> saladineye.DeviceCommand
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__send_device_command_request_proto_init() }
+func file_camera_service__send_device_command_request_proto_init() {
+	if File_camera_service__send_device_command_request_proto != nil {
+		return
+	}
+	file_camera_service__device_command_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__send_device_command_request_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SendDeviceCommandRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__send_device_command_request_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__send_device_command_request_proto_goTypes,
+		DependencyIndexes: file_camera_service__send_device_command_request_proto_depIdxs,
+		MessageInfos:      file_camera_service__send_device_command_request_proto_msgTypes,
+	}.Build()
+	File_camera_service__send_device_command_request_proto = out.File
+	file_camera_service__send_device_command_request_proto_rawDesc = nil
+	file_camera_service__send_device_command_request_proto_goTypes = nil
+	file_camera_service__send_device_command_request_proto_depIdxs = nil
+}
@@ -0,0 +1,147 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__set_capture_interval_command.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetCaptureIntervalCommand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IntervalSeconds uint32 `protobuf:"varint,1,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+}
+
+func (x *SetCaptureIntervalCommand) Reset() {
+	*x = SetCaptureIntervalCommand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__set_capture_interval_command_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetCaptureIntervalCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCaptureIntervalCommand) ProtoMessage() {}
+
+func (x *SetCaptureIntervalCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__set_capture_interval_command_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCaptureIntervalCommand.ProtoReflect.Descriptor instead.
+func (*SetCaptureIntervalCommand) Descriptor() ([]byte, []int) {
+	return file_camera_service__set_capture_interval_command_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetCaptureIntervalCommand) GetIntervalSeconds() uint32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+var File_camera_service__set_capture_interval_command_proto protoreflect.FileDescriptor
+
+var file_camera_service__set_capture_interval_command_proto_rawDesc = []byte{
+	0x0a, 0x32, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65,
+	0x22, 0x46, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x29, 0x0a,
+	0x10, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__set_capture_interval_command_proto_rawDescOnce sync.Once
+	file_camera_service__set_capture_interval_command_proto_rawDescData = file_camera_service__set_capture_interval_command_proto_rawDesc
+)
+
+func file_camera_service__set_capture_interval_command_proto_rawDescGZIP() []byte {
+	file_camera_service__set_capture_interval_command_proto_rawDescOnce.Do(func() {
+		file_camera_service__set_capture_interval_command_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__set_capture_interval_command_proto_rawDescData)
+	})
+	return file_camera_service__set_capture_interval_command_proto_rawDescData
+}
+
+var file_camera_service__set_capture_interval_command_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__set_capture_interval_command_proto_goTypes = []any{
+	(*SetCaptureIntervalCommand)(nil), // 0: saladineye.SetCaptureIntervalCommand
+}
+var file_camera_service__set_capture_interval_command_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__set_capture_interval_command_proto_init() }
+func file_camera_service__set_capture_interval_command_proto_init() {
+	if File_camera_service__set_capture_interval_command_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__set_capture_interval_command_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SetCaptureIntervalCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__set_capture_interval_command_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__set_capture_interval_command_proto_goTypes,
+		DependencyIndexes: file_camera_service__set_capture_interval_command_proto_depIdxs,
+		MessageInfos:      file_camera_service__set_capture_interval_command_proto_msgTypes,
+	}.Build()
+	File_camera_service__set_capture_interval_command_proto = out.File
+	file_camera_service__set_capture_interval_command_proto_rawDesc = nil
+	file_camera_service__set_capture_interval_command_proto_goTypes = nil
+	file_camera_service__set_capture_interval_command_proto_depIdxs = nil
+}
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__uptime.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The availability of a camera, or of the cameras of a site, over the period
+// of an uptime report. Only the time the cameras were tracked counts, from
+// the first heartbeat on.
+type Uptime struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Empty on the rows of the sites
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The location of the device in the registry
+	Site             string `protobuf:"bytes,3,opt,name=site,proto3" json:"site,omitempty"`
+	DeviceCount      int32  `protobuf:"varint,4,opt,name=device_count,json=deviceCount,proto3" json:"device_count,omitempty"`
+	MonitoredSeconds int64  `protobuf:"varint,5,opt,name=monitored_seconds,json=monitoredSeconds,proto3" json:"monitored_seconds,omitempty"`
+	OnlineSeconds    int64  `protobuf:"varint,6,opt,name=online_seconds,json=onlineSeconds,proto3" json:"online_seconds,omitempty"`
+	OfflineSeconds   int64  `protobuf:"varint,7,opt,name=offline_seconds,json=offlineSeconds,proto3" json:"offline_seconds,omitempty"`
+	// online_seconds of monitored_seconds, 0 when nothing was monitored
+	AvailabilityPercent float64 `protobuf:"fixed64,8,opt,name=availability_percent,json=availabilityPercent,proto3" json:"availability_percent,omitempty"`
+	// The offline periods in the report period
+	OutageCount int32 `protobuf:"varint,9,opt,name=outage_count,json=outageCount,proto3" json:"outage_count,omitempty"`
+	// Within the report period
+	LongestOutageSeconds int64 `protobuf:"varint,10,opt,name=longest_outage_seconds,json=longestOutageSeconds,proto3" json:"longest_outage_seconds,omitempty"`
+	// Mean time to recovery, the mean full length of the outages that ended in
+	// the report period, 0 when none did
+	MttrSeconds int64 `protobuf:"varint,11,opt,name=mttr_seconds,json=mttrSeconds,proto3" json:"mttr_seconds,omitempty"`
+}
+
+func (x *Uptime) Reset() {
+	*x = Uptime{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__uptime_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Uptime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uptime) ProtoMessage() {}
+
+func (x *Uptime) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__uptime_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uptime.ProtoReflect.Descriptor instead.
+func (*Uptime) Descriptor() ([]byte, []int) {
+	return file_camera_service__uptime_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Uptime) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Uptime) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Uptime) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *Uptime) GetDeviceCount() int32 {
+	if x != nil {
+		return x.DeviceCount
+	}
+	return 0
+}
+
+func (x *Uptime) GetMonitoredSeconds() int64 {
+	if x != nil {
+		return x.MonitoredSeconds
+	}
+	return 0
+}
+
+func (x *Uptime) GetOnlineSeconds() int64 {
+	if x != nil {
+		return x.OnlineSeconds
+	}
+	return 0
+}
+
+func (x *Uptime) GetOfflineSeconds() int64 {
+	if x != nil {
+		return x.OfflineSeconds
+	}
+	return 0
+}
+
+func (x *Uptime) GetAvailabilityPercent() float64 {
+	if x != nil {
+		return x.AvailabilityPercent
+	}
+	return 0
+}
+
+func (x *Uptime) GetOutageCount() int32 {
+	if x != nil {
+		return x.OutageCount
+	}
+	return 0
+}
+
+func (x *Uptime) GetLongestOutageSeconds() int64 {
+	if x != nil {
+		return x.LongestOutageSeconds
+	}
+	return 0
+}
+
+func (x *Uptime) GetMttrSeconds() int64 {
+	if x != nil {
+		return x.MttrSeconds
+	}
+	return 0
+}
+
+var File_camera_service__uptime_proto protoreflect.FileDescriptor
+
+var file_camera_service__uptime_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a,
+	0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x22, 0x9c, 0x03, 0x0a, 0x06, 0x55,
+	0x70, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2b, 0x0a,
+	0x11, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f,
+	0x72, 0x65, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x6e,
+	0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6f, 0x66, 0x66, 0x6c,
+	0x69, 0x6e, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x6f, 0x75, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x6f, 0x75, 0x74, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x34, 0x0a, 0x16, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x75, 0x74, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x14, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x4f, 0x75, 0x74, 0x61, 0x67, 0x65, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x74, 0x74, 0x72, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x74,
+	0x74, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__uptime_proto_rawDescOnce sync.Once
+	file_camera_service__uptime_proto_rawDescData = file_camera_service__uptime_proto_rawDesc
+)
+
+func file_camera_service__uptime_proto_rawDescGZIP() []byte {
+	file_camera_service__uptime_proto_rawDescOnce.Do(func() {
+		file_camera_service__uptime_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__uptime_proto_rawDescData)
+	})
+	return file_camera_service__uptime_proto_rawDescData
+}
+
+var file_camera_service__uptime_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__uptime_proto_goTypes = []any{
+	(*Uptime)(nil), // 0: saladineye.Uptime
+}
+var file_camera_service__uptime_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__uptime_proto_init() }
+func file_camera_service__uptime_proto_init() {
+	if File_camera_service__uptime_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__uptime_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Uptime); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__uptime_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__uptime_proto_goTypes,
+		DependencyIndexes: file_camera_service__uptime_proto_depIdxs,
+		MessageInfos:      file_camera_service__uptime_proto_msgTypes,
+	}.Build()
+	File_camera_service__uptime_proto = out.File
+	file_camera_service__uptime_proto_rawDesc = nil
+	file_camera_service__uptime_proto_goTypes = nil
+	file_camera_service__uptime_proto_depIdxs = nil
+}
@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: camera_service__uptime_report.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UptimeReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unix time in seconds
+	From int64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   int64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	// By device id
+	Devices []*Uptime `protobuf:"bytes,3,rep,name=devices,proto3" json:"devices,omitempty"`
+	// By site
+	Sites []*Uptime `protobuf:"bytes,4,rep,name=sites,proto3" json:"sites,omitempty"`
+}
+
+func (x *UptimeReport) Reset() {
+	*x = UptimeReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_camera_service__uptime_report_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UptimeReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UptimeReport) ProtoMessage() {}
+
+func (x *UptimeReport) ProtoReflect() protoreflect.Message {
+	mi := &file_camera_service__uptime_report_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UptimeReport.ProtoReflect.Descriptor instead.
+func (*UptimeReport) Descriptor() ([]byte, []int) {
+	return file_camera_service__uptime_report_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UptimeReport) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *UptimeReport) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *UptimeReport) GetDevices() []*Uptime {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *UptimeReport) GetSites() []*Uptime {
+	if x != nil {
+		return x.Sites
+	}
+	return nil
+}
+
+var File_camera_service__uptime_report_proto protoreflect.FileDescriptor
+
+var file_camera_service__uptime_report_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79,
+	0x65, 0x1a, 0x1c, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x5f, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x8a, 0x01, 0x0a, 0x0c, 0x55, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x2c, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65,
+	0x79, 0x65, 0x2e, 0x55, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x69, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x55,
+	0x70, 0x74, 0x69, 0x6d, 0x65, 0x52, 0x05, 0x73, 0x69, 0x74, 0x65, 0x73, 0x42, 0x13, 0x5a, 0x11,
+	0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_camera_service__uptime_report_proto_rawDescOnce sync.Once
+	file_camera_service__uptime_report_proto_rawDescData = file_camera_service__uptime_report_proto_rawDesc
+)
+
+func file_camera_service__uptime_report_proto_rawDescGZIP() []byte {
+	file_camera_service__uptime_report_proto_rawDescOnce.Do(func() {
+		file_camera_service__uptime_report_proto_rawDescData = protoimpl.X.CompressGZIP(file_camera_service__uptime_report_proto_rawDescData)
+	})
+	return file_camera_service__uptime_report_proto_rawDescData
+}
+
+var file_camera_service__uptime_report_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_camera_service__uptime_report_proto_goTypes = []any{
+	(*UptimeReport)(nil), // 0: saladineye.UptimeReport
+	(*Uptime)(nil),       // 1: saladineye.Uptime
+}
+var file_camera_service__uptime_report_proto_depIdxs = []int32{
+	1, // 0: saladineye.UptimeReport.devices:type_name -> saladineye.Uptime
+	1, // 1: saladineye.UptimeReport.sites:type_name -> saladineye.Uptime
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_camera_service__uptime_report_proto_init() }
+func file_camera_service__uptime_report_proto_init() {
+	if File_camera_service__uptime_report_proto != nil {
+		return
+	}
+	file_camera_service__uptime_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_camera_service__uptime_report_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*UptimeReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_camera_service__uptime_report_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_camera_service__uptime_report_proto_goTypes,
+		DependencyIndexes: file_camera_service__uptime_report_proto_depIdxs,
+		MessageInfos:      file_camera_service__uptime_report_proto_msgTypes,
+	}.Build()
+	File_camera_service__uptime_report_proto = out.File
+	file_camera_service__uptime_report_proto_rawDesc = nil
+	file_camera_service__uptime_report_proto_goTypes = nil
+	file_camera_service__uptime_report_proto_depIdxs = nil
+}
@@ -0,0 +1,847 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.3
+// source: camera_service.proto
+
+package genproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CameraService_GetCameraStatus_FullMethodName         = "/saladineye.CameraService/GetCameraStatus"
+	CameraService_SendDeviceCommand_FullMethodName       = "/saladineye.CameraService/SendDeviceCommand"
+	CameraService_GetDeviceCommand_FullMethodName        = "/saladineye.CameraService/GetDeviceCommand"
+	CameraService_ListDeviceCommands_FullMethodName      = "/saladineye.CameraService/ListDeviceCommands"
+	CameraService_GetDeviceConfig_FullMethodName         = "/saladineye.CameraService/GetDeviceConfig"
+	CameraService_SetDeviceConfig_FullMethodName         = "/saladineye.CameraService/SetDeviceConfig"
+	CameraService_ListDeviceConfigHistory_FullMethodName = "/saladineye.CameraService/ListDeviceConfigHistory"
+	CameraService_GetDeviceStatus_FullMethodName         = "/saladineye.CameraService/GetDeviceStatus"
+	CameraService_ListDeviceStatusHistory_FullMethodName = "/saladineye.CameraService/ListDeviceStatusHistory"
+	CameraService_CreateDevice_FullMethodName            = "/saladineye.CameraService/CreateDevice"
+	CameraService_GetDevice_FullMethodName               = "/saladineye.CameraService/GetDevice"
+	CameraService_UpdateDevice_FullMethodName            = "/saladineye.CameraService/UpdateDevice"
+	CameraService_DeleteDevice_FullMethodName            = "/saladineye.CameraService/DeleteDevice"
+	CameraService_ListDevices_FullMethodName             = "/saladineye.CameraService/ListDevices"
+	CameraService_CreateEnrolmentToken_FullMethodName    = "/saladineye.CameraService/CreateEnrolmentToken"
+	CameraService_RevokeEnrolmentToken_FullMethodName    = "/saladineye.CameraService/RevokeEnrolmentToken"
+	CameraService_GetCameraStatusHistory_FullMethodName  = "/saladineye.CameraService/GetCameraStatusHistory"
+	CameraService_GetCameraStatuses_FullMethodName       = "/saladineye.CameraService/GetCameraStatuses"
+	CameraService_WatchCameraStatus_FullMethodName       = "/saladineye.CameraService/WatchCameraStatus"
+	CameraService_GetUptimeReport_FullMethodName         = "/saladineye.CameraService/GetUptimeReport"
+)
+
+// CameraServiceClient is the client API for CameraService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CameraServiceClient interface {
+	GetCameraStatus(ctx context.Context, in *GetCameraStatusRequest, opts ...grpc.CallOption) (*GetCameraStatusResponse, error)
+	SendDeviceCommand(ctx context.Context, in *SendDeviceCommandRequest, opts ...grpc.CallOption) (*SendDeviceCommandResponse, error)
+	GetDeviceCommand(ctx context.Context, in *GetDeviceCommandRequest, opts ...grpc.CallOption) (*GetDeviceCommandResponse, error)
+	ListDeviceCommands(ctx context.Context, in *ListDeviceCommandsRequest, opts ...grpc.CallOption) (*ListDeviceCommandsResponse, error)
+	GetDeviceConfig(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*GetDeviceConfigResponse, error)
+	SetDeviceConfig(ctx context.Context, in *SetDeviceConfigRequest, opts ...grpc.CallOption) (*SetDeviceConfigResponse, error)
+	ListDeviceConfigHistory(ctx context.Context, in *ListDeviceConfigHistoryRequest, opts ...grpc.CallOption) (*ListDeviceConfigHistoryResponse, error)
+	GetDeviceStatus(ctx context.Context, in *GetDeviceStatusRequest, opts ...grpc.CallOption) (*GetDeviceStatusResponse, error)
+	ListDeviceStatusHistory(ctx context.Context, in *ListDeviceStatusHistoryRequest, opts ...grpc.CallOption) (*ListDeviceStatusHistoryResponse, error)
+	CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*CreateDeviceResponse, error)
+	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error)
+	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error)
+	DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error)
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	CreateEnrolmentToken(ctx context.Context, in *CreateEnrolmentTokenRequest, opts ...grpc.CallOption) (*CreateEnrolmentTokenResponse, error)
+	RevokeEnrolmentToken(ctx context.Context, in *RevokeEnrolmentTokenRequest, opts ...grpc.CallOption) (*RevokeEnrolmentTokenResponse, error)
+	GetCameraStatusHistory(ctx context.Context, in *GetCameraStatusHistoryRequest, opts ...grpc.CallOption) (*GetCameraStatusHistoryResponse, error)
+	GetCameraStatuses(ctx context.Context, in *GetCameraStatusesRequest, opts ...grpc.CallOption) (*GetCameraStatusesResponse, error)
+	WatchCameraStatus(ctx context.Context, in *WatchCameraStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchCameraStatusResponse], error)
+	GetUptimeReport(ctx context.Context, in *GetUptimeReportRequest, opts ...grpc.CallOption) (*GetUptimeReportResponse, error)
+}
+
+type cameraServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCameraServiceClient(cc grpc.ClientConnInterface) CameraServiceClient {
+	return &cameraServiceClient{cc}
+}
+
+func (c *cameraServiceClient) GetCameraStatus(ctx context.Context, in *GetCameraStatusRequest, opts ...grpc.CallOption) (*GetCameraStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCameraStatusResponse)
+	err := c.cc.Invoke(ctx, CameraService_GetCameraStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) SendDeviceCommand(ctx context.Context, in *SendDeviceCommandRequest, opts ...grpc.CallOption) (*SendDeviceCommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendDeviceCommandResponse)
+	err := c.cc.Invoke(ctx, CameraService_SendDeviceCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) GetDeviceCommand(ctx context.Context, in *GetDeviceCommandRequest, opts ...grpc.CallOption) (*GetDeviceCommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeviceCommandResponse)
+	err := c.cc.Invoke(ctx, CameraService_GetDeviceCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) ListDeviceCommands(ctx context.Context, in *ListDeviceCommandsRequest, opts ...grpc.CallOption) (*ListDeviceCommandsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeviceCommandsResponse)
+	err := c.cc.Invoke(ctx, CameraService_ListDeviceCommands_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) GetDeviceConfig(ctx context.Context, in *GetDeviceConfigRequest, opts ...grpc.CallOption) (*GetDeviceConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeviceConfigResponse)
+	err := c.cc.Invoke(ctx, CameraService_GetDeviceConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) SetDeviceConfig(ctx context.Context, in *SetDeviceConfigRequest, opts ...grpc.CallOption) (*SetDeviceConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetDeviceConfigResponse)
+	err := c.cc.Invoke(ctx, CameraService_SetDeviceConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) ListDeviceConfigHistory(ctx context.Context, in *ListDeviceConfigHistoryRequest, opts ...grpc.CallOption) (*ListDeviceConfigHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeviceConfigHistoryResponse)
+	err := c.cc.Invoke(ctx, CameraService_ListDeviceConfigHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) GetDeviceStatus(ctx context.Context, in *GetDeviceStatusRequest, opts ...grpc.CallOption) (*GetDeviceStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeviceStatusResponse)
+	err := c.cc.Invoke(ctx, CameraService_GetDeviceStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) ListDeviceStatusHistory(ctx context.Context, in *ListDeviceStatusHistoryRequest, opts ...grpc.CallOption) (*ListDeviceStatusHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeviceStatusHistoryResponse)
+	err := c.cc.Invoke(ctx, CameraService_ListDeviceStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*CreateDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateDeviceResponse)
+	err := c.cc.Invoke(ctx, CameraService_CreateDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeviceResponse)
+	err := c.cc.Invoke(ctx, CameraService_GetDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateDeviceResponse)
+	err := c.cc.Invoke(ctx, CameraService_UpdateDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteDeviceResponse)
+	err := c.cc.Invoke(ctx, CameraService_DeleteDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, CameraService_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) CreateEnrolmentToken(ctx context.Context, in *CreateEnrolmentTokenRequest, opts ...grpc.CallOption) (*CreateEnrolmentTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateEnrolmentTokenResponse)
+	err := c.cc.Invoke(ctx, CameraService_CreateEnrolmentToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) RevokeEnrolmentToken(ctx context.Context, in *RevokeEnrolmentTokenRequest, opts ...grpc.CallOption) (*RevokeEnrolmentTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeEnrolmentTokenResponse)
+	err := c.cc.Invoke(ctx, CameraService_RevokeEnrolmentToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) GetCameraStatusHistory(ctx context.Context, in *GetCameraStatusHistoryRequest, opts ...grpc.CallOption) (*GetCameraStatusHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCameraStatusHistoryResponse)
+	err := c.cc.Invoke(ctx, CameraService_GetCameraStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) GetCameraStatuses(ctx context.Context, in *GetCameraStatusesRequest, opts ...grpc.CallOption) (*GetCameraStatusesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCameraStatusesResponse)
+	err := c.cc.Invoke(ctx, CameraService_GetCameraStatuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cameraServiceClient) WatchCameraStatus(ctx context.Context, in *WatchCameraStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchCameraStatusResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CameraService_ServiceDesc.Streams[0], CameraService_WatchCameraStatus_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCameraStatusRequest, WatchCameraStatusResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CameraService_WatchCameraStatusClient = grpc.ServerStreamingClient[WatchCameraStatusResponse]
+
+func (c *cameraServiceClient) GetUptimeReport(ctx context.Context, in *GetUptimeReportRequest, opts ...grpc.CallOption) (*GetUptimeReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUptimeReportResponse)
+	err := c.cc.Invoke(ctx, CameraService_GetUptimeReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CameraServiceServer is the server API for CameraService service.
+// All implementations must embed UnimplementedCameraServiceServer
+// for forward compatibility.
+type CameraServiceServer interface {
+	GetCameraStatus(context.Context, *GetCameraStatusRequest) (*GetCameraStatusResponse, error)
+	SendDeviceCommand(context.Context, *SendDeviceCommandRequest) (*SendDeviceCommandResponse, error)
+	GetDeviceCommand(context.Context, *GetDeviceCommandRequest) (*GetDeviceCommandResponse, error)
+	ListDeviceCommands(context.Context, *ListDeviceCommandsRequest) (*ListDeviceCommandsResponse, error)
+	GetDeviceConfig(context.Context, *GetDeviceConfigRequest) (*GetDeviceConfigResponse, error)
+	SetDeviceConfig(context.Context, *SetDeviceConfigRequest) (*SetDeviceConfigResponse, error)
+	ListDeviceConfigHistory(context.Context, *ListDeviceConfigHistoryRequest) (*ListDeviceConfigHistoryResponse, error)
+	GetDeviceStatus(context.Context, *GetDeviceStatusRequest) (*GetDeviceStatusResponse, error)
+	ListDeviceStatusHistory(context.Context, *ListDeviceStatusHistoryRequest) (*ListDeviceStatusHistoryResponse, error)
+	CreateDevice(context.Context, *CreateDeviceRequest) (*CreateDeviceResponse, error)
+	GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error)
+	UpdateDevice(context.Context, *UpdateDeviceRequest) (*UpdateDeviceResponse, error)
+	DeleteDevice(context.Context, *DeleteDeviceRequest) (*DeleteDeviceResponse, error)
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	CreateEnrolmentToken(context.Context, *CreateEnrolmentTokenRequest) (*CreateEnrolmentTokenResponse, error)
+	RevokeEnrolmentToken(context.Context, *RevokeEnrolmentTokenRequest) (*RevokeEnrolmentTokenResponse, error)
+	GetCameraStatusHistory(context.Context, *GetCameraStatusHistoryRequest) (*GetCameraStatusHistoryResponse, error)
+	GetCameraStatuses(context.Context, *GetCameraStatusesRequest) (*GetCameraStatusesResponse, error)
+	WatchCameraStatus(*WatchCameraStatusRequest, grpc.ServerStreamingServer[WatchCameraStatusResponse]) error
+	GetUptimeReport(context.Context, *GetUptimeReportRequest) (*GetUptimeReportResponse, error)
+	mustEmbedUnimplementedCameraServiceServer()
+}
+
+// UnimplementedCameraServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCameraServiceServer struct{}
+
+func (UnimplementedCameraServiceServer) GetCameraStatus(context.Context, *GetCameraStatusRequest) (*GetCameraStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCameraStatus not implemented")
+}
+func (UnimplementedCameraServiceServer) SendDeviceCommand(context.Context, *SendDeviceCommandRequest) (*SendDeviceCommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendDeviceCommand not implemented")
+}
+func (UnimplementedCameraServiceServer) GetDeviceCommand(context.Context, *GetDeviceCommandRequest) (*GetDeviceCommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceCommand not implemented")
+}
+func (UnimplementedCameraServiceServer) ListDeviceCommands(context.Context, *ListDeviceCommandsRequest) (*ListDeviceCommandsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceCommands not implemented")
+}
+func (UnimplementedCameraServiceServer) GetDeviceConfig(context.Context, *GetDeviceConfigRequest) (*GetDeviceConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceConfig not implemented")
+}
+func (UnimplementedCameraServiceServer) SetDeviceConfig(context.Context, *SetDeviceConfigRequest) (*SetDeviceConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeviceConfig not implemented")
+}
+func (UnimplementedCameraServiceServer) ListDeviceConfigHistory(context.Context, *ListDeviceConfigHistoryRequest) (*ListDeviceConfigHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceConfigHistory not implemented")
+}
+func (UnimplementedCameraServiceServer) GetDeviceStatus(context.Context, *GetDeviceStatusRequest) (*GetDeviceStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceStatus not implemented")
+}
+func (UnimplementedCameraServiceServer) ListDeviceStatusHistory(context.Context, *ListDeviceStatusHistoryRequest) (*ListDeviceStatusHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceStatusHistory not implemented")
+}
+func (UnimplementedCameraServiceServer) CreateDevice(context.Context, *CreateDeviceRequest) (*CreateDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDevice not implemented")
+}
+func (UnimplementedCameraServiceServer) GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDevice not implemented")
+}
+func (UnimplementedCameraServiceServer) UpdateDevice(context.Context, *UpdateDeviceRequest) (*UpdateDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDevice not implemented")
+}
+func (UnimplementedCameraServiceServer) DeleteDevice(context.Context, *DeleteDeviceRequest) (*DeleteDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDevice not implemented")
+}
+func (UnimplementedCameraServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedCameraServiceServer) CreateEnrolmentToken(context.Context, *CreateEnrolmentTokenRequest) (*CreateEnrolmentTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEnrolmentToken not implemented")
+}
+func (UnimplementedCameraServiceServer) RevokeEnrolmentToken(context.Context, *RevokeEnrolmentTokenRequest) (*RevokeEnrolmentTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeEnrolmentToken not implemented")
+}
+func (UnimplementedCameraServiceServer) GetCameraStatusHistory(context.Context, *GetCameraStatusHistoryRequest) (*GetCameraStatusHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCameraStatusHistory not implemented")
+}
+func (UnimplementedCameraServiceServer) GetCameraStatuses(context.Context, *GetCameraStatusesRequest) (*GetCameraStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCameraStatuses not implemented")
+}
+func (UnimplementedCameraServiceServer) WatchCameraStatus(*WatchCameraStatusRequest, grpc.ServerStreamingServer[WatchCameraStatusResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCameraStatus not implemented")
+}
+func (UnimplementedCameraServiceServer) GetUptimeReport(context.Context, *GetUptimeReportRequest) (*GetUptimeReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUptimeReport not implemented")
+}
+func (UnimplementedCameraServiceServer) mustEmbedUnimplementedCameraServiceServer() {}
+func (UnimplementedCameraServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeCameraServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CameraServiceServer will
+// result in compilation errors.
+type UnsafeCameraServiceServer interface {
+	mustEmbedUnimplementedCameraServiceServer()
+}
+
+func RegisterCameraServiceServer(s grpc.ServiceRegistrar, srv CameraServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCameraServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CameraService_ServiceDesc, srv)
+}
+
+func _CameraService_GetCameraStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCameraStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).GetCameraStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_GetCameraStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).GetCameraStatus(ctx, req.(*GetCameraStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_SendDeviceCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendDeviceCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).SendDeviceCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_SendDeviceCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).SendDeviceCommand(ctx, req.(*SendDeviceCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_GetDeviceCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).GetDeviceCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_GetDeviceCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).GetDeviceCommand(ctx, req.(*GetDeviceCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_ListDeviceCommands_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceCommandsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).ListDeviceCommands(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_ListDeviceCommands_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).ListDeviceCommands(ctx, req.(*ListDeviceCommandsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_GetDeviceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).GetDeviceConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_GetDeviceConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).GetDeviceConfig(ctx, req.(*GetDeviceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_SetDeviceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeviceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).SetDeviceConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_SetDeviceConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).SetDeviceConfig(ctx, req.(*SetDeviceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_ListDeviceConfigHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceConfigHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).ListDeviceConfigHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_ListDeviceConfigHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).ListDeviceConfigHistory(ctx, req.(*ListDeviceConfigHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_GetDeviceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).GetDeviceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_GetDeviceStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).GetDeviceStatus(ctx, req.(*GetDeviceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_ListDeviceStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).ListDeviceStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_ListDeviceStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).ListDeviceStatusHistory(ctx, req.(*ListDeviceStatusHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_CreateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).CreateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_CreateDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).CreateDevice(ctx, req.(*CreateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).GetDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_GetDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).GetDevice(ctx, req.(*GetDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).UpdateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_UpdateDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).UpdateDevice(ctx, req.(*UpdateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_DeleteDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).DeleteDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_DeleteDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).DeleteDevice(ctx, req.(*DeleteDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_CreateEnrolmentToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEnrolmentTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).CreateEnrolmentToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_CreateEnrolmentToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).CreateEnrolmentToken(ctx, req.(*CreateEnrolmentTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_RevokeEnrolmentToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeEnrolmentTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).RevokeEnrolmentToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_RevokeEnrolmentToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).RevokeEnrolmentToken(ctx, req.(*RevokeEnrolmentTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_GetCameraStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCameraStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).GetCameraStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_GetCameraStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).GetCameraStatusHistory(ctx, req.(*GetCameraStatusHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_GetCameraStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCameraStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).GetCameraStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_GetCameraStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).GetCameraStatuses(ctx, req.(*GetCameraStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CameraService_WatchCameraStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCameraStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CameraServiceServer).WatchCameraStatus(m, &grpc.GenericServerStream[WatchCameraStatusRequest, WatchCameraStatusResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CameraService_WatchCameraStatusServer = grpc.ServerStreamingServer[WatchCameraStatusResponse]
+
+func _CameraService_GetUptimeReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUptimeReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CameraServiceServer).GetUptimeReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CameraService_GetUptimeReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CameraServiceServer).GetUptimeReport(ctx, req.(*GetUptimeReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CameraService_ServiceDesc is the grpc.ServiceDesc for CameraService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CameraService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "saladineye.CameraService",
+	HandlerType: (*CameraServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCameraStatus",
+			Handler:    _CameraService_GetCameraStatus_Handler,
+		},
+		{
+			MethodName: "SendDeviceCommand",
+			Handler:    _CameraService_SendDeviceCommand_Handler,
+		},
+		{
+			MethodName: "GetDeviceCommand",
+			Handler:    _CameraService_GetDeviceCommand_Handler,
+		},
+		{
+			MethodName: "ListDeviceCommands",
+			Handler:    _CameraService_ListDeviceCommands_Handler,
+		},
+		{
+			MethodName: "GetDeviceConfig",
+			Handler:    _CameraService_GetDeviceConfig_Handler,
+		},
+		{
+			MethodName: "SetDeviceConfig",
+			Handler:    _CameraService_SetDeviceConfig_Handler,
+		},
+		{
+			MethodName: "ListDeviceConfigHistory",
+			Handler:    _CameraService_ListDeviceConfigHistory_Handler,
+		},
+		{
+			MethodName: "GetDeviceStatus",
+			Handler:    _CameraService_GetDeviceStatus_Handler,
+		},
+		{
+			MethodName: "ListDeviceStatusHistory",
+			Handler:    _CameraService_ListDeviceStatusHistory_Handler,
+		},
+		{
+			MethodName: "CreateDevice",
+			Handler:    _CameraService_CreateDevice_Handler,
+		},
+		{
+			MethodName: "GetDevice",
+			Handler:    _CameraService_GetDevice_Handler,
+		},
+		{
+			MethodName: "UpdateDevice",
+			Handler:    _CameraService_UpdateDevice_Handler,
+		},
+		{
+			MethodName: "DeleteDevice",
+			Handler:    _CameraService_DeleteDevice_Handler,
+		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _CameraService_ListDevices_Handler,
+		},
+		{
+			MethodName: "CreateEnrolmentToken",
+			Handler:    _CameraService_CreateEnrolmentToken_Handler,
+		},
+		{
+			MethodName: "RevokeEnrolmentToken",
+			Handler:    _CameraService_RevokeEnrolmentToken_Handler,
+		},
+		{
+			MethodName: "GetCameraStatusHistory",
+			Handler:    _CameraService_GetCameraStatusHistory_Handler,
+		},
+		{
+			MethodName: "GetCameraStatuses",
+			Handler:    _CameraService_GetCameraStatuses_Handler,
+		},
+		{
+			MethodName: "GetUptimeReport",
+			Handler:    _CameraService_GetUptimeReport_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCameraStatus",
+			Handler:       _CameraService_WatchCameraStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "camera_service.proto",
+}
@@ -0,0 +1,83 @@
+package mediaservice
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+/**
+ * Client calls the media-service gRPC API, for the signing keys of the
+ * devices. Only the messages of the methods called are generated in this
+ * service, the methods are invoked by their full name.
+ */
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+// Singleton
+var (
+	client *Client
+	once   sync.Once
+)
+
+/**
+ * Connect to the media-service gRPC API at MEDIA_SERVICE_ADDR, with TLS when
+ * MEDIA_SERVICE_TLS is "true". The connection is made on the first call.
+ */
+func New() *Client {
+	once.Do(func() {
+		addr := os.Getenv("MEDIA_SERVICE_ADDR")
+		if addr == "" {
+			log.Fatal().Msg("MEDIA_SERVICE_ADDR environment variable not set")
+		}
+
+		transportCredentials := insecure.NewCredentials()
+		if os.Getenv("MEDIA_SERVICE_TLS") == "true" {
+			transportCredentials = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		}
+
+		conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(transportCredentials))
+		if err != nil {
+			log.Fatal().Msgf("invalid MEDIA_SERVICE_ADDR %s: %v", addr, err)
+		}
+
+		client = &Client{
+			conn: conn,
+		}
+	})
+	return client
+}
+
+// The calls carry the permission like the back-end passes the ones of its user
+func withPermission(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, constants.GRPC_METADATA_PERMISSIONS, constants.PERMISSION_MEDIA_MANAGE_DEVICE_KEYS)
+}
+
+func (c *Client) RegisterDeviceKey(ctx context.Context, request *genproto.RegisterDeviceKeyRequest) (*genproto.RegisterDeviceKeyResponse, error) {
+	response := &genproto.RegisterDeviceKeyResponse{}
+	if err := c.conn.Invoke(withPermission(ctx), "/saladineye.MediaService/RegisterDeviceKey", request, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *Client) RevokeDeviceKey(ctx context.Context, request *genproto.RevokeDeviceKeyRequest) (*genproto.RevokeDeviceKeyResponse, error) {
+	response := &genproto.RevokeDeviceKeyResponse{}
+	if err := c.conn.Invoke(withPermission(ctx), "/saladineye.MediaService/RevokeDeviceKey", request, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
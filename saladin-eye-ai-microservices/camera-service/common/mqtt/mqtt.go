@@ -0,0 +1,153 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/rs/zerolog/log"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/mqttconn"
+)
+
+var ErrNotConnected = errors.New("not connected to MQTT broker")
+
+// MessageHandler gets the topic and the payload of a received message
+type MessageHandler func(topic string, payload []byte)
+
+/**
+ * Client is the connection of the camera-service to the MQTT broker. It
+ * reconnects by itself with backoff, and makes the subscriptions again on
+ * every connect.
+ */
+type Client struct {
+	client paho.Client
+
+	mu            sync.Mutex
+	subscriptions map[string]MessageHandler
+}
+
+// Singleton
+var (
+	client *Client
+	once   sync.Once
+)
+
+/**
+ * Connect to the broker of MQTT_BROKER with MQTT_USERNAME and MQTT_PASSWORD,
+ * in the background. TLS and the client id are set up from the environment,
+ * see mqttconn.
+ */
+func New() *Client {
+	once.Do(func() {
+		broker := os.Getenv("MQTT_BROKER")
+		username := os.Getenv("MQTT_USERNAME")
+		password := os.Getenv("MQTT_PASSWORD")
+
+		if broker == "" || username == "" || password == "" {
+			log.Fatal().Msg("MQTT_BROKER, MQTT_USERNAME, and MQTT_PASSWORD environment variables must be set")
+		}
+
+		tlsConfig, err := mqttconn.TLSConfigFromEnv()
+		if err != nil {
+			log.Fatal().Msgf("invalid MQTT TLS config: %v", err)
+		}
+
+		clientId, err := mqttconn.ClientIdFromEnv("camera-service")
+		if err != nil {
+			log.Fatal().Msgf("invalid MQTT client id: %v", err)
+		}
+
+		client = &Client{
+			subscriptions: make(map[string]MessageHandler),
+		}
+
+		opts := paho.NewClientOptions().AddBroker(broker)
+		opts.SetClientID(clientId)
+		opts.SetUsername(username)
+		opts.SetPassword(password)
+		opts.SetTLSConfig(tlsConfig)
+		opts.SetKeepAlive(constants.MQTT_KEEP_ALIVE_SECONDS * time.Second)
+		opts.SetCleanSession(mqttconn.CleanSessionFromEnv())
+		opts.SetConnectRetry(true)
+		opts.SetConnectRetryInterval(constants.MQTT_RECONNECT_MIN_SECONDS * time.Second)
+		opts.SetAutoReconnect(true)
+		opts.SetMaxReconnectInterval(constants.MQTT_RECONNECT_MAX_SECONDS * time.Second)
+
+		opts.SetOnConnectHandler(func(pahoClient paho.Client) {
+			log.Info().Msgf("connected to MQTT broker %s", broker)
+
+			client.mu.Lock()
+			defer client.mu.Unlock()
+			for topicFilter, handler := range client.subscriptions {
+				go client.subscribe(topicFilter, handler)
+			}
+		})
+		opts.SetConnectionLostHandler(func(pahoClient paho.Client, err error) {
+			log.Error().Msgf("MQTT connection lost: %v", err)
+		})
+
+		client.client = paho.NewClient(opts)
+		client.client.Connect()
+	})
+
+	return client
+}
+
+// Subscribe to the topic filter with QoS 1, now when connected, and again on
+// every reconnect
+func (c *Client) Subscribe(topicFilter string, handler MessageHandler) {
+	c.mu.Lock()
+	c.subscriptions[topicFilter] = handler
+	c.mu.Unlock()
+
+	if c.client.IsConnectionOpen() {
+		go c.subscribe(topicFilter, handler)
+	}
+}
+
+func (c *Client) subscribe(topicFilter string, handler MessageHandler) {
+	for delay := constants.MQTT_RECONNECT_MIN_SECONDS * time.Second; c.client.IsConnectionOpen(); delay = min(2*delay, constants.MQTT_RECONNECT_MAX_SECONDS*time.Second) {
+		token := c.client.Subscribe(topicFilter, 1, func(pahoClient paho.Client, msg paho.Message) {
+			handler(msg.Topic(), msg.Payload())
+		})
+		if token.Wait() && token.Error() == nil {
+			log.Info().Msgf("subscribed to MQTT topic %s", topicFilter)
+			return
+		}
+
+		log.Error().Msgf("failed to subscribe to MQTT topic %s: %v", topicFilter, token.Error())
+		time.Sleep(delay)
+	}
+}
+
+// Publish with QoS 1, returns once the broker has acknowledged it
+func (c *Client) Publish(ctx context.Context, topic string, payload []byte) error {
+	return c.publish(ctx, topic, payload, false)
+}
+
+// PublishRetained replaces the retained message of the topic, the one a
+// client gets when it subscribes
+func (c *Client) PublishRetained(ctx context.Context, topic string, payload []byte) error {
+	return c.publish(ctx, topic, payload, true)
+}
+
+func (c *Client) publish(ctx context.Context, topic string, payload []byte, retained bool) error {
+	if !c.client.IsConnectionOpen() {
+		return ErrNotConnected
+	}
+
+	token := c.client.Publish(topic, 1, retained, payload)
+
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return fmt.Errorf("failed to publish MQTT message: %w", ctx.Err())
+	}
+}
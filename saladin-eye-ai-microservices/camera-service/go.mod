@@ -3,6 +3,8 @@ module github.com/andypmw/saladin-eye-ai/camera-service
 go 1.22
 
 require (
+	github.com/andypmw/saladin-eye-ai/mqttconn v0.0.0
+	github.com/eclipse/paho.mqtt.golang v1.5.0
 	github.com/go-redis/redis/v8 v8.11.5
 	github.com/rs/zerolog v1.33.0
 	google.golang.org/grpc v1.65.0
@@ -12,10 +14,14 @@ require (
 require (
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/gorilla/websocket v1.5.3 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.19 // indirect
 	golang.org/x/net v0.27.0 // indirect
+	golang.org/x/sync v0.7.0 // indirect
 	golang.org/x/sys v0.22.0 // indirect
 	golang.org/x/text v0.16.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
 )
+
+replace github.com/andypmw/saladin-eye-ai/mqttconn => ../mqttconn
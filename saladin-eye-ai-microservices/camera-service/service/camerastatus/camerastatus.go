@@ -0,0 +1,517 @@
+package camerastatus
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/cache"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/registry"
+)
+
+/**
+ * Set the status of the camera when it changes, and return the previous
+ * status, reason and since, or nil when it didn't change. Every instance
+ * detects the transitions, only one of them gets to record each.
+ *
+ * KEYS[1] the status hash, KEYS[2] the set of online cameras
+ * ARGV[1] the status, ARGV[2] since, ARGV[3] the reason, ARGV[4] the device id
+ */
+var transitionScript = redis.NewScript(`
+local current = redis.call('HMGET', KEYS[1], 'status', 'reason', 'since')
+if current[1] == ARGV[1] then
+	return false
+end
+redis.call('HSET', KEYS[1], 'status', ARGV[1], 'reason', ARGV[3], 'since', ARGV[2])
+if ARGV[1] == 'online' then
+	redis.call('SADD', KEYS[2], ARGV[4])
+else
+	redis.call('SREM', KEYS[2], ARGV[4])
+end
+return {current[1] or '', current[2] or '', current[3] or ''}
+`)
+
+type CameraStatusServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) CameraStatusServiceIface {
+	return &CameraStatusServiceImpl{
+		rdb: rdb,
+	}
+}
+
+func validateDeviceId(deviceId string) error {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	return nil
+}
+
+/**
+ * Follow the presence keys the camera-mqtt-listener sets on every heartbeat,
+ * until the context is done. A key set is the camera online, a key expired is
+ * the camera offline.
+ *
+ * The keyspace notifications are instant but Pub/Sub can lose them, and Redis
+ * only sends them when configured to. The sweeper runs alongside and catches
+ * what they missed.
+ */
+func (cs *CameraStatusServiceImpl) Watch(ctx context.Context) {
+	pubsub := cache.PSubscribe(ctx, constants.REDIS_KEYSPACE_DEVICE_ONLINE_PRESENCE_PATTERN)
+	defer pubsub.Close()
+	notifications := pubsub.Channel()
+
+	ticker := time.NewTicker(constants.CAMERA_STATUS_SWEEP_INTERVAL_SECONDS * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-notifications:
+			if !ok {
+				return
+			}
+			cs.handleNotification(ctx, notification)
+		case <-ticker.C:
+			if err := cs.Sweep(ctx); err != nil {
+				log.Error().Msgf("failed to sweep camera statuses: %v", err)
+			}
+		}
+	}
+}
+
+func (cs *CameraStatusServiceImpl) handleNotification(ctx context.Context, notification *redis.Message) {
+	prefix := strings.TrimSuffix(constants.REDIS_KEY_DEVICE_ONLINE_PRESENCE_FORMAT, "%s")
+	_, deviceId, ok := strings.Cut(notification.Channel, prefix)
+	if !ok {
+		return
+	}
+
+	var err error
+	switch notification.Payload {
+	case "set", "expire":
+		err = cs.transition(ctx, deviceId, STATUS_ONLINE, REASON_HEARTBEAT, time.Now())
+	case "expired":
+		err = cs.transition(ctx, deviceId, STATUS_OFFLINE, REASON_HEARTBEAT_TIMEOUT, time.Now())
+	}
+	if err != nil {
+		log.Error().Msgf("failed to record status of device_id %s: %v", deviceId, err)
+	}
+}
+
+/**
+ * Compare the presence keys with the recorded statuses: a camera with a key
+ * is online, an online camera without one has timed out.
+ */
+func (cs *CameraStatusServiceImpl) Sweep(ctx context.Context) error {
+	now := time.Now()
+	present := make(map[string]bool)
+
+	prefix := strings.TrimSuffix(constants.REDIS_KEY_DEVICE_ONLINE_PRESENCE_FORMAT, "%s")
+	iter := cs.rdb.Scan(ctx, 0, prefix+"*", 1000).Iterator()
+	for iter.Next(ctx) {
+		present[strings.TrimPrefix(iter.Val(), prefix)] = true
+	}
+	if err := iter.Err(); err != nil {
+		log.Error().Msgf("failed to scan presence keys in Redis: %v", err)
+		return fmt.Errorf("failed to scan presence keys in Redis: %w", err)
+	}
+
+	online, err := cs.rdb.SMembers(ctx, constants.REDIS_KEY_CAMERAS_ONLINE).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get online cameras from Redis: %v", err)
+		return fmt.Errorf("failed to get online cameras from Redis: %w", err)
+	}
+
+	wasOnline := make(map[string]bool, len(online))
+	for _, deviceId := range online {
+		wasOnline[deviceId] = true
+		if !present[deviceId] {
+			if err := cs.transition(ctx, deviceId, STATUS_OFFLINE, REASON_HEARTBEAT_TIMEOUT, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	for deviceId := range present {
+		if !wasOnline[deviceId] {
+			if err := cs.transition(ctx, deviceId, STATUS_ONLINE, REASON_HEARTBEAT, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+/**
+ * The broker published the Last Will of the camera, it is offline right away.
+ * The presence key goes too, so the sweeper doesn't take it as online until
+ * the next heartbeat.
+ */
+func (cs *CameraStatusServiceImpl) LastWill(ctx context.Context, deviceId string) error {
+	if err := validateDeviceId(deviceId); err != nil {
+		return err
+	}
+
+	if err := cs.rdb.Del(ctx, fmt.Sprintf(constants.REDIS_KEY_DEVICE_ONLINE_PRESENCE_FORMAT, deviceId)).Err(); err != nil {
+		log.Error().Msgf("failed to delete presence key from Redis: %v", err)
+		return fmt.Errorf("failed to delete presence key from Redis: %w", err)
+	}
+
+	return cs.transition(ctx, deviceId, STATUS_OFFLINE, REASON_LAST_WILL, time.Now())
+}
+
+/**
+ * Record the transition of the camera, when its status changed: the period
+ * that ended goes to the history, and the event is published.
+ */
+func (cs *CameraStatusServiceImpl) transition(ctx context.Context, deviceId, newStatus, reason string, at time.Time) error {
+	statusKey := fmt.Sprintf(constants.REDIS_KEY_CAMERA_STATUS_FORMAT, deviceId)
+
+	result, err := transitionScript.Run(ctx, cs.rdb, []string{statusKey, constants.REDIS_KEY_CAMERAS_ONLINE}, newStatus, at.Unix(), reason, deviceId).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil
+		}
+
+		log.Error().Msgf("failed to set camera status in Redis: %v", err)
+		return fmt.Errorf("failed to set camera status in Redis: %w", err)
+	}
+
+	event := &genproto.CameraStatusEvent{
+		DeviceId: deviceId,
+		Status:   newStatus,
+		Reason:   reason,
+		At:       at.Unix(),
+	}
+
+	previous, _ := result.([]interface{})
+	if len(previous) == 3 && previous[0] != "" {
+		startedAt, _ := strconv.ParseInt(fmt.Sprint(previous[2]), 10, 64)
+		event.Previous = &genproto.CameraStatusPeriod{
+			Status:          fmt.Sprint(previous[0]),
+			Reason:          fmt.Sprint(previous[1]),
+			StartedAt:       startedAt,
+			EndedAt:         at.Unix(),
+			DurationSeconds: max(at.Unix()-startedAt, 0),
+		}
+
+		if err := cs.addToHistory(ctx, deviceId, event.Previous, at); err != nil {
+			return err
+		}
+	}
+
+	log.Info().Msgf("device_id %s is %s, %s", deviceId, newStatus, reason)
+
+	eventByteArr, err := proto.Marshal(event)
+	if err != nil {
+		log.Error().Msgf("failed to marshal CameraStatusEvent: %v", err)
+		return fmt.Errorf("failed to marshal CameraStatusEvent: %w", err)
+	}
+
+	if err := cs.rdb.Publish(ctx, constants.REDIS_CHANNEL_CAMERA_STATUS, eventByteArr).Err(); err != nil {
+		log.Error().Msgf("failed to publish camera status event: %v", err)
+		return fmt.Errorf("failed to publish camera status event: %w", err)
+	}
+
+	return nil
+}
+
+func (cs *CameraStatusServiceImpl) addToHistory(ctx context.Context, deviceId string, period *genproto.CameraStatusPeriod, now time.Time) error {
+	periodByteArr, err := proto.Marshal(period)
+	if err != nil {
+		log.Error().Msgf("failed to marshal CameraStatusPeriod: %v", err)
+		return fmt.Errorf("failed to marshal CameraStatusPeriod: %w", err)
+	}
+
+	historyKey := fmt.Sprintf(constants.REDIS_KEY_CAMERA_STATUS_HISTORY_FORMAT, deviceId)
+	retention := constants.CAMERA_STATUS_HISTORY_RETENTION_DAYS * 24 * time.Hour
+	oldest := now.Add(-retention).Unix()
+
+	_, err = cs.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, historyKey, &redis.Z{Score: float64(period.StartedAt), Member: periodByteArr})
+		pipe.ZRemRangeByScore(ctx, historyKey, "-inf", "("+strconv.FormatInt(oldest, 10))
+		pipe.Expire(ctx, historyKey, retention)
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to add camera status period in Redis: %v", err)
+		return fmt.Errorf("failed to add camera status period in Redis: %w", err)
+	}
+
+	return nil
+}
+
+/**
+ * Return the current period of the camera, and the periods that started
+ * between from and to, newest first. The last CAMERA_STATUS_HISTORY_DEFAULT_DAYS
+ * when both are zero.
+ */
+func (cs *CameraStatusServiceImpl) History(ctx context.Context, deviceId string, from, to time.Time, limit int) (*genproto.CameraStatusPeriod, []*genproto.CameraStatusPeriod, error) {
+	if err := validateDeviceId(deviceId); err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if to.IsZero() {
+		to = now
+	}
+	if from.IsZero() {
+		from = to.Add(-constants.CAMERA_STATUS_HISTORY_DEFAULT_DAYS * 24 * time.Hour)
+	}
+	if from.After(to) {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "from must not be after to")
+	}
+
+	if limit <= 0 {
+		limit = constants.CAMERA_STATUS_HISTORY_DEFAULT_LIMIT
+	}
+	limit = min(limit, constants.CAMERA_STATUS_HISTORY_MAX_LIMIT)
+
+	current, err := cs.current(ctx, deviceId, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	historyKey := fmt.Sprintf(constants.REDIS_KEY_CAMERA_STATUS_HISTORY_FORMAT, deviceId)
+	periodsByteArr, err := cs.rdb.ZRevRangeByScore(ctx, historyKey, &redis.ZRangeBy{
+		Min:   strconv.FormatInt(from.Unix(), 10),
+		Max:   strconv.FormatInt(to.Unix(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get camera status history from Redis: %v", err)
+		return nil, nil, fmt.Errorf("failed to get camera status history from Redis: %w", err)
+	}
+
+	periods := make([]*genproto.CameraStatusPeriod, 0, len(periodsByteArr))
+	for _, periodByteArr := range periodsByteArr {
+		period := &genproto.CameraStatusPeriod{}
+		if err := proto.Unmarshal([]byte(periodByteArr), period); err != nil {
+			log.Error().Msgf("failed to unmarshal CameraStatusPeriod: %v", err)
+			return nil, nil, fmt.Errorf("failed to unmarshal CameraStatusPeriod: %w", err)
+		}
+		periods = append(periods, period)
+	}
+
+	return current, periods, nil
+}
+
+// The period the camera is in, nil when it was never seen
+func (cs *CameraStatusServiceImpl) current(ctx context.Context, deviceId string, now time.Time) (*genproto.CameraStatusPeriod, error) {
+	fields, err := cs.rdb.HGetAll(ctx, fmt.Sprintf(constants.REDIS_KEY_CAMERA_STATUS_FORMAT, deviceId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get camera status from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get camera status from Redis: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	since, _ := strconv.ParseInt(fields["since"], 10, 64)
+
+	return &genproto.CameraStatusPeriod{
+		Status:          fields["status"],
+		Reason:          fields["reason"],
+		StartedAt:       since,
+		DurationSeconds: max(now.Unix()-since, 0),
+	}, nil
+}
+
+func validateDeviceIds(deviceIds []string) error {
+	if len(deviceIds) == 0 {
+		return status.Errorf(codes.InvalidArgument, "device_ids must not be empty")
+	}
+	if len(deviceIds) > constants.CAMERA_STATUS_MAX_DEVICE_IDS {
+		return status.Errorf(codes.InvalidArgument, "too many device_ids: %d, the maximum is %d", len(deviceIds), constants.CAMERA_STATUS_MAX_DEVICE_IDS)
+	}
+
+	for _, deviceId := range deviceIds {
+		if err := validateDeviceId(deviceId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Return the status of every camera, in the order of the device ids, from one
+ * pipeline of Redis reads.
+ *
+ * The presence key holds the time of the last heartbeat until the offline
+ * threshold of the camera, then the last seen time is that of its last
+ * DeviceStatus, when the firmware sends one. A camera is degraded past half
+ * of its threshold, it missed a heartbeat.
+ */
+func (cs *CameraStatusServiceImpl) Statuses(ctx context.Context, deviceIds []string) ([]*genproto.CameraStatus, error) {
+	if err := validateDeviceIds(deviceIds); err != nil {
+		return nil, err
+	}
+
+	presenceCmds := make([]*redis.StringCmd, len(deviceIds))
+	thresholdCmds := make([]*redis.StringCmd, len(deviceIds))
+	sinceCmds := make([]*redis.StringCmd, len(deviceIds))
+	deviceStatusCmds := make([]*redis.StringCmd, len(deviceIds))
+
+	now := time.Now()
+	_, err := cs.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, deviceId := range deviceIds {
+			presenceCmds[i] = pipe.Get(ctx, fmt.Sprintf(constants.REDIS_KEY_DEVICE_ONLINE_PRESENCE_FORMAT, deviceId))
+			thresholdCmds[i] = pipe.HGet(ctx, fmt.Sprintf(constants.REDIS_KEY_DEVICE_FORMAT, deviceId), registry.FIELD_OFFLINE_THRESHOLD_SECONDS)
+			sinceCmds[i] = pipe.HGet(ctx, fmt.Sprintf(constants.REDIS_KEY_CAMERA_STATUS_FORMAT, deviceId), "since")
+			deviceStatusCmds[i] = pipe.Get(ctx, fmt.Sprintf(constants.REDIS_KEY_DEVICE_STATUS_FORMAT, deviceId))
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		log.Error().Msgf("failed to get camera statuses from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get camera statuses from Redis: %w", err)
+	}
+
+	statuses := make([]*genproto.CameraStatus, len(deviceIds))
+	for i, deviceId := range deviceIds {
+		cameraStatus := &genproto.CameraStatus{
+			DeviceId: deviceId,
+			Status:   STATUS_OFFLINE,
+		}
+
+		// An older camera-mqtt-listener sets the presence key to "1"
+		if presence, err := presenceCmds[i].Result(); err == nil {
+			cameraStatus.IsOnline = true
+			if presence != "1" {
+				cameraStatus.LastSeen, _ = strconv.ParseInt(presence, 10, 64)
+			}
+		}
+
+		cameraStatus.Since, _ = strconv.ParseInt(sinceCmds[i].Val(), 10, 64)
+
+		if deviceStatusByteArr, err := deviceStatusCmds[i].Bytes(); err == nil {
+			deviceStatus := &genproto.DeviceStatus{}
+			if err := proto.Unmarshal(deviceStatusByteArr, deviceStatus); err != nil {
+				log.Error().Msgf("failed to unmarshal DeviceStatus of device_id %s: %v", deviceId, err)
+			}
+			cameraStatus.LastSeen = max(cameraStatus.LastSeen, deviceStatus.ReceivedAt)
+		}
+
+		if cameraStatus.IsOnline {
+			threshold, _ := strconv.ParseInt(thresholdCmds[i].Val(), 10, 64)
+			if threshold <= 0 {
+				threshold = constants.DEVICE_OFFLINE_THRESHOLD_DEFAULT_SECONDS
+			}
+
+			cameraStatus.Status = STATUS_ONLINE
+			if cameraStatus.LastSeen > 0 && now.Unix()-cameraStatus.LastSeen > threshold/2 {
+				cameraStatus.Status = STATUS_DEGRADED
+			}
+		}
+
+		statuses[i] = cameraStatus
+	}
+
+	return statuses, nil
+}
+
+/**
+ * Send the status of every camera, then again every time one of them goes
+ * online or offline, until the context is done or send fails.
+ *
+ * It subscribes before reading the statuses, a transition in between is sent
+ * twice rather than missed. A camera turns degraded without any event, by
+ * missing a heartbeat, so the cameras are also checked every
+ * CAMERA_STATUS_STREAM_RECHECK_SECONDS and sent when their status changed.
+ */
+func (cs *CameraStatusServiceImpl) Stream(ctx context.Context, deviceIds []string, send func(*genproto.CameraStatus) error) error {
+	if err := validateDeviceIds(deviceIds); err != nil {
+		return err
+	}
+
+	watched := make(map[string]bool, len(deviceIds))
+	for _, deviceId := range deviceIds {
+		watched[deviceId] = true
+	}
+
+	pubsub := cache.Subscribe(ctx, constants.REDIS_CHANNEL_CAMERA_STATUS)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		log.Error().Msgf("failed to subscribe to camera status events: %v", err)
+		return fmt.Errorf("failed to subscribe to camera status events: %w", err)
+	}
+	events := pubsub.Channel()
+
+	// The status last sent of every camera
+	sent := make(map[string]string, len(deviceIds))
+
+	statuses, err := cs.Statuses(ctx, deviceIds)
+	if err != nil {
+		return err
+	}
+	for _, cameraStatus := range statuses {
+		if err := send(cameraStatus); err != nil {
+			return err
+		}
+		sent[cameraStatus.DeviceId] = cameraStatus.Status
+	}
+
+	ticker := time.NewTicker(constants.CAMERA_STATUS_STREAM_RECHECK_SECONDS * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			statuses, err := cs.Statuses(ctx, deviceIds)
+			if err != nil {
+				return err
+			}
+			for _, cameraStatus := range statuses {
+				if cameraStatus.Status == sent[cameraStatus.DeviceId] {
+					continue
+				}
+				if err := send(cameraStatus); err != nil {
+					return err
+				}
+				sent[cameraStatus.DeviceId] = cameraStatus.Status
+			}
+		case message, ok := <-events:
+			if !ok {
+				return fmt.Errorf("camera status events subscription closed")
+			}
+
+			event := &genproto.CameraStatusEvent{}
+			if err := proto.Unmarshal([]byte(message.Payload), event); err != nil {
+				log.Error().Msgf("failed to unmarshal CameraStatusEvent: %v", err)
+				continue
+			}
+			if !watched[event.DeviceId] {
+				continue
+			}
+
+			statuses, err := cs.Statuses(ctx, []string{event.DeviceId})
+			if err != nil {
+				return err
+			}
+			if err := send(statuses[0]); err != nil {
+				return err
+			}
+			sent[event.DeviceId] = statuses[0].Status
+		}
+	}
+}
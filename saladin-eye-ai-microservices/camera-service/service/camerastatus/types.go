@@ -0,0 +1,32 @@
+package camerastatus
+
+import (
+	"context"
+	"time"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+const (
+	STATUS_ONLINE  = "online"
+	STATUS_OFFLINE = "offline"
+
+	// Only reported, the history has online and offline periods
+	STATUS_DEGRADED = "degraded"
+)
+
+// What made the camera go online or offline
+const (
+	REASON_HEARTBEAT         = "heartbeat"
+	REASON_HEARTBEAT_TIMEOUT = "heartbeat_timeout"
+	REASON_LAST_WILL         = "last_will"
+)
+
+type CameraStatusServiceIface interface {
+	Watch(ctx context.Context)
+	Sweep(ctx context.Context) error
+	LastWill(ctx context.Context, deviceId string) error
+	Statuses(ctx context.Context, deviceIds []string) ([]*genproto.CameraStatus, error)
+	Stream(ctx context.Context, deviceIds []string, send func(*genproto.CameraStatus) error) error
+	History(ctx context.Context, deviceId string, from, to time.Time, limit int) (*genproto.CameraStatusPeriod, []*genproto.CameraStatusPeriod, error)
+}
@@ -0,0 +1,410 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/cache"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+const historyTTL = constants.DEVICE_COMMAND_HISTORY_TTL_DAYS * 24 * time.Hour
+
+type CommandServiceImpl struct {
+	rdb       redis.Cmdable
+	publisher Publisher
+}
+
+func New(rdb redis.Cmdable, publisher Publisher) CommandServiceIface {
+	return &CommandServiceImpl{
+		rdb:       rdb,
+		publisher: publisher,
+	}
+}
+
+/**
+ * Send the command to every device, as a DeviceCommand with its own command
+ * id on the command topic of the device:
+ *   saladin-eye/device/[device-id]/command
+ *
+ * Every command is recorded, and stays in the history of its device for
+ * DEVICE_COMMAND_HISTORY_TTL_DAYS. The device has until the timeout to
+ * acknowledge it, after that the command is timed out and the device must
+ * not run it anymore.
+ *
+ * With waitForAck it only returns once every device has acknowledged, or
+ * at the timeout.
+ */
+func (cs *CommandServiceImpl) Send(ctx context.Context, deviceIds []string, command *genproto.DeviceCommand, timeout time.Duration, waitForAck bool) ([]*genproto.DeviceCommandRecord, error) {
+	deviceIds, err := validateDeviceIds(deviceIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCommand(command); err != nil {
+		return nil, err
+	}
+
+	if timeout == 0 {
+		timeout = constants.DEVICE_COMMAND_DEFAULT_TIMEOUT_SECONDS * time.Second
+	}
+	if timeout < 0 || timeout > constants.DEVICE_COMMAND_MAX_TIMEOUT_SECONDS*time.Second {
+		return nil, status.Errorf(codes.InvalidArgument, "timeout must be at most %d seconds", constants.DEVICE_COMMAND_MAX_TIMEOUT_SECONDS)
+	}
+
+	// Subscribed before publishing, so no acknowledgement is missed
+	var acks <-chan *redis.Message
+	if waitForAck {
+		pubsub := cache.Subscribe(ctx, constants.REDIS_CHANNEL_DEVICE_COMMAND_ACK)
+		defer pubsub.Close()
+
+		if _, err := pubsub.Receive(ctx); err != nil {
+			log.Error().Msgf("failed to subscribe to device command acknowledgements: %v", err)
+			return nil, fmt.Errorf("failed to subscribe to device command acknowledgements: %w", err)
+		}
+		acks = pubsub.Channel()
+	}
+
+	now := time.Now().UTC()
+	records := make([]*genproto.DeviceCommandRecord, 0, len(deviceIds))
+	for _, deviceId := range deviceIds {
+		record, err := cs.send(ctx, deviceId, command, now, now.Add(timeout))
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	if waitForAck {
+		records = cs.waitForAcks(ctx, records, acks, now.Add(timeout))
+	}
+
+	return records, nil
+}
+
+func (cs *CommandServiceImpl) send(ctx context.Context, deviceId string, command *genproto.DeviceCommand, sentAt, timeoutAt time.Time) (*genproto.DeviceCommandRecord, error) {
+	commandIdBytes := make([]byte, 16)
+	if _, err := rand.Read(commandIdBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate command id: %w", err)
+	}
+
+	deviceCommand := proto.Clone(command).(*genproto.DeviceCommand)
+	deviceCommand.CommandId = hex.EncodeToString(commandIdBytes)
+	deviceCommand.DeviceId = deviceId
+	deviceCommand.IssuedAt = sentAt.Unix()
+	deviceCommand.ExpiresAt = timeoutAt.Unix()
+
+	record := &genproto.DeviceCommandRecord{
+		CommandId: deviceCommand.CommandId,
+		DeviceId:  deviceId,
+		Command:   deviceCommand,
+		Status:    STATUS_SENT,
+		SentAt:    sentAt.Unix(),
+		TimeoutAt: timeoutAt.Unix(),
+	}
+
+	// Recorded first, the acknowledgement can come back before Publish returns
+	if err := cs.save(ctx, record, true); err != nil {
+		return nil, err
+	}
+
+	payload, err := proto.Marshal(deviceCommand)
+	if err != nil {
+		log.Error().Msgf("failed to marshal DeviceCommand: %v", err)
+		return nil, fmt.Errorf("failed to marshal DeviceCommand: %w", err)
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, constants.MQTT_PUBLISH_TIMEOUT_SECONDS*time.Second)
+	defer cancel()
+
+	topic := fmt.Sprintf(constants.MQTT_TOPIC_DEVICE_COMMAND_FORMAT, deviceId)
+	if err := cs.publisher.Publish(publishCtx, topic, payload); err != nil {
+		log.Error().Msgf("failed to publish command %s to device_id %s: %v", record.CommandId, deviceId, err)
+
+		record.Status = STATUS_PUBLISH_FAILED
+		record.Message = err.Error()
+		if err := cs.save(ctx, record, false); err != nil {
+			return nil, err
+		}
+		return record, nil
+	}
+
+	log.Info().Msgf("sent command %s to device_id %s", record.CommandId, deviceId)
+
+	return record, nil
+}
+
+// Wait until every command is final or timed out, the acknowledgements come
+// from HandleAck of whichever instance received them
+func (cs *CommandServiceImpl) waitForAcks(ctx context.Context, records []*genproto.DeviceCommandRecord, acks <-chan *redis.Message, timeoutAt time.Time) []*genproto.DeviceCommandRecord {
+	pending := make(map[string]int)
+	for i, record := range records {
+		if !isFinal(record.Status) {
+			pending[record.CommandId] = i
+		}
+	}
+
+	timer := time.NewTimer(time.Until(timeoutAt))
+	defer timer.Stop()
+
+	for len(pending) > 0 {
+		select {
+		case msg, ok := <-acks:
+			if !ok {
+				return cs.reload(ctx, records)
+			}
+
+			i, ok := pending[msg.Payload]
+			if !ok {
+				continue
+			}
+
+			record, err := cs.Get(ctx, msg.Payload)
+			if err != nil {
+				continue
+			}
+			records[i] = record
+			if isFinal(record.Status) {
+				delete(pending, msg.Payload)
+			}
+		case <-timer.C:
+			return cs.reload(ctx, records)
+		case <-ctx.Done():
+			return cs.reload(ctx, records)
+		}
+	}
+
+	return records
+}
+
+func (cs *CommandServiceImpl) reload(ctx context.Context, records []*genproto.DeviceCommandRecord) []*genproto.DeviceCommandRecord {
+	for i, record := range records {
+		if reloaded, err := cs.Get(context.WithoutCancel(ctx), record.CommandId); err == nil {
+			records[i] = reloaded
+		} else {
+			records[i] = withTimeout(record, time.Now())
+		}
+	}
+
+	return records
+}
+
+// Get the command, with its status as of now
+func (cs *CommandServiceImpl) Get(ctx context.Context, commandId string) (*genproto.DeviceCommandRecord, error) {
+	commandId = strings.TrimSpace(commandId)
+	if commandId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "missing command_id")
+	}
+
+	recordByteArr, err := cs.rdb.Get(ctx, fmt.Sprintf(constants.REDIS_KEY_DEVICE_COMMAND_FORMAT, commandId)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, status.Errorf(codes.NotFound, "command %s not found", commandId)
+		}
+
+		log.Error().Msgf("failed to get device command from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device command from Redis: %w", err)
+	}
+
+	record := &genproto.DeviceCommandRecord{}
+	if err := proto.Unmarshal(recordByteArr, record); err != nil {
+		log.Error().Msgf("failed to unmarshal DeviceCommandRecord: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal DeviceCommandRecord: %w", err)
+	}
+
+	return withTimeout(record, time.Now()), nil
+}
+
+// The command history of the device, newest first
+func (cs *CommandServiceImpl) List(ctx context.Context, deviceId string, limit int) ([]*genproto.DeviceCommandRecord, error) {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return nil, status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	if limit <= 0 {
+		limit = constants.DEVICE_COMMAND_LIST_DEFAULT_LIMIT
+	}
+	limit = min(limit, constants.DEVICE_COMMAND_LIST_MAX_LIMIT)
+
+	historyKey := fmt.Sprintf(constants.REDIS_KEY_DEVICE_COMMAND_HISTORY_FORMAT, deviceId)
+	commandIds, err := cs.rdb.ZRevRange(ctx, historyKey, 0, int64(limit-1)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get device command history from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device command history from Redis: %w", err)
+	}
+
+	records := make([]*genproto.DeviceCommandRecord, 0, len(commandIds))
+	for _, commandId := range commandIds {
+		record, err := cs.Get(ctx, commandId)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				continue
+			}
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+/**
+ * Record the DeviceCommandAck a device published on its ack topic:
+ *   saladin-eye/server/camera-service/command-ack/[device-id]
+ *
+ * Only the device the command was sent to can acknowledge it, until the
+ * command is final or timed out.
+ */
+func (cs *CommandServiceImpl) HandleAck(ctx context.Context, topic string, payload []byte) error {
+	topicDeviceId := strings.TrimPrefix(topic, constants.MQTT_TOPIC_COMMAND_ACK_PREFIX+"/")
+
+	ack := &genproto.DeviceCommandAck{}
+	if err := proto.Unmarshal(payload, ack); err != nil {
+		return fmt.Errorf("failed to unmarshal DeviceCommandAck: %w", err)
+	}
+
+	record, err := cs.Get(ctx, ack.CommandId)
+	if err != nil {
+		return err
+	}
+
+	if record.DeviceId != topicDeviceId || record.DeviceId != ack.DeviceId {
+		return fmt.Errorf("command %s is not a command of device_id %s", ack.CommandId, topicDeviceId)
+	}
+
+	switch ack.Status {
+	case STATUS_ACCEPTED, STATUS_SUCCEEDED, STATUS_FAILED, STATUS_REJECTED, STATUS_EXPIRED:
+	default:
+		return fmt.Errorf("invalid acknowledgement status %s of command %s", ack.Status, ack.CommandId)
+	}
+
+	if isFinal(record.Status) {
+		log.Warn().Msgf("ignoring %s acknowledgement of command %s, already %s", ack.Status, ack.CommandId, record.Status)
+		return nil
+	}
+
+	record.Status = ack.Status
+	record.Message = ack.Message
+	record.AckedAt = time.Now().UTC().Unix()
+	if err := cs.save(ctx, record, false); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("command %s of device_id %s %s", ack.CommandId, ack.DeviceId, ack.Status)
+
+	if err := cs.rdb.Publish(ctx, constants.REDIS_CHANNEL_DEVICE_COMMAND_ACK, ack.CommandId).Err(); err != nil {
+		log.Error().Msgf("failed to publish device command acknowledgement: %v", err)
+	}
+
+	return nil
+}
+
+func (cs *CommandServiceImpl) save(ctx context.Context, record *genproto.DeviceCommandRecord, isNew bool) error {
+	recordByteArr, err := proto.Marshal(record)
+	if err != nil {
+		log.Error().Msgf("failed to marshal DeviceCommandRecord: %v", err)
+		return fmt.Errorf("failed to marshal DeviceCommandRecord: %w", err)
+	}
+
+	recordKey := fmt.Sprintf(constants.REDIS_KEY_DEVICE_COMMAND_FORMAT, record.CommandId)
+	if !isNew {
+		if err := cs.rdb.Set(ctx, recordKey, recordByteArr, redis.KeepTTL).Err(); err != nil {
+			log.Error().Msgf("failed to set device command in Redis: %v", err)
+			return fmt.Errorf("failed to set device command in Redis: %w", err)
+		}
+		return nil
+	}
+
+	historyKey := fmt.Sprintf(constants.REDIS_KEY_DEVICE_COMMAND_HISTORY_FORMAT, record.DeviceId)
+	_, err = cs.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, recordKey, recordByteArr, historyTTL)
+		pipe.ZAdd(ctx, historyKey, &redis.Z{Score: float64(record.SentAt), Member: record.CommandId})
+		pipe.ZRemRangeByRank(ctx, historyKey, 0, -constants.DEVICE_COMMAND_HISTORY_MAX_ENTRIES-1)
+		pipe.Expire(ctx, historyKey, historyTTL)
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to record device command in Redis: %v", err)
+		return fmt.Errorf("failed to record device command in Redis: %w", err)
+	}
+
+	return nil
+}
+
+func isFinal(commandStatus string) bool {
+	return commandStatus != STATUS_SENT && commandStatus != STATUS_ACCEPTED
+}
+
+// A command not acknowledged as final by its timeout is timed out, there is
+// no job that marks it, it is seen when read
+func withTimeout(record *genproto.DeviceCommandRecord, now time.Time) *genproto.DeviceCommandRecord {
+	if !isFinal(record.Status) && now.Unix() > record.TimeoutAt {
+		record.Status = STATUS_TIMED_OUT
+	}
+
+	return record
+}
+
+func validateDeviceIds(deviceIds []string) ([]string, error) {
+	if len(deviceIds) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "missing device_ids")
+	}
+	if len(deviceIds) > constants.DEVICE_COMMAND_MAX_DEVICES {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d device_ids", constants.DEVICE_COMMAND_MAX_DEVICES)
+	}
+
+	unique := make([]string, 0, len(deviceIds))
+	seen := make(map[string]bool, len(deviceIds))
+	for _, deviceId := range deviceIds {
+		deviceId = strings.TrimSpace(deviceId)
+		if len(deviceId) != 9 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid device_id %s length %d", deviceId, len(deviceId))
+		}
+		if !seen[deviceId] {
+			seen[deviceId] = true
+			unique = append(unique, deviceId)
+		}
+	}
+
+	return unique, nil
+}
+
+func validateCommand(command *genproto.DeviceCommand) error {
+	if command == nil {
+		return status.Errorf(codes.InvalidArgument, "missing command")
+	}
+
+	switch c := command.Command.(type) {
+	case *genproto.DeviceCommand_CaptureNow:
+	case *genproto.DeviceCommand_SetCaptureInterval:
+		interval := c.SetCaptureInterval.GetIntervalSeconds()
+		if interval < constants.CAPTURE_INTERVAL_MIN_SECONDS || interval > constants.CAPTURE_INTERVAL_MAX_SECONDS {
+			return status.Errorf(codes.InvalidArgument, "capture interval must be %d to %d seconds", constants.CAPTURE_INTERVAL_MIN_SECONDS, constants.CAPTURE_INTERVAL_MAX_SECONDS)
+		}
+	case *genproto.DeviceCommand_SetFlashLed:
+	case *genproto.DeviceCommand_Reboot:
+		if c.Reboot.GetDelaySeconds() > constants.REBOOT_MAX_DELAY_SECONDS {
+			return status.Errorf(codes.InvalidArgument, "reboot delay must be at most %d seconds", constants.REBOOT_MAX_DELAY_SECONDS)
+		}
+	case *genproto.DeviceCommand_ResyncNtp:
+		if len(c.ResyncNtp.GetNtpServer()) > constants.NTP_SERVER_MAX_LENGTH {
+			return status.Errorf(codes.InvalidArgument, "ntp server is longer than %d characters", constants.NTP_SERVER_MAX_LENGTH)
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "missing command")
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+// Status of a command, sent until the device acknowledges it
+const (
+	STATUS_SENT           = "sent"
+	STATUS_ACCEPTED       = "accepted"
+	STATUS_SUCCEEDED      = "succeeded"
+	STATUS_FAILED         = "failed"
+	STATUS_REJECTED       = "rejected"
+	STATUS_EXPIRED        = "expired"
+	STATUS_TIMED_OUT      = "timed_out"
+	STATUS_PUBLISH_FAILED = "publish_failed"
+)
+
+// Publisher publishes the commands to the devices, the MQTT client
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
+
+type CommandServiceIface interface {
+	Send(ctx context.Context, deviceIds []string, command *genproto.DeviceCommand, timeout time.Duration, waitForAck bool) ([]*genproto.DeviceCommandRecord, error)
+	Get(ctx context.Context, commandId string) (*genproto.DeviceCommandRecord, error)
+	List(ctx context.Context, deviceId string, limit int) ([]*genproto.DeviceCommandRecord, error)
+	HandleAck(ctx context.Context, topic string, payload []byte) error
+}
@@ -0,0 +1,255 @@
+package deviceconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+/**
+ * Store the configuration of the next version, unless another Set took the
+ * version first. The version, the configuration and its history change
+ * together, a reader never sees one without the others.
+ *
+ * KEYS[1] the version, KEYS[2] the configuration, KEYS[3] the history
+ * ARGV[1] the version to store, ARGV[2] the configuration, ARGV[3] the
+ * history length
+ */
+var setScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+if current + 1 ~= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('SET', KEYS[2], ARGV[2])
+redis.call('LPUSH', KEYS[3], ARGV[2])
+redis.call('LTRIM', KEYS[3], 0, tonumber(ARGV[3]) - 1)
+return 1
+`)
+
+type DeviceConfigServiceImpl struct {
+	rdb       redis.Cmdable
+	publisher Publisher
+}
+
+func New(rdb redis.Cmdable, publisher Publisher) DeviceConfigServiceIface {
+	return &DeviceConfigServiceImpl{
+		rdb:       rdb,
+		publisher: publisher,
+	}
+}
+
+func validateDeviceId(deviceId string) error {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	return nil
+}
+
+// Defaults is the configuration of a device that has none, version 0
+func Defaults() *genproto.DeviceConfig {
+	return &genproto.DeviceConfig{
+		UtcOffsetSeconds:         constants.DEVICE_CONFIG_DEFAULT_UTC_OFFSET_SECONDS,
+		NtpServer:                constants.DEVICE_CONFIG_DEFAULT_NTP_SERVER,
+		NtpPort:                  constants.DEVICE_CONFIG_DEFAULT_NTP_PORT,
+		NtpUpdateIntervalSeconds: constants.DEVICE_CONFIG_DEFAULT_NTP_UPDATE_INTERVAL_SECONDS,
+		CaptureIntervalSeconds:   constants.DEVICE_CONFIG_DEFAULT_CAPTURE_INTERVAL_SECONDS,
+	}
+}
+
+/**
+ * Return the configuration of the device, or the defaults when it has none.
+ */
+func (ds *DeviceConfigServiceImpl) Get(ctx context.Context, deviceId string) (*genproto.DeviceConfig, error) {
+	if err := validateDeviceId(deviceId); err != nil {
+		return nil, err
+	}
+
+	configByteArr, err := ds.rdb.Get(ctx, fmt.Sprintf(constants.REDIS_KEY_DEVICE_CONFIG_FORMAT, deviceId)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return Defaults(), nil
+		}
+
+		log.Error().Msgf("failed to get device config from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device config from Redis: %w", err)
+	}
+
+	config := &genproto.DeviceConfig{}
+	if err := proto.Unmarshal(configByteArr, config); err != nil {
+		log.Error().Msgf("failed to unmarshal DeviceConfig: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal DeviceConfig: %w", err)
+	}
+
+	return config, nil
+}
+
+/**
+ * Replace the configuration of the device, the fields left empty get their
+ * default. Every change gets a new version, the previous configuration goes
+ * to the history. The version is in the stored configuration, so it is read
+ * first and the configuration only stored when no other change took it.
+ *
+ * The new configuration is then retained on the config topic of the device,
+ * the device picks it up live, or on its next boot:
+ *   saladin-eye/device/[device-id]/config
+ */
+func (ds *DeviceConfigServiceImpl) Set(ctx context.Context, deviceId string, config *genproto.DeviceConfig) (*genproto.DeviceConfig, error) {
+	if err := validateDeviceId(deviceId); err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "missing config")
+	}
+
+	config = withDefaults(config)
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	versionKey := fmt.Sprintf(constants.REDIS_KEY_DEVICE_CONFIG_VERSION_FORMAT, deviceId)
+	configKey := fmt.Sprintf(constants.REDIS_KEY_DEVICE_CONFIG_FORMAT, deviceId)
+	historyKey := fmt.Sprintf(constants.REDIS_KEY_DEVICE_CONFIG_HISTORY_FORMAT, deviceId)
+
+	var version int64
+	var configByteArr []byte
+	for attempt := 0; ; attempt++ {
+		if attempt == constants.DEVICE_CONFIG_SET_MAX_ATTEMPTS {
+			log.Error().Msgf("device config of device_id %s changed concurrently %d times", deviceId, attempt)
+			return nil, status.Errorf(codes.Aborted, "device config changed concurrently, try again")
+		}
+
+		current, err := ds.rdb.Get(ctx, versionKey).Int64()
+		if err != nil && err != redis.Nil {
+			log.Error().Msgf("failed to get device config version from Redis: %v", err)
+			return nil, fmt.Errorf("failed to get device config version from Redis: %w", err)
+		}
+
+		version = current + 1
+		config.Version = version
+		config.UpdatedAt = time.Now().UTC().Unix()
+
+		configByteArr, err = proto.Marshal(config)
+		if err != nil {
+			log.Error().Msgf("failed to marshal DeviceConfig: %v", err)
+			return nil, fmt.Errorf("failed to marshal DeviceConfig: %w", err)
+		}
+
+		stored, err := setScript.Run(ctx, ds.rdb, []string{versionKey, configKey, historyKey}, version, configByteArr, constants.DEVICE_CONFIG_HISTORY_MAX_ENTRIES).Int()
+		if err != nil {
+			log.Error().Msgf("failed to set device config in Redis: %v", err)
+			return nil, fmt.Errorf("failed to set device config in Redis: %w", err)
+		}
+		if stored == 1 {
+			break
+		}
+	}
+
+	log.Info().Msgf("device config of device_id %s set to version %d", deviceId, version)
+
+	// Stored already, a device that misses the retained message still gets
+	// the configuration with get-device-config, answered by media-service
+	topic := fmt.Sprintf(constants.MQTT_TOPIC_DEVICE_CONFIG_FORMAT, deviceId)
+	if err := ds.publisher.PublishRetained(ctx, topic, configByteArr); err != nil {
+		log.Error().Msgf("failed to publish device config of device_id %s: %v", deviceId, err)
+	}
+
+	return config, nil
+}
+
+// The configurations of the device, the current one first
+func (ds *DeviceConfigServiceImpl) History(ctx context.Context, deviceId string, limit int) ([]*genproto.DeviceConfig, error) {
+	if err := validateDeviceId(deviceId); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = constants.DEVICE_CONFIG_LIST_DEFAULT_LIMIT
+	}
+	limit = min(limit, constants.DEVICE_CONFIG_HISTORY_MAX_ENTRIES)
+
+	historyKey := fmt.Sprintf(constants.REDIS_KEY_DEVICE_CONFIG_HISTORY_FORMAT, deviceId)
+	configsByteArr, err := ds.rdb.LRange(ctx, historyKey, 0, int64(limit-1)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get device config history from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device config history from Redis: %w", err)
+	}
+
+	configs := make([]*genproto.DeviceConfig, 0, len(configsByteArr))
+	for _, configByteArr := range configsByteArr {
+		config := &genproto.DeviceConfig{}
+		if err := proto.Unmarshal([]byte(configByteArr), config); err != nil {
+			log.Error().Msgf("failed to unmarshal DeviceConfig: %v", err)
+			return nil, fmt.Errorf("failed to unmarshal DeviceConfig: %w", err)
+		}
+		configs = append(configs, config)
+	}
+
+	return configs, nil
+}
+
+func withDefaults(config *genproto.DeviceConfig) *genproto.DeviceConfig {
+	defaults := Defaults()
+
+	config = &genproto.DeviceConfig{
+		UtcOffsetSeconds:         config.UtcOffsetSeconds,
+		NtpServer:                strings.TrimSpace(config.NtpServer),
+		NtpPort:                  config.NtpPort,
+		NtpUpdateIntervalSeconds: config.NtpUpdateIntervalSeconds,
+		CaptureIntervalSeconds:   config.CaptureIntervalSeconds,
+		FlashLedEnabled:          config.FlashLedEnabled,
+	}
+
+	if config.NtpServer == "" {
+		config.NtpServer = defaults.NtpServer
+	}
+	if config.NtpPort == 0 {
+		config.NtpPort = defaults.NtpPort
+	}
+	if config.NtpUpdateIntervalSeconds == 0 {
+		config.NtpUpdateIntervalSeconds = defaults.NtpUpdateIntervalSeconds
+	}
+	if config.CaptureIntervalSeconds == 0 {
+		config.CaptureIntervalSeconds = defaults.CaptureIntervalSeconds
+	}
+
+	return config
+}
+
+func validateConfig(config *genproto.DeviceConfig) error {
+	if config.UtcOffsetSeconds < constants.DEVICE_CONFIG_MIN_UTC_OFFSET_SECONDS || config.UtcOffsetSeconds > constants.DEVICE_CONFIG_MAX_UTC_OFFSET_SECONDS {
+		return status.Errorf(codes.InvalidArgument, "utc offset must be %d to %d seconds", constants.DEVICE_CONFIG_MIN_UTC_OFFSET_SECONDS, constants.DEVICE_CONFIG_MAX_UTC_OFFSET_SECONDS)
+	}
+	if config.UtcOffsetSeconds%constants.DEVICE_CONFIG_UTC_OFFSET_STEP_SECONDS != 0 {
+		return status.Errorf(codes.InvalidArgument, "utc offset must be in quarter hours")
+	}
+
+	if len(config.NtpServer) > constants.NTP_SERVER_MAX_LENGTH || strings.ContainsAny(config.NtpServer, " /:") {
+		return status.Errorf(codes.InvalidArgument, "invalid ntp server: %s", config.NtpServer)
+	}
+	if config.NtpPort > 65535 {
+		return status.Errorf(codes.InvalidArgument, "invalid ntp port: %d", config.NtpPort)
+	}
+	if config.NtpUpdateIntervalSeconds < constants.DEVICE_CONFIG_MIN_NTP_UPDATE_INTERVAL_SECONDS || config.NtpUpdateIntervalSeconds > constants.DEVICE_CONFIG_MAX_NTP_UPDATE_INTERVAL_SECONDS {
+		return status.Errorf(codes.InvalidArgument, "ntp update interval must be %d to %d seconds", constants.DEVICE_CONFIG_MIN_NTP_UPDATE_INTERVAL_SECONDS, constants.DEVICE_CONFIG_MAX_NTP_UPDATE_INTERVAL_SECONDS)
+	}
+
+	if config.CaptureIntervalSeconds < constants.CAPTURE_INTERVAL_MIN_SECONDS || config.CaptureIntervalSeconds > constants.CAPTURE_INTERVAL_MAX_SECONDS {
+		return status.Errorf(codes.InvalidArgument, "capture interval must be %d to %d seconds", constants.CAPTURE_INTERVAL_MIN_SECONDS, constants.CAPTURE_INTERVAL_MAX_SECONDS)
+	}
+
+	return nil
+}
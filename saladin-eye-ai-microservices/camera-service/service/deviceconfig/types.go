@@ -0,0 +1,18 @@
+package deviceconfig
+
+import (
+	"context"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+// Publisher publishes the configurations to the devices, the MQTT client
+type Publisher interface {
+	PublishRetained(ctx context.Context, topic string, payload []byte) error
+}
+
+type DeviceConfigServiceIface interface {
+	Get(ctx context.Context, deviceId string) (*genproto.DeviceConfig, error)
+	Set(ctx context.Context, deviceId string, config *genproto.DeviceConfig) (*genproto.DeviceConfig, error)
+	History(ctx context.Context, deviceId string, limit int) ([]*genproto.DeviceConfig, error)
+}
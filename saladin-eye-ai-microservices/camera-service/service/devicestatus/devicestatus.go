@@ -0,0 +1,129 @@
+package devicestatus
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+type DeviceStatusServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) DeviceStatusServiceIface {
+	return &DeviceStatusServiceImpl{
+		rdb: rdb,
+	}
+}
+
+func validateDeviceId(deviceId string) error {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	return nil
+}
+
+/**
+ * Return whether the device is online, and its last status. The status is
+ * nil when the device never sent one, or its firmware sends an empty
+ * heartbeat.
+ */
+func (ds *DeviceStatusServiceImpl) Get(ctx context.Context, deviceId string) (bool, *genproto.DeviceStatus, error) {
+	if err := validateDeviceId(deviceId); err != nil {
+		return false, nil, err
+	}
+
+	var presenceCmd *redis.StringCmd
+	var statusCmd *redis.StringCmd
+	_, err := ds.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		presenceCmd = pipe.Get(ctx, fmt.Sprintf(constants.REDIS_KEY_DEVICE_ONLINE_PRESENCE_FORMAT, deviceId))
+		statusCmd = pipe.Get(ctx, fmt.Sprintf(constants.REDIS_KEY_DEVICE_STATUS_FORMAT, deviceId))
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		log.Error().Msgf("failed to get device status from Redis: %v", err)
+		return false, nil, fmt.Errorf("failed to get device status from Redis: %w", err)
+	}
+
+	// The presence key holds the time of the last heartbeat, it is gone once
+	// the device is offline
+	online := presenceCmd.Err() == nil
+
+	statusByteArr, err := statusCmd.Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return online, nil, nil
+		}
+
+		log.Error().Msgf("failed to get device status from Redis: %v", err)
+		return false, nil, fmt.Errorf("failed to get device status from Redis: %w", err)
+	}
+
+	deviceStatus := &genproto.DeviceStatus{}
+	if err := proto.Unmarshal(statusByteArr, deviceStatus); err != nil {
+		log.Error().Msgf("failed to unmarshal DeviceStatus: %v", err)
+		return false, nil, fmt.Errorf("failed to unmarshal DeviceStatus: %w", err)
+	}
+
+	return online, deviceStatus, nil
+}
+
+/**
+ * Return the statuses the device sent between from and to, newest first, the
+ * last DEVICE_STATUS_HISTORY_DEFAULT_HOURS when both are zero.
+ */
+func (ds *DeviceStatusServiceImpl) History(ctx context.Context, deviceId string, from, to time.Time, limit int) ([]*genproto.DeviceStatus, error) {
+	if err := validateDeviceId(deviceId); err != nil {
+		return nil, err
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-constants.DEVICE_STATUS_HISTORY_DEFAULT_HOURS * time.Hour)
+	}
+	if from.After(to) {
+		return nil, status.Errorf(codes.InvalidArgument, "from must not be after to")
+	}
+
+	if limit <= 0 {
+		limit = constants.DEVICE_STATUS_LIST_DEFAULT_LIMIT
+	}
+	limit = min(limit, constants.DEVICE_STATUS_LIST_MAX_LIMIT)
+
+	historyKey := fmt.Sprintf(constants.REDIS_KEY_DEVICE_STATUS_HISTORY_FORMAT, deviceId)
+	statusesByteArr, err := ds.rdb.ZRevRangeByScore(ctx, historyKey, &redis.ZRangeBy{
+		Min:   strconv.FormatInt(from.UnixMilli(), 10),
+		Max:   strconv.FormatInt(to.UnixMilli(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get device status history from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device status history from Redis: %w", err)
+	}
+
+	statuses := make([]*genproto.DeviceStatus, 0, len(statusesByteArr))
+	for _, statusByteArr := range statusesByteArr {
+		deviceStatus := &genproto.DeviceStatus{}
+		if err := proto.Unmarshal([]byte(statusByteArr), deviceStatus); err != nil {
+			log.Error().Msgf("failed to unmarshal DeviceStatus: %v", err)
+			return nil, fmt.Errorf("failed to unmarshal DeviceStatus: %w", err)
+		}
+		statuses = append(statuses, deviceStatus)
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,13 @@
+package devicestatus
+
+import (
+	"context"
+	"time"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+type DeviceStatusServiceIface interface {
+	Get(ctx context.Context, deviceId string) (bool, *genproto.DeviceStatus, error)
+	History(ctx context.Context, deviceId string, from, to time.Time, limit int) ([]*genproto.DeviceStatus, error)
+}
@@ -0,0 +1,462 @@
+package enrolment
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/deviceconfig"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/registry"
+)
+
+var tokenIdPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// The fields of the token hash
+const (
+	fieldSecretSha256 = "secret_sha256"
+	fieldToken        = "token"
+)
+
+// The topics of a device, by its id. It gets its responses, commands and
+// configuration, and sends its status, requests and command acks.
+var (
+	mqttReadTopicFormats  = []string{"saladin-eye/device/%s/#"}
+	mqttWriteTopicFormats = []string{
+		"saladin-eye/device/%s/status",
+		"saladin-eye/device/%s/last-will",
+		"saladin-eye/server/media-service/request/+/%s/#",
+		"saladin-eye/server/camera-service/command-ack/%s",
+	}
+)
+
+type EnrolmentServiceImpl struct {
+	rdb                 redis.Cmdable
+	registryService     registry.RegistryServiceIface
+	deviceConfigService deviceconfig.DeviceConfigServiceIface
+	keyRegistry         KeyRegistry
+	mqttBroker          string
+}
+
+// mqttBroker is the address the devices connect to, returned on enrolment
+func New(rdb redis.Cmdable, registryService registry.RegistryServiceIface, deviceConfigService deviceconfig.DeviceConfigServiceIface, keyRegistry KeyRegistry, mqttBroker string) EnrolmentServiceIface {
+	return &EnrolmentServiceImpl{
+		rdb:                 rdb,
+		registryService:     registryService,
+		deviceConfigService: deviceConfigService,
+		keyRegistry:         keyRegistry,
+		mqttBroker:          mqttBroker,
+	}
+}
+
+func tokenRedisKey(tokenId string) string {
+	return fmt.Sprintf(constants.REDIS_KEY_ENROLMENT_TOKEN_FORMAT, tokenId)
+}
+
+/**
+ * Create a one-time enrolment token, the device is registered as given once
+ * it enrols with the token. The token is [token id].[secret], only the hash
+ * of the secret is stored:
+ *   saladin-eye:camera-service:enrolment-token:[tokenId]
+ */
+func (es *EnrolmentServiceImpl) CreateToken(ctx context.Context, device *genproto.Device, ttl time.Duration) (string, *genproto.EnrolmentToken, error) {
+	if device == nil {
+		return "", nil, status.Errorf(codes.InvalidArgument, "missing device")
+	}
+
+	// Validated with a placeholder id, the real one is assigned on enrolment
+	template := proto.Clone(device).(*genproto.Device)
+	template.DeviceId = strings.Repeat("0", 9)
+	template, err := registry.Normalize(template)
+	if err != nil {
+		return "", nil, err
+	}
+	template.DeviceId = ""
+
+	if ttl == 0 {
+		ttl = constants.ENROLMENT_TOKEN_DEFAULT_TTL_SECONDS * time.Second
+	}
+	if ttl < 0 || ttl > constants.ENROLMENT_TOKEN_MAX_TTL_SECONDS*time.Second {
+		return "", nil, status.Errorf(codes.InvalidArgument, "ttl must be at most %d seconds", constants.ENROLMENT_TOKEN_MAX_TTL_SECONDS)
+	}
+
+	tokenIdBytes := make([]byte, 8)
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenIdBytes); err != nil {
+		return "", nil, fmt.Errorf("failed to generate token id: %w", err)
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", nil, fmt.Errorf("failed to generate token secret: %w", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	now := time.Now().UTC()
+	enrolmentToken := &genproto.EnrolmentToken{
+		TokenId:   hex.EncodeToString(tokenIdBytes),
+		Device:    template,
+		CreatedAt: now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+
+	tokenByteArr, err := proto.Marshal(enrolmentToken)
+	if err != nil {
+		log.Error().Msgf("failed to marshal EnrolmentToken: %v", err)
+		return "", nil, fmt.Errorf("failed to marshal EnrolmentToken: %w", err)
+	}
+
+	secretSha256 := sha256.Sum256([]byte(secret))
+	if err := es.saveToken(ctx, enrolmentToken, tokenByteArr, hex.EncodeToString(secretSha256[:])); err != nil {
+		return "", nil, err
+	}
+
+	log.Info().Msgf("created enrolment token %s, expires at %d", enrolmentToken.TokenId, enrolmentToken.ExpiresAt)
+
+	return enrolmentToken.TokenId + "." + secret, enrolmentToken, nil
+}
+
+func (es *EnrolmentServiceImpl) RevokeToken(ctx context.Context, tokenId string) error {
+	if !tokenIdPattern.MatchString(tokenId) {
+		return status.Errorf(codes.InvalidArgument, "invalid token_id %s", tokenId)
+	}
+
+	deleted, err := es.rdb.Del(ctx, tokenRedisKey(tokenId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to delete enrolment token from Redis: %v", err)
+		return fmt.Errorf("failed to delete enrolment token from Redis: %w", err)
+	}
+	if deleted == 0 {
+		return status.Errorf(codes.NotFound, "enrolment token %s not found", tokenId)
+	}
+
+	log.Info().Msgf("revoked enrolment token %s", tokenId)
+
+	return nil
+}
+
+/**
+ * Enrol a new device with its token: assign it a device id, register it,
+ * issue its MQTT credentials and its signing key, and return them with its
+ * configuration.
+ *
+ * The token is used up, but put back when the enrolment fails on the server
+ * side, so the device can try again.
+ */
+func (es *EnrolmentServiceImpl) Enrol(ctx context.Context, request *genproto.EnrolDeviceRequest) (*genproto.EnrolDeviceResponse, error) {
+	if len(request.Ed25519PublicKey) != 0 && len(request.Ed25519PublicKey) != ed25519.PublicKeySize {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid Ed25519 public key length: %d", len(request.Ed25519PublicKey))
+	}
+
+	enrolmentToken, tokenFields, err := es.useToken(ctx, request.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := es.enrol(ctx, enrolmentToken, request)
+	if err != nil {
+		es.restoreToken(ctx, enrolmentToken, tokenFields)
+		return nil, err
+	}
+
+	log.Info().Msgf("enrolled device_id %s with token %s, firmware %s", response.DeviceId, enrolmentToken.TokenId, request.FirmwareVersion)
+
+	return response, nil
+}
+
+func (es *EnrolmentServiceImpl) enrol(ctx context.Context, enrolmentToken *genproto.EnrolmentToken, request *genproto.EnrolDeviceRequest) (response *genproto.EnrolDeviceResponse, err error) {
+	device, err := es.register(ctx, enrolmentToken.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	username := constants.MQTT_DEVICE_USERNAME_PREFIX + device.DeviceId
+	var keyId string
+
+	// The device enrols again with the restored token, under another id,
+	// nothing issued to this one is left behind. Even when the enrolment
+	// failed for its request being cancelled.
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		cleanupCtx := context.WithoutCancel(ctx)
+		if keyId != "" {
+			es.revokeKey(cleanupCtx, device.DeviceId, keyId)
+		}
+		es.deleteMqttCredentials(cleanupCtx, username)
+		if deleteErr := es.registryService.Delete(cleanupCtx, device.DeviceId); deleteErr != nil {
+			log.Error().Msgf("failed to delete device_id %s of failed enrolment: %v", device.DeviceId, deleteErr)
+		}
+	}()
+
+	password, err := es.issueMqttCredentials(ctx, device.DeviceId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := es.issueKey(ctx, device.DeviceId, request.Ed25519PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	keyId = key.Key.KeyId
+
+	config, err := es.deviceConfigService.Get(ctx, device.DeviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	response = &genproto.EnrolDeviceResponse{
+		DeviceId:     device.DeviceId,
+		MqttBroker:   es.mqttBroker,
+		MqttClientId: username,
+		MqttUsername: username,
+		MqttPassword: password,
+		KeyId:        key.Key.KeyId,
+		KeyAlgorithm: key.Key.Algorithm,
+		HmacSecret:   key.Secret,
+		Config:       config,
+	}
+
+	return response, nil
+}
+
+// Check the token and use it up, only one enrolment gets it
+func (es *EnrolmentServiceImpl) useToken(ctx context.Context, token string) (*genproto.EnrolmentToken, map[string]string, error) {
+	tokenId, secret, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok || !tokenIdPattern.MatchString(tokenId) {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "invalid enrolment token")
+	}
+
+	tokenFields, err := es.rdb.HGetAll(ctx, tokenRedisKey(tokenId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get enrolment token from Redis: %v", err)
+		return nil, nil, fmt.Errorf("failed to get enrolment token from Redis: %w", err)
+	}
+
+	secretSha256 := sha256.Sum256([]byte(secret))
+	if len(tokenFields) == 0 || subtle.ConstantTimeCompare([]byte(hex.EncodeToString(secretSha256[:])), []byte(tokenFields[fieldSecretSha256])) != 1 {
+		log.Warn().Msgf("enrolment with invalid or expired token %s", tokenId)
+		return nil, nil, status.Errorf(codes.Unauthenticated, "invalid enrolment token")
+	}
+
+	deleted, err := es.rdb.Del(ctx, tokenRedisKey(tokenId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to delete enrolment token from Redis: %v", err)
+		return nil, nil, fmt.Errorf("failed to delete enrolment token from Redis: %w", err)
+	}
+	if deleted == 0 {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "enrolment token already used")
+	}
+
+	enrolmentToken := &genproto.EnrolmentToken{}
+	if err := proto.Unmarshal([]byte(tokenFields[fieldToken]), enrolmentToken); err != nil {
+		log.Error().Msgf("failed to unmarshal EnrolmentToken: %v", err)
+		return nil, nil, fmt.Errorf("failed to unmarshal EnrolmentToken: %w", err)
+	}
+
+	return enrolmentToken, tokenFields, nil
+}
+
+func (es *EnrolmentServiceImpl) restoreToken(ctx context.Context, enrolmentToken *genproto.EnrolmentToken, tokenFields map[string]string) {
+	if err := es.saveToken(ctx, enrolmentToken, []byte(tokenFields[fieldToken]), tokenFields[fieldSecretSha256]); err != nil {
+		log.Error().Msgf("failed to restore enrolment token %s: %v", enrolmentToken.TokenId, err)
+	}
+}
+
+func (es *EnrolmentServiceImpl) saveToken(ctx context.Context, enrolmentToken *genproto.EnrolmentToken, tokenByteArr []byte, secretSha256 string) error {
+	key := tokenRedisKey(enrolmentToken.TokenId)
+
+	_, err := es.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, fieldSecretSha256, secretSha256, fieldToken, tokenByteArr)
+		pipe.ExpireAt(ctx, key, time.Unix(enrolmentToken.ExpiresAt, 0))
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to set enrolment token in Redis: %v", err)
+		return fmt.Errorf("failed to set enrolment token in Redis: %w", err)
+	}
+
+	return nil
+}
+
+// Register the device under a new random id, drawn again when taken
+func (es *EnrolmentServiceImpl) register(ctx context.Context, template *genproto.Device) (*genproto.Device, error) {
+	for attempt := 0; attempt < constants.ENROLMENT_DEVICE_ID_ATTEMPTS; attempt++ {
+		deviceId, err := randomDeviceId()
+		if err != nil {
+			return nil, err
+		}
+
+		device := proto.Clone(template).(*genproto.Device)
+		device.DeviceId = deviceId
+		device.Enabled = true
+
+		registered, err := es.registryService.Create(ctx, device)
+		if status.Code(err) == codes.AlreadyExists {
+			continue
+		}
+
+		return registered, err
+	}
+
+	return nil, status.Errorf(codes.Unavailable, "failed to assign a device_id")
+}
+
+/**
+ * Store the hash of a new random password, and the topics of the device, for
+ * the broker. They are in the layout of the mosquitto-go-auth Redis backend,
+ * the broker checks them with:
+ *
+ *	auth_opt_backends redis
+ *	auth_opt_redis_host [the Redis of camera-service]
+ *	auth_opt_hasher pbkdf2
+ *	auth_opt_hasher_salt_encoding base64
+ */
+func (es *EnrolmentServiceImpl) issueMqttCredentials(ctx context.Context, deviceId, username string) (string, error) {
+	passwordBytes := make([]byte, constants.ENROLMENT_MQTT_PASSWORD_BYTES)
+	saltBytes := make([]byte, constants.MQTT_PASSWORD_PBKDF2_SALT_BYTES)
+	if _, err := rand.Read(passwordBytes); err != nil {
+		return "", fmt.Errorf("failed to generate MQTT password: %w", err)
+	}
+	if _, err := rand.Read(saltBytes); err != nil {
+		return "", fmt.Errorf("failed to generate MQTT password salt: %w", err)
+	}
+
+	password := base64.RawURLEncoding.EncodeToString(passwordBytes)
+	passwordHash := fmt.Sprintf("PBKDF2$%s$%d$%s$%s",
+		constants.MQTT_PASSWORD_PBKDF2_ALGORITHM,
+		constants.MQTT_PASSWORD_PBKDF2_ITERATIONS,
+		base64.StdEncoding.EncodeToString(saltBytes),
+		base64.StdEncoding.EncodeToString(pbkdf2Sha512([]byte(password), saltBytes, constants.MQTT_PASSWORD_PBKDF2_ITERATIONS, constants.MQTT_PASSWORD_PBKDF2_KEY_BYTES)),
+	)
+
+	readTopics := make([]interface{}, 0, len(mqttReadTopicFormats))
+	for _, topicFormat := range mqttReadTopicFormats {
+		readTopics = append(readTopics, fmt.Sprintf(topicFormat, deviceId))
+	}
+	writeTopics := make([]interface{}, 0, len(mqttWriteTopicFormats))
+	for _, topicFormat := range mqttWriteTopicFormats {
+		writeTopics = append(writeTopics, fmt.Sprintf(topicFormat, deviceId))
+	}
+
+	_, err := es.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, fmt.Sprintf(constants.REDIS_KEY_MQTT_PASSWORD_FORMAT, username), passwordHash, 0)
+		pipe.SAdd(ctx, fmt.Sprintf(constants.REDIS_KEY_MQTT_SUBSCRIBE_ACLS_FORMAT, username), readTopics...)
+		pipe.SAdd(ctx, fmt.Sprintf(constants.REDIS_KEY_MQTT_READ_ACLS_FORMAT, username), readTopics...)
+		pipe.SAdd(ctx, fmt.Sprintf(constants.REDIS_KEY_MQTT_WRITE_ACLS_FORMAT, username), writeTopics...)
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to set MQTT credentials in Redis: %v", err)
+		return "", fmt.Errorf("failed to set MQTT credentials in Redis: %w", err)
+	}
+
+	return password, nil
+}
+
+func (es *EnrolmentServiceImpl) deleteMqttCredentials(ctx context.Context, username string) {
+	err := es.rdb.Del(ctx,
+		fmt.Sprintf(constants.REDIS_KEY_MQTT_PASSWORD_FORMAT, username),
+		fmt.Sprintf(constants.REDIS_KEY_MQTT_SUBSCRIBE_ACLS_FORMAT, username),
+		fmt.Sprintf(constants.REDIS_KEY_MQTT_READ_ACLS_FORMAT, username),
+		fmt.Sprintf(constants.REDIS_KEY_MQTT_WRITE_ACLS_FORMAT, username),
+	).Err()
+	if err != nil {
+		log.Error().Msgf("failed to delete MQTT credentials of %s: %v", username, err)
+	}
+}
+
+// PBKDF2 with HMAC-SHA512, RFC 8018, as the broker checks the password
+func pbkdf2Sha512(password, salt []byte, iterations, keyLength int) []byte {
+	prf := hmac.New(sha512.New, password)
+
+	key := make([]byte, 0, keyLength)
+	for block := uint32(1); len(key) < keyLength; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(binary.BigEndian.AppendUint32(nil, block))
+		u := prf.Sum(nil)
+
+		t := append([]byte{}, u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+
+		key = append(key, t...)
+	}
+
+	return key[:keyLength]
+}
+
+// Register the signing key of the device with media-service, the Ed25519
+// public key of the device or else an HMAC secret media-service generates
+func (es *EnrolmentServiceImpl) issueKey(ctx context.Context, deviceId string, ed25519PublicKey []byte) (*genproto.RegisterDeviceKeyResponse, error) {
+	request := &genproto.RegisterDeviceKeyRequest{
+		DeviceId:  deviceId,
+		Algorithm: KEY_ALGORITHM_ED25519,
+		PublicKey: ed25519PublicKey,
+	}
+	if len(ed25519PublicKey) == 0 {
+		request.Algorithm = KEY_ALGORITHM_HMAC_SHA256
+	}
+
+	response, err := es.keyRegistry.RegisterDeviceKey(ctx, request)
+	if err != nil {
+		log.Error().Msgf("failed to register key of device_id %s with media-service: %v", deviceId, err)
+		return nil, status.Errorf(codes.Unavailable, "failed to register device key")
+	}
+	if response.Key == nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to register device key")
+	}
+
+	return response, nil
+}
+
+func (es *EnrolmentServiceImpl) revokeKey(ctx context.Context, deviceId, keyId string) {
+	_, err := es.keyRegistry.RevokeDeviceKey(ctx, &genproto.RevokeDeviceKeyRequest{
+		DeviceId: deviceId,
+		KeyId:    keyId,
+	})
+	if err != nil {
+		log.Error().Msgf("failed to revoke key %s of device_id %s with media-service: %v", keyId, deviceId, err)
+	}
+}
+
+func randomDeviceId() (string, error) {
+	alphabetSize := big.NewInt(int64(len(constants.DEVICE_ID_ALPHABET)))
+
+	deviceId := make([]byte, 9)
+	for i := range deviceId {
+		index, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate device_id: %w", err)
+		}
+		deviceId[i] = constants.DEVICE_ID_ALPHABET[index.Int64()]
+	}
+
+	return string(deviceId), nil
+}
@@ -0,0 +1,27 @@
+package enrolment
+
+import (
+	"context"
+	"time"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+// The algorithms of the signing key, the same as in media-service
+const (
+	KEY_ALGORITHM_HMAC_SHA256 = "hmac-sha256"
+	KEY_ALGORITHM_ED25519     = "ed25519"
+)
+
+// KeyRegistry registers the signing keys of the devices with media-service,
+// the media-service gRPC client
+type KeyRegistry interface {
+	RegisterDeviceKey(ctx context.Context, request *genproto.RegisterDeviceKeyRequest) (*genproto.RegisterDeviceKeyResponse, error)
+	RevokeDeviceKey(ctx context.Context, request *genproto.RevokeDeviceKeyRequest) (*genproto.RevokeDeviceKeyResponse, error)
+}
+
+type EnrolmentServiceIface interface {
+	CreateToken(ctx context.Context, device *genproto.Device, ttl time.Duration) (string, *genproto.EnrolmentToken, error)
+	RevokeToken(ctx context.Context, tokenId string) error
+	Enrol(ctx context.Context, request *genproto.EnrolDeviceRequest) (*genproto.EnrolDeviceResponse, error)
+}
@@ -0,0 +1,355 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+var (
+	deviceIdPattern = regexp.MustCompile(`^[A-Z0-9]{9}$`)
+	tagPattern      = regexp.MustCompile(`^[a-z0-9_:-]+$`)
+)
+
+type RegistryServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) RegistryServiceIface {
+	return &RegistryServiceImpl{
+		rdb: rdb,
+	}
+}
+
+func deviceRedisKey(deviceId string) string {
+	return fmt.Sprintf(constants.REDIS_KEY_DEVICE_FORMAT, deviceId)
+}
+
+func validateDeviceId(deviceId string) error {
+	if !deviceIdPattern.MatchString(deviceId) {
+		log.Error().Msgf("invalid device_id %s", deviceId)
+		return status.Errorf(codes.InvalidArgument, "invalid device_id %s, must be 9 uppercase letters or digits", deviceId)
+	}
+
+	return nil
+}
+
+/**
+ * Register the device, stored in its hash:
+ *   saladin-eye:camera-service:device:[deviceId]
+ *
+ * The hash is what media-service checks every request of the device against,
+ * a device must be registered and enabled to upload photos.
+ */
+func (rs *RegistryServiceImpl) Create(ctx context.Context, device *genproto.Device) (*genproto.Device, error) {
+	device, err := Normalize(device)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Unix()
+	device.CreatedAt = now
+	device.UpdatedAt = now
+
+	// Setting created_at first claims the device id, only one create wins
+	created, err := rs.rdb.HSetNX(ctx, deviceRedisKey(device.DeviceId), FIELD_CREATED_AT, now).Result()
+	if err != nil {
+		log.Error().Msgf("failed to create device in Redis: %v", err)
+		return nil, fmt.Errorf("failed to create device in Redis: %w", err)
+	}
+	if !created {
+		return nil, status.Errorf(codes.AlreadyExists, "device_id %s is already registered", device.DeviceId)
+	}
+
+	if err := rs.save(ctx, device); err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("registered device_id %s", device.DeviceId)
+
+	return device, nil
+}
+
+func (rs *RegistryServiceImpl) Get(ctx context.Context, deviceId string) (*genproto.Device, error) {
+	if err := validateDeviceId(deviceId); err != nil {
+		return nil, err
+	}
+
+	fields, err := rs.rdb.HGetAll(ctx, deviceRedisKey(deviceId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get device from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device from Redis: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, status.Errorf(codes.NotFound, "device_id %s is not registered", deviceId)
+	}
+
+	return fromFields(deviceId, fields), nil
+}
+
+/**
+ * Replace the device, every field but created_at. Disabling the device makes
+ * media-service reject its requests right away.
+ */
+func (rs *RegistryServiceImpl) Update(ctx context.Context, device *genproto.Device) (*genproto.Device, error) {
+	device, err := Normalize(device)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := rs.Get(ctx, device.DeviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	device.CreatedAt = existing.CreatedAt
+	device.UpdatedAt = time.Now().UTC().Unix()
+
+	if err := rs.save(ctx, device); err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("updated device_id %s, enabled %t", device.DeviceId, device.Enabled)
+
+	return device, nil
+}
+
+// Remove the device from the registry, its photos and history are kept
+func (rs *RegistryServiceImpl) Delete(ctx context.Context, deviceId string) error {
+	if err := validateDeviceId(deviceId); err != nil {
+		return err
+	}
+
+	var delCmd *redis.IntCmd
+	_, err := rs.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		delCmd = pipe.Del(ctx, deviceRedisKey(deviceId))
+		pipe.ZRem(ctx, constants.REDIS_KEY_DEVICES, deviceId)
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to delete device from Redis: %v", err)
+		return fmt.Errorf("failed to delete device from Redis: %w", err)
+	}
+	if delCmd.Val() == 0 {
+		return status.Errorf(codes.NotFound, "device_id %s is not registered", deviceId)
+	}
+
+	log.Info().Msgf("deleted device_id %s", deviceId)
+
+	return nil
+}
+
+/**
+ * List the devices by device id, a page at a time. The page token is the last
+ * device id of the previous page.
+ */
+func (rs *RegistryServiceImpl) List(ctx context.Context, owner, tag string, pageSize int, pageToken string) ([]*genproto.Device, string, error) {
+	if pageSize <= 0 {
+		pageSize = constants.DEVICE_LIST_DEFAULT_PAGE_SIZE
+	}
+	pageSize = min(pageSize, constants.DEVICE_LIST_MAX_PAGE_SIZE)
+
+	if pageToken != "" {
+		if err := validateDeviceId(pageToken); err != nil {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid page_token")
+		}
+	}
+
+	devices := make([]*genproto.Device, 0, pageSize)
+	cursor := pageToken
+
+	// With a filter a batch can match few devices, keep reading until the
+	// page is full or every device has been read
+	for {
+		start := "-"
+		if cursor != "" {
+			start = "(" + cursor
+		}
+
+		deviceIds, err := rs.rdb.ZRangeByLex(ctx, constants.REDIS_KEY_DEVICES, &redis.ZRangeBy{
+			Min:   start,
+			Max:   "+",
+			Count: int64(pageSize),
+		}).Result()
+		if err != nil {
+			log.Error().Msgf("failed to list devices from Redis: %v", err)
+			return nil, "", fmt.Errorf("failed to list devices from Redis: %w", err)
+		}
+		if len(deviceIds) == 0 {
+			return devices, "", nil
+		}
+
+		batch, err := rs.getAll(ctx, deviceIds)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, device := range batch {
+			if owner != "" && device.Owner != owner {
+				continue
+			}
+			if tag != "" && !slices.Contains(device.Tags, tag) {
+				continue
+			}
+
+			devices = append(devices, device)
+			if len(devices) == pageSize {
+				return devices, device.DeviceId, nil
+			}
+		}
+
+		if len(deviceIds) < pageSize {
+			return devices, "", nil
+		}
+		cursor = deviceIds[len(deviceIds)-1]
+	}
+}
+
+func (rs *RegistryServiceImpl) getAll(ctx context.Context, deviceIds []string) ([]*genproto.Device, error) {
+	cmds := make([]*redis.StringStringMapCmd, 0, len(deviceIds))
+	_, err := rs.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, deviceId := range deviceIds {
+			cmds = append(cmds, pipe.HGetAll(ctx, deviceRedisKey(deviceId)))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to get devices from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get devices from Redis: %w", err)
+	}
+
+	devices := make([]*genproto.Device, 0, len(deviceIds))
+	for i, cmd := range cmds {
+		// Deleted in between
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+		devices = append(devices, fromFields(deviceIds[i], cmd.Val()))
+	}
+
+	return devices, nil
+}
+
+func (rs *RegistryServiceImpl) save(ctx context.Context, device *genproto.Device) error {
+	enabled := "0"
+	if device.Enabled {
+		enabled = "1"
+	}
+
+	_, err := rs.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, deviceRedisKey(device.DeviceId),
+			FIELD_NAME, device.Name,
+			FIELD_LOCATION, device.Location,
+			FIELD_OWNER, device.Owner,
+			FIELD_TIMEZONE, device.Timezone,
+			FIELD_TAGS, strings.Join(device.Tags, ","),
+			FIELD_ENABLED, enabled,
+			FIELD_CREATED_AT, device.CreatedAt,
+			FIELD_UPDATED_AT, device.UpdatedAt,
+			FIELD_OFFLINE_THRESHOLD_SECONDS, device.OfflineThresholdSeconds,
+		)
+		pipe.ZAdd(ctx, constants.REDIS_KEY_DEVICES, &redis.Z{Score: 0, Member: device.DeviceId})
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to set device in Redis: %v", err)
+		return fmt.Errorf("failed to set device in Redis: %w", err)
+	}
+
+	return nil
+}
+
+func fromFields(deviceId string, fields map[string]string) *genproto.Device {
+	device := &genproto.Device{
+		DeviceId: deviceId,
+		Name:     fields[FIELD_NAME],
+		Location: fields[FIELD_LOCATION],
+		Owner:    fields[FIELD_OWNER],
+		Timezone: fields[FIELD_TIMEZONE],
+		Tags:     []string{},
+		Enabled:  fields[FIELD_ENABLED] == "1",
+	}
+	if fields[FIELD_TAGS] != "" {
+		device.Tags = strings.Split(fields[FIELD_TAGS], ",")
+	}
+	device.CreatedAt, _ = strconv.ParseInt(fields[FIELD_CREATED_AT], 10, 64)
+	device.UpdatedAt, _ = strconv.ParseInt(fields[FIELD_UPDATED_AT], 10, 64)
+	offlineThresholdSeconds, _ := strconv.ParseInt(fields[FIELD_OFFLINE_THRESHOLD_SECONDS], 10, 32)
+	device.OfflineThresholdSeconds = int32(offlineThresholdSeconds)
+
+	return device
+}
+
+// Normalize validates the device and returns a copy with the fields trimmed,
+// the tags sorted without duplicates and the time zone defaulted to UTC
+func Normalize(device *genproto.Device) (*genproto.Device, error) {
+	if device == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "missing device")
+	}
+
+	normalized := &genproto.Device{
+		DeviceId: strings.TrimSpace(device.DeviceId),
+		Name:     strings.TrimSpace(device.Name),
+		Location: strings.TrimSpace(device.Location),
+		Owner:    strings.TrimSpace(device.Owner),
+		Timezone: strings.TrimSpace(device.Timezone),
+		Tags:     []string{},
+		Enabled:  device.Enabled,
+
+		OfflineThresholdSeconds: device.OfflineThresholdSeconds,
+	}
+
+	if err := validateDeviceId(normalized.DeviceId); err != nil {
+		return nil, err
+	}
+
+	if normalized.Name == "" || utf8.RuneCountInString(normalized.Name) > constants.DEVICE_NAME_MAX_LENGTH {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be 1 to %d characters", constants.DEVICE_NAME_MAX_LENGTH)
+	}
+	if utf8.RuneCountInString(normalized.Location) > constants.DEVICE_LOCATION_MAX_LENGTH {
+		return nil, status.Errorf(codes.InvalidArgument, "location must be at most %d characters", constants.DEVICE_LOCATION_MAX_LENGTH)
+	}
+	if utf8.RuneCountInString(normalized.Owner) > constants.DEVICE_OWNER_MAX_LENGTH {
+		return nil, status.Errorf(codes.InvalidArgument, "owner must be at most %d characters", constants.DEVICE_OWNER_MAX_LENGTH)
+	}
+
+	if normalized.OfflineThresholdSeconds != 0 && (normalized.OfflineThresholdSeconds < constants.DEVICE_OFFLINE_THRESHOLD_MIN_SECONDS || normalized.OfflineThresholdSeconds > constants.DEVICE_OFFLINE_THRESHOLD_MAX_SECONDS) {
+		return nil, status.Errorf(codes.InvalidArgument, "offline_threshold_seconds must be 0 for the default, or %d to %d", constants.DEVICE_OFFLINE_THRESHOLD_MIN_SECONDS, constants.DEVICE_OFFLINE_THRESHOLD_MAX_SECONDS)
+	}
+
+	if normalized.Timezone == "" {
+		normalized.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(normalized.Timezone); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown timezone %s", normalized.Timezone)
+	}
+
+	for _, tag := range device.Tags {
+		tag = strings.TrimSpace(tag)
+		if len(tag) == 0 || len(tag) > constants.DEVICE_TAG_MAX_LENGTH || !tagPattern.MatchString(tag) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid tag %s, must be 1 to %d lowercase letters, digits, \"-\", \"_\" or \":\"", tag, constants.DEVICE_TAG_MAX_LENGTH)
+		}
+		if !slices.Contains(normalized.Tags, tag) {
+			normalized.Tags = append(normalized.Tags, tag)
+		}
+	}
+	if len(normalized.Tags) > constants.DEVICE_MAX_TAGS {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d tags", constants.DEVICE_MAX_TAGS)
+	}
+	slices.Sort(normalized.Tags)
+
+	return normalized, nil
+}
@@ -0,0 +1,30 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+// The fields of the device hash. media-service only reads enabled, "1" or "0".
+const (
+	FIELD_NAME       = "name"
+	FIELD_LOCATION   = "location"
+	FIELD_OWNER      = "owner"
+	FIELD_TIMEZONE   = "timezone"
+	FIELD_TAGS       = "tags"
+	FIELD_ENABLED    = "enabled"
+	FIELD_CREATED_AT = "created_at"
+	FIELD_UPDATED_AT = "updated_at"
+
+	// Read by the camera-mqtt-listener, the TTL of the presence key
+	FIELD_OFFLINE_THRESHOLD_SECONDS = "offline_threshold_seconds"
+)
+
+type RegistryServiceIface interface {
+	Create(ctx context.Context, device *genproto.Device) (*genproto.Device, error)
+	Get(ctx context.Context, deviceId string) (*genproto.Device, error)
+	Update(ctx context.Context, device *genproto.Device) (*genproto.Device, error)
+	Delete(ctx context.Context, deviceId string) error
+	List(ctx context.Context, owner, tag string, pageSize int, pageToken string) ([]*genproto.Device, string, error)
+}
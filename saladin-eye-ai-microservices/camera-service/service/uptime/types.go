@@ -0,0 +1,18 @@
+package uptime
+
+import (
+	"context"
+	"time"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+)
+
+const (
+	FORMAT_JSON = "json"
+	FORMAT_CSV  = "csv"
+)
+
+type UptimeServiceIface interface {
+	Report(ctx context.Context, deviceIds []string, owner, site string, from, to time.Time) (*genproto.UptimeReport, error)
+	Render(report *genproto.UptimeReport, format string) (string, []byte, error)
+}
@@ -0,0 +1,287 @@
+package uptime
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/camerastatus"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/registry"
+)
+
+type UptimeServiceImpl struct {
+	rdb             redis.Cmdable
+	registryService registry.RegistryServiceIface
+}
+
+func New(rdb redis.Cmdable, registryService registry.RegistryServiceIface) UptimeServiceIface {
+	return &UptimeServiceImpl{
+		rdb:             rdb,
+		registryService: registryService,
+	}
+}
+
+// The uptime of a device or a site as it adds up, the MTTR is only worked
+// out at the end
+type totals struct {
+	uptime           *genproto.Uptime
+	recoveredSeconds int64
+	recoveredCount   int64
+}
+
+/**
+ * Report the uptime of the devices between from and to, each and by site,
+ * from the online and offline periods in the camera status history. Periods
+ * older than CAMERA_STATUS_HISTORY_RETENTION_DAYS are gone, and don't count
+ * as monitored.
+ */
+func (us *UptimeServiceImpl) Report(ctx context.Context, deviceIds []string, owner, site string, from, to time.Time) (*genproto.UptimeReport, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-constants.UPTIME_REPORT_DEFAULT_DAYS * 24 * time.Hour)
+	}
+	if !from.Before(to) {
+		return nil, status.Errorf(codes.InvalidArgument, "from must be before to")
+	}
+
+	devices, err := us.devices(ctx, deviceIds, owner, site)
+	if err != nil {
+		return nil, err
+	}
+
+	historyCmds := make([]*redis.StringSliceCmd, len(devices))
+	currentCmds := make([]*redis.StringStringMapCmd, len(devices))
+	_, err = us.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, device := range devices {
+			// A period that started before from can still run into the report
+			historyCmds[i] = pipe.ZRangeByScore(ctx, fmt.Sprintf(constants.REDIS_KEY_CAMERA_STATUS_HISTORY_FORMAT, device.DeviceId), &redis.ZRangeBy{
+				Min: "-inf",
+				Max: strconv.FormatInt(to.Unix(), 10),
+			})
+			currentCmds[i] = pipe.HGetAll(ctx, fmt.Sprintf(constants.REDIS_KEY_CAMERA_STATUS_FORMAT, device.DeviceId))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to get camera status history from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get camera status history from Redis: %w", err)
+	}
+
+	report := &genproto.UptimeReport{
+		From: from.Unix(),
+		To:   to.Unix(),
+	}
+	sites := make(map[string]*totals)
+
+	now := time.Now().Unix()
+	for i, device := range devices {
+		deviceTotals := &totals{uptime: &genproto.Uptime{
+			DeviceId:    device.DeviceId,
+			Name:        device.Name,
+			Site:        device.Location,
+			DeviceCount: 1,
+		}}
+
+		for _, periodByteArr := range historyCmds[i].Val() {
+			period := &genproto.CameraStatusPeriod{}
+			if err := proto.Unmarshal([]byte(periodByteArr), period); err != nil {
+				log.Error().Msgf("failed to unmarshal CameraStatusPeriod: %v", err)
+				return nil, fmt.Errorf("failed to unmarshal CameraStatusPeriod: %w", err)
+			}
+			deviceTotals.addPeriod(period, report.From, report.To, true)
+		}
+
+		// The current period runs until now
+		if current := currentCmds[i].Val(); len(current) > 0 {
+			since, _ := strconv.ParseInt(current["since"], 10, 64)
+			deviceTotals.addPeriod(&genproto.CameraStatusPeriod{
+				Status:    current["status"],
+				StartedAt: since,
+				EndedAt:   now,
+			}, report.From, report.To, false)
+		}
+
+		report.Devices = append(report.Devices, deviceTotals.finish())
+
+		siteTotals, ok := sites[device.Location]
+		if !ok {
+			siteTotals = &totals{uptime: &genproto.Uptime{Site: device.Location}}
+			sites[device.Location] = siteTotals
+		}
+		siteTotals.add(deviceTotals)
+	}
+
+	for _, siteTotals := range sites {
+		report.Sites = append(report.Sites, siteTotals.finish())
+	}
+	sort.Slice(report.Sites, func(i, j int) bool {
+		return report.Sites[i].Site < report.Sites[j].Site
+	})
+
+	return report, nil
+}
+
+// The devices of the report, at most UPTIME_REPORT_MAX_DEVICES
+func (us *UptimeServiceImpl) devices(ctx context.Context, deviceIds []string, owner, site string) ([]*genproto.Device, error) {
+	if len(deviceIds) > 0 {
+		if len(deviceIds) > constants.UPTIME_REPORT_MAX_DEVICES {
+			return nil, status.Errorf(codes.InvalidArgument, "too many device_ids: %d, the maximum is %d", len(deviceIds), constants.UPTIME_REPORT_MAX_DEVICES)
+		}
+
+		devices := make([]*genproto.Device, 0, len(deviceIds))
+		for _, deviceId := range deviceIds {
+			device, err := us.registryService.Get(ctx, deviceId)
+			if err != nil {
+				// A camera that is not in the registry has no name nor site
+				if status.Code(err) != codes.NotFound {
+					return nil, err
+				}
+				device = &genproto.Device{DeviceId: deviceId}
+			}
+			devices = append(devices, device)
+		}
+
+		return devices, nil
+	}
+
+	devices := make([]*genproto.Device, 0)
+	pageToken := ""
+	for {
+		page, nextPageToken, err := us.registryService.List(ctx, owner, "", constants.DEVICE_LIST_MAX_PAGE_SIZE, pageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, device := range page {
+			if site != "" && device.Location != site {
+				continue
+			}
+
+			devices = append(devices, device)
+			if len(devices) > constants.UPTIME_REPORT_MAX_DEVICES {
+				return nil, status.Errorf(codes.InvalidArgument, "more than %d devices in the report, narrow it down by owner or site", constants.UPTIME_REPORT_MAX_DEVICES)
+			}
+		}
+
+		if nextPageToken == "" {
+			return devices, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// Add the part of the period between from and to. The current period has not
+// ended, it runs until now.
+func (t *totals) addPeriod(period *genproto.CameraStatusPeriod, from, to int64, ended bool) {
+	seconds := min(period.EndedAt, to) - max(period.StartedAt, from)
+	if seconds <= 0 {
+		return
+	}
+
+	t.uptime.MonitoredSeconds += seconds
+	if period.Status == camerastatus.STATUS_ONLINE {
+		t.uptime.OnlineSeconds += seconds
+		return
+	}
+
+	t.uptime.OfflineSeconds += seconds
+	t.uptime.OutageCount++
+	t.uptime.LongestOutageSeconds = max(t.uptime.LongestOutageSeconds, seconds)
+
+	// The camera only recovered from an outage that ended in the report
+	if ended && period.EndedAt <= to {
+		t.recoveredSeconds += period.EndedAt - period.StartedAt
+		t.recoveredCount++
+	}
+}
+
+// Add the totals of a device to its site
+func (t *totals) add(device *totals) {
+	t.uptime.DeviceCount += device.uptime.DeviceCount
+	t.uptime.MonitoredSeconds += device.uptime.MonitoredSeconds
+	t.uptime.OnlineSeconds += device.uptime.OnlineSeconds
+	t.uptime.OfflineSeconds += device.uptime.OfflineSeconds
+	t.uptime.OutageCount += device.uptime.OutageCount
+	t.uptime.LongestOutageSeconds = max(t.uptime.LongestOutageSeconds, device.uptime.LongestOutageSeconds)
+	t.recoveredSeconds += device.recoveredSeconds
+	t.recoveredCount += device.recoveredCount
+}
+
+func (t *totals) finish() *genproto.Uptime {
+	if t.uptime.MonitoredSeconds > 0 {
+		t.uptime.AvailabilityPercent = 100 * float64(t.uptime.OnlineSeconds) / float64(t.uptime.MonitoredSeconds)
+	}
+	if t.recoveredCount > 0 {
+		t.uptime.MttrSeconds = t.recoveredSeconds / t.recoveredCount
+	}
+
+	return t.uptime
+}
+
+/**
+ * Render the report in the format, json or csv, and return its content type.
+ * The CSV has a row per device, then a row per site with an empty device_id.
+ */
+func (us *UptimeServiceImpl) Render(report *genproto.UptimeReport, format string) (string, []byte, error) {
+	switch format {
+	case "", FORMAT_JSON:
+		content, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(report)
+		if err != nil {
+			log.Error().Msgf("failed to marshal UptimeReport: %v", err)
+			return "", nil, fmt.Errorf("failed to marshal UptimeReport: %w", err)
+		}
+		return "application/json", content, nil
+	case FORMAT_CSV:
+		var content bytes.Buffer
+		w := csv.NewWriter(&content)
+
+		w.Write([]string{
+			"from", "to", "site", "device_id", "name", "device_count",
+			"monitored_seconds", "online_seconds", "offline_seconds", "availability_percent",
+			"outage_count", "longest_outage_seconds", "mttr_seconds",
+		})
+		for _, rows := range [][]*genproto.Uptime{report.Devices, report.Sites} {
+			for _, row := range rows {
+				w.Write([]string{
+					time.Unix(report.From, 0).UTC().Format(time.RFC3339),
+					time.Unix(report.To, 0).UTC().Format(time.RFC3339),
+					row.Site,
+					row.DeviceId,
+					row.Name,
+					strconv.Itoa(int(row.DeviceCount)),
+					strconv.FormatInt(row.MonitoredSeconds, 10),
+					strconv.FormatInt(row.OnlineSeconds, 10),
+					strconv.FormatInt(row.OfflineSeconds, 10),
+					strconv.FormatFloat(row.AvailabilityPercent, 'f', 3, 64),
+					strconv.Itoa(int(row.OutageCount)),
+					strconv.FormatInt(row.LongestOutageSeconds, 10),
+					strconv.FormatInt(row.MttrSeconds, 10),
+				})
+			}
+		}
+
+		w.Flush()
+		if err := w.Error(); err != nil {
+			log.Error().Msgf("failed to write UptimeReport CSV: %v", err)
+			return "", nil, fmt.Errorf("failed to write UptimeReport CSV: %w", err)
+		}
+		return "text/csv", content.Bytes(), nil
+	default:
+		return "", nil, status.Errorf(codes.InvalidArgument, "unsupported format: %s", format)
+	}
+}
@@ -0,0 +1,77 @@
+package uptime
+
+import (
+	"testing"
+
+	"github.com/andypmw/saladin-eye-ai/camera-service/common/genproto"
+	"github.com/andypmw/saladin-eye-ai/camera-service/service/camerastatus"
+)
+
+func TestAddPeriod(t *testing.T) {
+	const from, to = 1000, 2000
+
+	tests := []struct {
+		name                  string
+		status                string
+		startedAt, endedAt    int64
+		ended                 bool
+		online, offline       int64
+		outages               int32
+		longestOutage         int64
+		recovered, recoveries int64
+	}{
+		{"online inside", camerastatus.STATUS_ONLINE, 1100, 1400, true, 300, 0, 0, 0, 0, 0},
+		{"online across from", camerastatus.STATUS_ONLINE, 500, 1300, true, 300, 0, 0, 0, 0, 0},
+		{"online across to", camerastatus.STATUS_ONLINE, 1800, 2500, true, 200, 0, 0, 0, 0, 0},
+		{"before the report", camerastatus.STATUS_OFFLINE, 100, 900, true, 0, 0, 0, 0, 0, 0},
+		{"after the report", camerastatus.STATUS_OFFLINE, 2100, 2500, true, 0, 0, 0, 0, 0, 0},
+		{"ends at from", camerastatus.STATUS_OFFLINE, 500, 1000, true, 0, 0, 0, 0, 0, 0},
+		{"outage inside", camerastatus.STATUS_OFFLINE, 1200, 1500, true, 0, 300, 1, 300, 300, 1},
+		// Recovered in the report, the whole outage counts for the MTTR
+		{"outage across from", camerastatus.STATUS_OFFLINE, 800, 1100, true, 0, 100, 1, 100, 300, 1},
+		{"outage across to", camerastatus.STATUS_OFFLINE, 1900, 2300, true, 0, 100, 1, 100, 0, 0},
+		{"outage not ended", camerastatus.STATUS_OFFLINE, 1700, 1900, false, 0, 200, 1, 200, 0, 0},
+		{"degraded", camerastatus.STATUS_DEGRADED, 1200, 1300, true, 0, 100, 1, 100, 100, 1},
+	}
+
+	for _, tt := range tests {
+		deviceTotals := &totals{uptime: &genproto.Uptime{}}
+		deviceTotals.addPeriod(&genproto.CameraStatusPeriod{
+			Status:    tt.status,
+			StartedAt: tt.startedAt,
+			EndedAt:   tt.endedAt,
+		}, from, to, tt.ended)
+
+		uptime := deviceTotals.uptime
+		if uptime.MonitoredSeconds != tt.online+tt.offline {
+			t.Errorf("%s: monitored %d, want %d", tt.name, uptime.MonitoredSeconds, tt.online+tt.offline)
+		}
+		if uptime.OnlineSeconds != tt.online || uptime.OfflineSeconds != tt.offline {
+			t.Errorf("%s: online %d offline %d, want %d %d", tt.name, uptime.OnlineSeconds, uptime.OfflineSeconds, tt.online, tt.offline)
+		}
+		if uptime.OutageCount != tt.outages || uptime.LongestOutageSeconds != tt.longestOutage {
+			t.Errorf("%s: %d outages longest %d, want %d %d", tt.name, uptime.OutageCount, uptime.LongestOutageSeconds, tt.outages, tt.longestOutage)
+		}
+		if deviceTotals.recoveredSeconds != tt.recovered || deviceTotals.recoveredCount != tt.recoveries {
+			t.Errorf("%s: recovered %d in %d, want %d in %d", tt.name, deviceTotals.recoveredSeconds, deviceTotals.recoveredCount, tt.recovered, tt.recoveries)
+		}
+	}
+}
+
+func TestTotalsFinish(t *testing.T) {
+	deviceTotals := &totals{uptime: &genproto.Uptime{}}
+	deviceTotals.addPeriod(&genproto.CameraStatusPeriod{Status: camerastatus.STATUS_ONLINE, StartedAt: 0, EndedAt: 900}, 0, 1000, true)
+	deviceTotals.addPeriod(&genproto.CameraStatusPeriod{Status: camerastatus.STATUS_OFFLINE, StartedAt: 900, EndedAt: 960}, 0, 1000, true)
+	deviceTotals.addPeriod(&genproto.CameraStatusPeriod{Status: camerastatus.STATUS_OFFLINE, StartedAt: 960, EndedAt: 1000}, 0, 1000, true)
+
+	uptime := deviceTotals.finish()
+	if uptime.AvailabilityPercent != 90 {
+		t.Errorf("availability %v%%, want 90%%", uptime.AvailabilityPercent)
+	}
+	if uptime.MttrSeconds != 50 {
+		t.Errorf("MTTR %d seconds, want 50", uptime.MttrSeconds)
+	}
+	if uptime.LongestOutageSeconds != 60 {
+		t.Errorf("longest outage %d seconds, want 60", uptime.LongestOutageSeconds)
+	}
+}
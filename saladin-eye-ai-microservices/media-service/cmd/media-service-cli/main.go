@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/service/photo"
+)
+
+const usage = `SaladinEye.AI - Media Service - CLI
+
+Usage:
+  media-service-cli verify-integrity <device-id> [photo-path]
+      Download the photos again and check them against the integrity chain
+      of the device. Without photo path, every photo of the chain is checked.
+`
+
+func init() {
+	// Log setup, only problems are logged, the result goes to stdout
+	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "verify-integrity":
+		os.Exit(verifyIntegrity(os.Args[2:]))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+}
+
+func verifyIntegrity(args []string) int {
+	if len(args) < 1 || len(args) > 2 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	deviceId := args[0]
+	photoPath := ""
+	if len(args) == 2 {
+		photoPath = args[1]
+	}
+
+	photoService, err := photo.New()
+	if err != nil {
+		log.Fatal().Msgf("failed to create photo service: %v", err)
+	}
+
+	// The whole chain, a page at a time
+	checked, failures := int64(0), 0
+	for start := int64(0); ; {
+		result, err := photoService.VerifyIntegrity(context.Background(), deviceId, photoPath, start, 0)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to verify integrity: %v\n", err)
+			return 1
+		}
+
+		for _, failure := range result.Failures {
+			fmt.Printf("FAIL #%d %s: %s\n", failure.Index, failure.PhotoPath, failure.Reason)
+		}
+		checked += result.CheckedEntries
+		failures += len(result.Failures)
+
+		if result.NextIndex == 0 {
+			break
+		}
+		start = result.NextIndex
+	}
+
+	if failures > 0 {
+		fmt.Printf("device %s: %d entries checked, %d failures\n", deviceId, checked, failures)
+		return 1
+	}
+
+	fmt.Printf("device %s: %d entries checked, OK\n", deviceId, checked)
+	return 0
+}
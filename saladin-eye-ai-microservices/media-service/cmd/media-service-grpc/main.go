@@ -20,8 +20,8 @@ func main() {
 	log.Info().Msg("SaladinEye.AI - Media Service - GRPC Server")
 
 	// Start gRPC server
-	server := grpc.NewServer()
 	mediaService := grpcHandler.New()
+	server := grpc.NewServer(grpc.UnaryInterceptor(mediaService.DeviceInterceptor))
 	genproto.RegisterMediaServiceServer(server, mediaService)
 
 	port := os.Getenv("GRPC_PORT")
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	httpHandler "github.com/andypmw/saladin-eye-ai/media-service/handler/http"
+)
+
+func init() {
+	// Log setup
+	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+}
+
+func main() {
+	log.Info().Msg("SaladinEye.AI - Media Service - HTTP Server")
+
+	port := os.Getenv("HTTP_PORT")
+	if port == "" {
+		log.Fatal().Msg("HTTP_PORT environment variable not set")
+	}
+
+	mux := http.NewServeMux()
+	httpHandler.New().Register(mux)
+
+	// No write timeout, the live view streams for as long as it is watched
+	server := &http.Server{
+		Addr:              port,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Fatal().Err(server.ListenAndServe())
+}
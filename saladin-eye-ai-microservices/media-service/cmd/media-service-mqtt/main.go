@@ -1,6 +1,11 @@
 package main
 
 import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"os"
+
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
@@ -16,5 +21,26 @@ func main() {
 	log.Info().Msg("SaladinEye.AI - Media Service - MQTT Handler")
 
 	mqttHandler := mqttHandler.New()
+
+	// Optional, the handler metrics as JSON on /debug/vars, and the broker
+	// connection on /healthz, 503 while disconnected
+	if port := os.Getenv("METRICS_PORT"); port != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", expvar.Handler())
+		mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+			state := mqttHandler.Health()
+
+			w.Header().Set("Content-Type", "application/json")
+			if !state.Connected {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			json.NewEncoder(w).Encode(state)
+		})
+
+		go func() {
+			log.Fatal().Err(http.ListenAndServe(port, mux)).Msg("metrics server stopped")
+		}()
+	}
+
 	mqttHandler.Start()
 }
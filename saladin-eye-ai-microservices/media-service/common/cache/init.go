@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"context"
 	"os"
 	"sync"
 
@@ -11,7 +12,7 @@ import (
 
 // Singleton
 var (
-	redisClient redis.Cmdable
+	redisClient *redis.Client
 	once        sync.Once
 )
 
@@ -29,3 +30,10 @@ func New() redis.Cmdable {
 	})
 	return redisClient
 }
+
+// PSubscribe subscribes to the Pub/Sub channels matching the patterns.
+// The subscription has its own connection, and reconnects by itself.
+func PSubscribe(ctx context.Context, patterns ...string) *redis.PubSub {
+	New()
+	return redisClient.PSubscribe(ctx, patterns...)
+}
@@ -0,0 +1,19 @@
+package constants
+
+// The caller (the back-end) passes the permissions of the signed in user
+// as a comma separated list in this gRPC metadata
+const GRPC_METADATA_PERMISSIONS = "x-saladin-eye-permissions"
+
+const (
+	PERMISSION_VIEW_UNMASKED_MEDIA  = "media:view-unmasked"
+	PERMISSION_MANAGE_PRIVACY_MASKS = "media:manage-privacy-masks"
+	PERMISSION_MANAGE_WATERMARK     = "media:manage-watermark"
+	PERMISSION_MANAGE_DEVICE_KEYS   = "media:manage-device-keys"
+	PERMISSION_MANAGE_FIRMWARE      = "media:manage-firmware"
+	PERMISSION_VIEW_DEVICE_CLOCKS   = "media:view-device-clocks"
+	PERMISSION_VERIFY_INTEGRITY     = "media:verify-integrity"
+)
+
+// Optional, a retried call with the same key gets the response of the first
+// call instead of being run again
+const GRPC_METADATA_IDEMPOTENCY_KEY = "x-idempotency-key"
@@ -1,3 +1,58 @@
 package constants
 
 const MQTT_TOPIC_SUBSCRIBE = "saladin-eye/server/media-service/request/#"
+
+// With MQTT_SHARED_SUBSCRIPTION_GROUP set, the instances subscribe to
+// $share/[group]/[topic] and the broker hands every request to one of them
+const MQTT_SHARED_SUBSCRIPTION_FORMAT = "$share/%s/%s"
+
+// Defaults of the request worker pool, MQTT_WORKERS, MQTT_QUEUE_SIZE and
+// MQTT_REQUEST_TIMEOUT_SECONDS
+const (
+	MQTT_DEFAULT_WORKERS                 = 8
+	MQTT_DEFAULT_QUEUE_SIZE              = 256
+	MQTT_DEFAULT_REQUEST_TIMEOUT_SECONDS = 30
+	MQTT_PUBLISH_TIMEOUT_SECONDS         = 10
+)
+
+// Request topics are [prefix]/[method-name]/[device-id]/[idempotency-key]
+const MQTT_TOPIC_REQUEST_PREFIX = "saladin-eye/server/media-service/request"
+
+// The response of a request goes to the device that sent it, per method name
+const MQTT_TOPIC_RESPONSE_FORMAT = "saladin-eye/device/%s/response/media-service/%s"
+
+// A failed request is answered on its response topic with this suffix, with
+// an ErrorResponse instead of the method response
+const MQTT_TOPIC_ERROR_SUFFIX = "/error"
+
+// How long the devices wait before retrying a failed request
+const (
+	MQTT_ERROR_RETRY_AFTER_SECONDS                    = 5
+	MQTT_ERROR_RESOURCE_EXHAUSTED_RETRY_AFTER_SECONDS = 30
+	MQTT_ERROR_MESSAGE_MAX_LENGTH                     = 255
+)
+
+// Every topic of a device is under this prefix, MQTT 5 response topics too
+const MQTT_TOPIC_DEVICE_PREFIX_FORMAT = "saladin-eye/device/%s/"
+
+// MQTT 5 user properties of the requests and responses
+const (
+	MQTT_USER_PROPERTY_CONTENT_TYPE     = "content-type"
+	MQTT_USER_PROPERTY_PROTOCOL_VERSION = "protocol-version"
+	MQTT_USER_PROPERTY_MESSAGE_TYPE     = "message-type"
+	MQTT_USER_PROPERTY_IDEMPOTENCY_KEY  = "idempotency-key"
+
+	MQTT_CONTENT_TYPE_PROTOBUF = "application/x-protobuf"
+	MQTT_PROTOCOL_VERSION      = "1"
+)
+
+// The broker connection, reconnects back off exponentially from the min to
+// the max interval
+const (
+	MQTT_KEEP_ALIVE_SECONDS             = 30
+	MQTT_CONNECT_TIMEOUT_SECONDS        = 30
+	MQTT_RECONNECT_MIN_SECONDS          = 1
+	MQTT_RECONNECT_MAX_SECONDS          = 120
+	MQTT_SUBSCRIBE_QOS                  = 1
+	MQTT_DEFAULT_SESSION_EXPIRY_SECONDS = 3600
+)
@@ -1,3 +1,79 @@
 package constants
 
 const PHOTO_SERVICE_EXPIRATION_MINUTES = 15
+
+// A photo is downloaded whole to be checked, anything larger is not a photo
+// of the camera
+const PHOTO_MAX_SIZE_BYTES = 8 * 1024 * 1024
+
+// Image quality thresholds, applied on the 8-bit luminance of a confirmed photo
+const (
+	QUALITY_DARK_MEAN_LUMINANCE           = 30.0
+	QUALITY_OVEREXPOSED_MEAN_LUMINANCE    = 225.0
+	QUALITY_OBSTRUCTED_LUMINANCE_STDDEV   = 6.0
+	QUALITY_BLURRED_LAPLACIAN_VARIANCE    = 40.0
+	QUALITY_SCENE_CHANGE_HAMMING_DISTANCE = 24
+	QUALITY_BAD_FRAME_STREAK_THRESHOLD    = 5
+	QUALITY_REPORT_RETENTION_DAYS         = 30
+)
+
+// Verifying the integrity chain, a page at a time as every photo of the page
+// is downloaded again
+const (
+	INTEGRITY_VERIFY_DEFAULT_LIMIT = 100
+	INTEGRITY_VERIFY_MAX_LIMIT     = 1000
+)
+
+// Deleting the derived images of an old privacy mask version
+const DERIVED_PURGE_TIMEOUT_MINUTES = 10
+
+// Setting a privacy mask while it is changed concurrently, tried again with
+// the version that won
+const PRIVACY_MASK_SET_MAX_ATTEMPTS = 5
+
+// Live view (MJPEG over HTTP)
+const (
+	LIVE_VIEW_DEFAULT_MAX_FPS        = 2
+	LIVE_VIEW_MAX_FPS                = 10
+	LIVE_VIEW_URL_EXPIRATION_MINUTES = 5
+	LIVE_VIEW_REGISTRY_CHECK_SECONDS = 15
+)
+
+// Idempotent requests, the response is replayed to retries for as long as
+// the presigned URLs in it are valid
+const (
+	IDEMPOTENCY_TTL_MINUTES  = PHOTO_SERVICE_EXPIRATION_MINUTES
+	IDEMPOTENCY_WAIT_SECONDS = 10
+)
+
+// Device keys of the signed MQTT requests
+const (
+	DEVICE_KEY_MAX_KEYS             = 5
+	DEVICE_KEY_HMAC_SECRET_LENGTH   = 32
+	SIGNED_REQUEST_MAX_SKEW_SECONDS = 300
+	SIGNED_REQUEST_MIN_NONCE_LENGTH = 8
+	SIGNED_REQUEST_MAX_NONCE_LENGTH = 16
+
+	// The server time requests are accepted whatever their timestamp, only
+	// their nonce stops a replay, and a replayed one only gets the time
+	SIGNED_REQUEST_ANY_TIMESTAMP_NONCE_TTL_HOURS = 7 * 24
+)
+
+// Firmware images and their rollouts
+const (
+	FIRMWARE_MAX_SIZE_BYTES                    = 16 * 1024 * 1024
+	FIRMWARE_UPLOAD_URL_EXPIRATION_MINUTES     = 60
+	FIRMWARE_DOWNLOAD_URL_EXPIRATION_MINUTES   = PHOTO_SERVICE_EXPIRATION_MINUTES
+	FIRMWARE_ROLLOUT_MAX_DEVICES               = 1000
+	FIRMWARE_DEFAULT_FAILURE_THRESHOLD_PERCENT = 20
+	FIRMWARE_DEFAULT_MIN_FAILURES              = 3
+)
+
+// Device clocks, measured by the get-server-time MQTT method. A clock off by
+// more than the threshold is flagged.
+const (
+	DEVICE_CLOCK_SKEW_THRESHOLD_MS = 2000
+	DEVICE_CLOCK_TTL_DAYS          = 30
+	DEVICE_CLOCK_DEFAULT_LIST_SIZE = 100
+	DEVICE_CLOCK_MAX_LIST_SIZE     = 1000
+)
@@ -0,0 +1,225 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: media_service__create_firmware_rollout_request.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The devices offered the update are the device_ids and the enabled devices of
+// the registry matching every filter set below. At least one of them is set.
+type CreateFirmwareRolloutRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Moved from the rollout they are in to this one
+	DeviceIds []string `protobuf:"bytes,2,rep,name=device_ids,json=deviceIds,proto3" json:"device_ids,omitempty"`
+	// Share of the devices offered the update, 1 to 100
+	Percentage uint32 `protobuf:"varint,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	// 20 when 0
+	FailureThresholdPercent uint32 `protobuf:"varint,4,opt,name=failure_threshold_percent,json=failureThresholdPercent,proto3" json:"failure_threshold_percent,omitempty"`
+	// 3 when 0
+	MinFailures uint32 `protobuf:"varint,5,opt,name=min_failures,json=minFailures,proto3" json:"min_failures,omitempty"`
+	// The devices of this owner
+	Owner string `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The devices at this site, their location
+	Location string `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	// The devices with this tag
+	Tag string `protobuf:"bytes,8,opt,name=tag,proto3" json:"tag,omitempty"`
+}
+
+func (x *CreateFirmwareRolloutRequest) Reset() {
+	*x = CreateFirmwareRolloutRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_service__create_firmware_rollout_request_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateFirmwareRolloutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFirmwareRolloutRequest) ProtoMessage() {}
+
+func (x *CreateFirmwareRolloutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_service__create_firmware_rollout_request_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFirmwareRolloutRequest.ProtoReflect.Descriptor instead.
+func (*CreateFirmwareRolloutRequest) Descriptor() ([]byte, []int) {
+	return file_media_service__create_firmware_rollout_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateFirmwareRolloutRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CreateFirmwareRolloutRequest) GetDeviceIds() []string {
+	if x != nil {
+		return x.DeviceIds
+	}
+	return nil
+}
+
+func (x *CreateFirmwareRolloutRequest) GetPercentage() uint32 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *CreateFirmwareRolloutRequest) GetFailureThresholdPercent() uint32 {
+	if x != nil {
+		return x.FailureThresholdPercent
+	}
+	return 0
+}
+
+func (x *CreateFirmwareRolloutRequest) GetMinFailures() uint32 {
+	if x != nil {
+		return x.MinFailures
+	}
+	return 0
+}
+
+func (x *CreateFirmwareRolloutRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *CreateFirmwareRolloutRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *CreateFirmwareRolloutRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+var File_media_service__create_firmware_rollout_request_proto protoreflect.FileDescriptor
+
+var file_media_service__create_firmware_rollout_request_proto_rawDesc = []byte{
+	0x0a, 0x34, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65,
+	0x5f, 0x72, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65,
+	0x79, 0x65, 0x22, 0x9a, 0x02, 0x0a, 0x1c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x72,
+	0x6d, 0x77, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x3a, 0x0a, 0x19,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x17, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x6d, 0x69, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a,
+	0x03, 0x74, 0x61, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x42,
+	0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_media_service__create_firmware_rollout_request_proto_rawDescOnce sync.Once
+	file_media_service__create_firmware_rollout_request_proto_rawDescData = file_media_service__create_firmware_rollout_request_proto_rawDesc
+)
+
+func file_media_service__create_firmware_rollout_request_proto_rawDescGZIP() []byte {
+	file_media_service__create_firmware_rollout_request_proto_rawDescOnce.Do(func() {
+		file_media_service__create_firmware_rollout_request_proto_rawDescData = protoimpl.X.CompressGZIP(file_media_service__create_firmware_rollout_request_proto_rawDescData)
+	})
+	return file_media_service__create_firmware_rollout_request_proto_rawDescData
+}
+
+var file_media_service__create_firmware_rollout_request_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_media_service__create_firmware_rollout_request_proto_goTypes = []any{
+	(*CreateFirmwareRolloutRequest)(nil), // 0: saladineye.CreateFirmwareRolloutRequest
+}
+var file_media_service__create_firmware_rollout_request_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_media_service__create_firmware_rollout_request_proto_init() }
+func file_media_service__create_firmware_rollout_request_proto_init() {
+	if File_media_service__create_firmware_rollout_request_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_media_service__create_firmware_rollout_request_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateFirmwareRolloutRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_media_service__create_firmware_rollout_request_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_media_service__create_firmware_rollout_request_proto_goTypes,
+		DependencyIndexes: file_media_service__create_firmware_rollout_request_proto_depIdxs,
+		MessageInfos:      file_media_service__create_firmware_rollout_request_proto_msgTypes,
+	}.Build()
+	File_media_service__create_firmware_rollout_request_proto = out.File
+	file_media_service__create_firmware_rollout_request_proto_rawDesc = nil
+	file_media_service__create_firmware_rollout_request_proto_goTypes = nil
+	file_media_service__create_firmware_rollout_request_proto_depIdxs = nil
+}
@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: media_service__export_photo_request.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExportPhotoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Date     string `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Hour     int32  `protobuf:"varint,3,opt,name=hour,proto3" json:"hour,omitempty"`
+	FileName string `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Unmasked bool   `protobuf:"varint,5,opt,name=unmasked,proto3" json:"unmasked,omitempty"`
+}
+
+func (x *ExportPhotoRequest) Reset() {
+	*x = ExportPhotoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_service__export_photo_request_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportPhotoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPhotoRequest) ProtoMessage() {}
+
+func (x *ExportPhotoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_service__export_photo_request_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPhotoRequest.ProtoReflect.Descriptor instead.
+func (*ExportPhotoRequest) Descriptor() ([]byte, []int) {
+	return file_media_service__export_photo_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExportPhotoRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ExportPhotoRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *ExportPhotoRequest) GetHour() int32 {
+	if x != nil {
+		return x.Hour
+	}
+	return 0
+}
+
+func (x *ExportPhotoRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportPhotoRequest) GetUnmasked() bool {
+	if x != nil {
+		return x.Unmasked
+	}
+	return false
+}
+
+var File_media_service__export_photo_request_proto protoreflect.FileDescriptor
+
+var file_media_service__export_photo_request_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c,
+	0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x22, 0x92, 0x01, 0x0a, 0x12, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x68,
+	0x6f, 0x75, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x6d, 0x61, 0x73, 0x6b, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x75, 0x6e, 0x6d, 0x61, 0x73, 0x6b, 0x65, 0x64, 0x42, 0x13, 0x5a, 0x11,
+	0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_media_service__export_photo_request_proto_rawDescOnce sync.Once
+	file_media_service__export_photo_request_proto_rawDescData = file_media_service__export_photo_request_proto_rawDesc
+)
+
+func file_media_service__export_photo_request_proto_rawDescGZIP() []byte {
+	file_media_service__export_photo_request_proto_rawDescOnce.Do(func() {
+		file_media_service__export_photo_request_proto_rawDescData = protoimpl.X.CompressGZIP(file_media_service__export_photo_request_proto_rawDescData)
+	})
+	return file_media_service__export_photo_request_proto_rawDescData
+}
+
+var file_media_service__export_photo_request_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_media_service__export_photo_request_proto_goTypes = []any{
+	(*ExportPhotoRequest)(nil), // 0: saladineye.ExportPhotoRequest
+}
+var file_media_service__export_photo_request_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_media_service__export_photo_request_proto_init() }
+func file_media_service__export_photo_request_proto_init() {
+	if File_media_service__export_photo_request_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_media_service__export_photo_request_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ExportPhotoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_media_service__export_photo_request_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_media_service__export_photo_request_proto_goTypes,
+		DependencyIndexes: file_media_service__export_photo_request_proto_depIdxs,
+		MessageInfos:      file_media_service__export_photo_request_proto_msgTypes,
+	}.Build()
+	File_media_service__export_photo_request_proto = out.File
+	file_media_service__export_photo_request_proto_rawDesc = nil
+	file_media_service__export_photo_request_proto_goTypes = nil
+	file_media_service__export_photo_request_proto_depIdxs = nil
+}
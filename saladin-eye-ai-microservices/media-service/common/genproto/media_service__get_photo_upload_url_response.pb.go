@@ -28,6 +28,7 @@ type GetPhotoUploadUrlResponse struct {
 	DeviceId          string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
 	UploadUrl         string `protobuf:"bytes,2,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
 	OriginalPhotoPath string `protobuf:"bytes,3,opt,name=original_photo_path,json=originalPhotoPath,proto3" json:"original_photo_path,omitempty"`
+	PhotoPath         string `protobuf:"bytes,4,opt,name=photo_path,json=photoPath,proto3" json:"photo_path,omitempty"`
 }
 
 func (x *GetPhotoUploadUrlResponse) Reset() {
@@ -83,6 +84,13 @@ func (x *GetPhotoUploadUrlResponse) GetOriginalPhotoPath() string {
 	return ""
 }
 
+func (x *GetPhotoUploadUrlResponse) GetPhotoPath() string {
+	if x != nil {
+		return x.PhotoPath
+	}
+	return ""
+}
+
 var File_media_service__get_photo_upload_url_response_proto protoreflect.FileDescriptor
 
 var file_media_service__get_photo_upload_url_response_proto_rawDesc = []byte{
@@ -90,7 +98,7 @@ var file_media_service__get_photo_upload_url_response_proto_rawDesc = []byte{
 	0x5f, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61,
 	0x64, 0x5f, 0x75, 0x72, 0x6c, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65,
-	0x22, 0x87, 0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x55, 0x70, 0x6c,
+	0x22, 0xa6, 0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x55, 0x70, 0x6c,
 	0x6f, 0x61, 0x64, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b,
 	0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x75,
@@ -98,9 +106,11 @@ var file_media_service__get_photo_upload_url_response_proto_rawDesc = []byte{
 	0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x72, 0x6c, 0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x72,
 	0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x70, 0x61, 0x74,
 	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61,
-	0x6c, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f,
-	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x68,
+	0x6f, 0x74, 0x6f, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x68, 0x6f, 0x74, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
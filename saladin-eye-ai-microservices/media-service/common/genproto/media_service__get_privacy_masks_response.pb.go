@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: media_service__get_privacy_masks_response.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetPrivacyMasksResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string                `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Version  int64                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Polygons []*PrivacyMaskPolygon `protobuf:"bytes,3,rep,name=polygons,proto3" json:"polygons,omitempty"`
+}
+
+func (x *GetPrivacyMasksResponse) Reset() {
+	*x = GetPrivacyMasksResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_service__get_privacy_masks_response_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPrivacyMasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrivacyMasksResponse) ProtoMessage() {}
+
+func (x *GetPrivacyMasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_service__get_privacy_masks_response_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrivacyMasksResponse.ProtoReflect.Descriptor instead.
+func (*GetPrivacyMasksResponse) Descriptor() ([]byte, []int) {
+	return file_media_service__get_privacy_masks_response_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPrivacyMasksResponse) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *GetPrivacyMasksResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetPrivacyMasksResponse) GetPolygons() []*PrivacyMaskPolygon {
+	if x != nil {
+		return x.Polygons
+	}
+	return nil
+}
+
+var File_media_service__get_privacy_masks_response_proto protoreflect.FileDescriptor
+
+var file_media_service__get_privacy_masks_response_proto_rawDesc = []byte{
+	0x0a, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x5f, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x5f, 0x6d, 0x61, 0x73,
+	0x6b, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x1a, 0x29, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x5f, 0x70, 0x72,
+	0x69, 0x76, 0x61, 0x63, 0x79, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x5f, 0x70, 0x6f, 0x6c, 0x79, 0x67,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8c, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74,
+	0x50, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x4d, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x08, 0x70,
+	0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x50, 0x72, 0x69, 0x76, 0x61,
+	0x63, 0x79, 0x4d, 0x61, 0x73, 0x6b, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x52, 0x08, 0x70,
+	0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_media_service__get_privacy_masks_response_proto_rawDescOnce sync.Once
+	file_media_service__get_privacy_masks_response_proto_rawDescData = file_media_service__get_privacy_masks_response_proto_rawDesc
+)
+
+func file_media_service__get_privacy_masks_response_proto_rawDescGZIP() []byte {
+	file_media_service__get_privacy_masks_response_proto_rawDescOnce.Do(func() {
+		file_media_service__get_privacy_masks_response_proto_rawDescData = protoimpl.X.CompressGZIP(file_media_service__get_privacy_masks_response_proto_rawDescData)
+	})
+	return file_media_service__get_privacy_masks_response_proto_rawDescData
+}
+
+var file_media_service__get_privacy_masks_response_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_media_service__get_privacy_masks_response_proto_goTypes = []any{
+	(*GetPrivacyMasksResponse)(nil), // 0: saladineye.GetPrivacyMasksResponse
+	(*PrivacyMaskPolygon)(nil),      // 1: saladineye.PrivacyMaskPolygon
+}
+var file_media_service__get_privacy_masks_response_proto_depIdxs = []int32{
+	1, // 0: saladineye.GetPrivacyMasksResponse.polygons:type_name -> saladineye.PrivacyMaskPolygon
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_media_service__get_privacy_masks_response_proto_init() }
+func file_media_service__get_privacy_masks_response_proto_init() {
+	if File_media_service__get_privacy_masks_response_proto != nil {
+		return
+	}
+	file_media_service__privacy_mask_polygon_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_media_service__get_privacy_masks_response_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPrivacyMasksResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_media_service__get_privacy_masks_response_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_media_service__get_privacy_masks_response_proto_goTypes,
+		DependencyIndexes: file_media_service__get_privacy_masks_response_proto_depIdxs,
+		MessageInfos:      file_media_service__get_privacy_masks_response_proto_msgTypes,
+	}.Build()
+	File_media_service__get_privacy_masks_response_proto = out.File
+	file_media_service__get_privacy_masks_response_proto_rawDesc = nil
+	file_media_service__get_privacy_masks_response_proto_goTypes = nil
+	file_media_service__get_privacy_masks_response_proto_depIdxs = nil
+}
@@ -0,0 +1,190 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: media_service__get_server_time_request.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// MQTT method get-server-time, for the devices that can't reach an NTP server.
+// The device takes the offset of its clock NTP style, with t0 the time it sent
+// the request and t3 the time it got the response, both by its own clock:
+//
+//	offset     = ((server_receive_time_ms - t0) + (server_send_time_ms - t3)) / 2
+//	round trip = (t3 - t0) - (server_send_time_ms - server_receive_time_ms)
+//
+// A replayed response has a long round trip, drop it.
+type GetServerTimeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// t0, Unix time in milliseconds by the device clock
+	DeviceSendTimeMs int64 `protobuf:"varint,2,opt,name=device_send_time_ms,json=deviceSendTimeMs,proto3" json:"device_send_time_ms,omitempty"`
+	// The offset measured at the previous sync, before the device corrected
+	// its clock, server time minus device time. Lets the server record the skew
+	// of the device clock, 0 on the first sync.
+	LastOffsetMs int64 `protobuf:"zigzag64,3,opt,name=last_offset_ms,json=lastOffsetMs,proto3" json:"last_offset_ms,omitempty"`
+	// The round trip of the previous sync, 0 when there is none
+	LastRoundTripMs uint32 `protobuf:"varint,4,opt,name=last_round_trip_ms,json=lastRoundTripMs,proto3" json:"last_round_trip_ms,omitempty"`
+}
+
+func (x *GetServerTimeRequest) Reset() {
+	*x = GetServerTimeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_service__get_server_time_request_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetServerTimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerTimeRequest) ProtoMessage() {}
+
+func (x *GetServerTimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_service__get_server_time_request_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerTimeRequest.ProtoReflect.Descriptor instead.
+func (*GetServerTimeRequest) Descriptor() ([]byte, []int) {
+	return file_media_service__get_server_time_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetServerTimeRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *GetServerTimeRequest) GetDeviceSendTimeMs() int64 {
+	if x != nil {
+		return x.DeviceSendTimeMs
+	}
+	return 0
+}
+
+func (x *GetServerTimeRequest) GetLastOffsetMs() int64 {
+	if x != nil {
+		return x.LastOffsetMs
+	}
+	return 0
+}
+
+func (x *GetServerTimeRequest) GetLastRoundTripMs() uint32 {
+	if x != nil {
+		return x.LastRoundTripMs
+	}
+	return 0
+}
+
+var File_media_service__get_server_time_request_proto protoreflect.FileDescriptor
+
+var file_media_service__get_server_time_request_proto_rawDesc = []byte{
+	0x0a, 0x2c, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x5f, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a,
+	0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x22, 0xb5, 0x01, 0x0a, 0x14, 0x47,
+	0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64,
+	0x12, 0x2d, 0x0a, 0x13, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12,
+	0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x6d,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x12, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x4d, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x5f, 0x74, 0x72, 0x69, 0x70, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x72, 0x69, 0x70,
+	0x4d, 0x73, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67,
+	0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_media_service__get_server_time_request_proto_rawDescOnce sync.Once
+	file_media_service__get_server_time_request_proto_rawDescData = file_media_service__get_server_time_request_proto_rawDesc
+)
+
+func file_media_service__get_server_time_request_proto_rawDescGZIP() []byte {
+	file_media_service__get_server_time_request_proto_rawDescOnce.Do(func() {
+		file_media_service__get_server_time_request_proto_rawDescData = protoimpl.X.CompressGZIP(file_media_service__get_server_time_request_proto_rawDescData)
+	})
+	return file_media_service__get_server_time_request_proto_rawDescData
+}
+
+var file_media_service__get_server_time_request_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_media_service__get_server_time_request_proto_goTypes = []any{
+	(*GetServerTimeRequest)(nil), // 0: saladineye.GetServerTimeRequest
+}
+var file_media_service__get_server_time_request_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_media_service__get_server_time_request_proto_init() }
+func file_media_service__get_server_time_request_proto_init() {
+	if File_media_service__get_server_time_request_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_media_service__get_server_time_request_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetServerTimeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_media_service__get_server_time_request_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_media_service__get_server_time_request_proto_goTypes,
+		DependencyIndexes: file_media_service__get_server_time_request_proto_depIdxs,
+		MessageInfos:      file_media_service__get_server_time_request_proto_msgTypes,
+	}.Build()
+	File_media_service__get_server_time_request_proto = out.File
+	file_media_service__get_server_time_request_proto_rawDesc = nil
+	file_media_service__get_server_time_request_proto_goTypes = nil
+	file_media_service__get_server_time_request_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: media_service__set_watermark_settings_request.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetWatermarkSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string             `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Settings *WatermarkSettings `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *SetWatermarkSettingsRequest) Reset() {
+	*x = SetWatermarkSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_service__set_watermark_settings_request_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetWatermarkSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWatermarkSettingsRequest) ProtoMessage() {}
+
+func (x *SetWatermarkSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_service__set_watermark_settings_request_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWatermarkSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetWatermarkSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_media_service__set_watermark_settings_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetWatermarkSettingsRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SetWatermarkSettingsRequest) GetSettings() *WatermarkSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+var File_media_service__set_watermark_settings_request_proto protoreflect.FileDescriptor
+
+var file_media_service__set_watermark_settings_request_proto_rawDesc = []byte{
+	0x0a, 0x33, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x5f, 0x73, 0x65, 0x74, 0x5f, 0x77, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x5f, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79,
+	0x65, 0x1a, 0x27, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x5f, 0x77, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x5f, 0x73, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x75, 0x0a, 0x1b, 0x53, 0x65,
+	0x74, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64,
+	0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65,
+	0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_media_service__set_watermark_settings_request_proto_rawDescOnce sync.Once
+	file_media_service__set_watermark_settings_request_proto_rawDescData = file_media_service__set_watermark_settings_request_proto_rawDesc
+)
+
+func file_media_service__set_watermark_settings_request_proto_rawDescGZIP() []byte {
+	file_media_service__set_watermark_settings_request_proto_rawDescOnce.Do(func() {
+		file_media_service__set_watermark_settings_request_proto_rawDescData = protoimpl.X.CompressGZIP(file_media_service__set_watermark_settings_request_proto_rawDescData)
+	})
+	return file_media_service__set_watermark_settings_request_proto_rawDescData
+}
+
+var file_media_service__set_watermark_settings_request_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_media_service__set_watermark_settings_request_proto_goTypes = []any{
+	(*SetWatermarkSettingsRequest)(nil), // 0: saladineye.SetWatermarkSettingsRequest
+	(*WatermarkSettings)(nil),           // 1: saladineye.WatermarkSettings
+}
+var file_media_service__set_watermark_settings_request_proto_depIdxs = []int32{
+	1, // 0: saladineye.SetWatermarkSettingsRequest.settings:type_name -> saladineye.WatermarkSettings
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_media_service__set_watermark_settings_request_proto_init() }
+func file_media_service__set_watermark_settings_request_proto_init() {
+	if File_media_service__set_watermark_settings_request_proto != nil {
+		return
+	}
+	file_media_service__watermark_settings_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_media_service__set_watermark_settings_request_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SetWatermarkSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_media_service__set_watermark_settings_request_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_media_service__set_watermark_settings_request_proto_goTypes,
+		DependencyIndexes: file_media_service__set_watermark_settings_request_proto_depIdxs,
+		MessageInfos:      file_media_service__set_watermark_settings_request_proto_msgTypes,
+	}.Build()
+	File_media_service__set_watermark_settings_request_proto = out.File
+	file_media_service__set_watermark_settings_request_proto_rawDesc = nil
+	file_media_service__set_watermark_settings_request_proto_goTypes = nil
+	file_media_service__set_watermark_settings_request_proto_depIdxs = nil
+}
@@ -0,0 +1,179 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: media_service__verify_photo_integrity_request.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VerifyPhotoIntegrityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Verify a single photo, or a page of the chain of the device when empty
+	PhotoPath string `protobuf:"bytes,2,opt,name=photo_path,json=photoPath,proto3" json:"photo_path,omitempty"`
+	// The page of the chain, from start_index on, the photo of every entry is
+	// downloaded again. limit is 100 by default, at most 1000.
+	StartIndex int64 `protobuf:"varint,3,opt,name=start_index,json=startIndex,proto3" json:"start_index,omitempty"`
+	Limit      int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *VerifyPhotoIntegrityRequest) Reset() {
+	*x = VerifyPhotoIntegrityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_service__verify_photo_integrity_request_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyPhotoIntegrityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPhotoIntegrityRequest) ProtoMessage() {}
+
+func (x *VerifyPhotoIntegrityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_service__verify_photo_integrity_request_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPhotoIntegrityRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPhotoIntegrityRequest) Descriptor() ([]byte, []int) {
+	return file_media_service__verify_photo_integrity_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VerifyPhotoIntegrityRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *VerifyPhotoIntegrityRequest) GetPhotoPath() string {
+	if x != nil {
+		return x.PhotoPath
+	}
+	return ""
+}
+
+func (x *VerifyPhotoIntegrityRequest) GetStartIndex() int64 {
+	if x != nil {
+		return x.StartIndex
+	}
+	return 0
+}
+
+func (x *VerifyPhotoIntegrityRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+var File_media_service__verify_photo_integrity_request_proto protoreflect.FileDescriptor
+
+var file_media_service__verify_photo_integrity_request_proto_rawDesc = []byte{
+	0x0a, 0x33, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79,
+	0x65, 0x22, 0x90, 0x01, 0x0a, 0x1b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x68, 0x6f, 0x74,
+	0x6f, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_media_service__verify_photo_integrity_request_proto_rawDescOnce sync.Once
+	file_media_service__verify_photo_integrity_request_proto_rawDescData = file_media_service__verify_photo_integrity_request_proto_rawDesc
+)
+
+func file_media_service__verify_photo_integrity_request_proto_rawDescGZIP() []byte {
+	file_media_service__verify_photo_integrity_request_proto_rawDescOnce.Do(func() {
+		file_media_service__verify_photo_integrity_request_proto_rawDescData = protoimpl.X.CompressGZIP(file_media_service__verify_photo_integrity_request_proto_rawDescData)
+	})
+	return file_media_service__verify_photo_integrity_request_proto_rawDescData
+}
+
+var file_media_service__verify_photo_integrity_request_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_media_service__verify_photo_integrity_request_proto_goTypes = []any{
+	(*VerifyPhotoIntegrityRequest)(nil), // 0: saladineye.VerifyPhotoIntegrityRequest
+}
+var file_media_service__verify_photo_integrity_request_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_media_service__verify_photo_integrity_request_proto_init() }
+func file_media_service__verify_photo_integrity_request_proto_init() {
+	if File_media_service__verify_photo_integrity_request_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_media_service__verify_photo_integrity_request_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*VerifyPhotoIntegrityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_media_service__verify_photo_integrity_request_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_media_service__verify_photo_integrity_request_proto_goTypes,
+		DependencyIndexes: file_media_service__verify_photo_integrity_request_proto_depIdxs,
+		MessageInfos:      file_media_service__verify_photo_integrity_request_proto_msgTypes,
+	}.Build()
+	File_media_service__verify_photo_integrity_request_proto = out.File
+	file_media_service__verify_photo_integrity_request_proto_rawDesc = nil
+	file_media_service__verify_photo_integrity_request_proto_goTypes = nil
+	file_media_service__verify_photo_integrity_request_proto_depIdxs = nil
+}
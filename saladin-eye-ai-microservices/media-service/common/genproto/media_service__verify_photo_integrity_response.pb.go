@@ -0,0 +1,194 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: media_service__verify_photo_integrity_response.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VerifyPhotoIntegrityResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId       string              `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Valid          bool                `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	CheckedEntries int64               `protobuf:"varint,3,opt,name=checked_entries,json=checkedEntries,proto3" json:"checked_entries,omitempty"`
+	Failures       []*IntegrityFailure `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+	// Where the next page starts, 0 once the end of the chain is reached
+	NextIndex int64 `protobuf:"varint,5,opt,name=next_index,json=nextIndex,proto3" json:"next_index,omitempty"`
+}
+
+func (x *VerifyPhotoIntegrityResponse) Reset() {
+	*x = VerifyPhotoIntegrityResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_service__verify_photo_integrity_response_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyPhotoIntegrityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPhotoIntegrityResponse) ProtoMessage() {}
+
+func (x *VerifyPhotoIntegrityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_service__verify_photo_integrity_response_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPhotoIntegrityResponse.ProtoReflect.Descriptor instead.
+func (*VerifyPhotoIntegrityResponse) Descriptor() ([]byte, []int) {
+	return file_media_service__verify_photo_integrity_response_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VerifyPhotoIntegrityResponse) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *VerifyPhotoIntegrityResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyPhotoIntegrityResponse) GetCheckedEntries() int64 {
+	if x != nil {
+		return x.CheckedEntries
+	}
+	return 0
+}
+
+func (x *VerifyPhotoIntegrityResponse) GetFailures() []*IntegrityFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *VerifyPhotoIntegrityResponse) GetNextIndex() int64 {
+	if x != nil {
+		return x.NextIndex
+	}
+	return 0
+}
+
+var File_media_service__verify_photo_integrity_response_proto protoreflect.FileDescriptor
+
+var file_media_service__verify_photo_integrity_response_proto_rawDesc = []byte{
+	0x0a, 0x34, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65,
+	0x79, 0x65, 0x1a, 0x26, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd3, 0x01, 0x0a, 0x1c, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x27,
+	0x0a, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64,
+	0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x61, 0x6c, 0x61,
+	0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_media_service__verify_photo_integrity_response_proto_rawDescOnce sync.Once
+	file_media_service__verify_photo_integrity_response_proto_rawDescData = file_media_service__verify_photo_integrity_response_proto_rawDesc
+)
+
+func file_media_service__verify_photo_integrity_response_proto_rawDescGZIP() []byte {
+	file_media_service__verify_photo_integrity_response_proto_rawDescOnce.Do(func() {
+		file_media_service__verify_photo_integrity_response_proto_rawDescData = protoimpl.X.CompressGZIP(file_media_service__verify_photo_integrity_response_proto_rawDescData)
+	})
+	return file_media_service__verify_photo_integrity_response_proto_rawDescData
+}
+
+var file_media_service__verify_photo_integrity_response_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_media_service__verify_photo_integrity_response_proto_goTypes = []any{
+	(*VerifyPhotoIntegrityResponse)(nil), // 0: saladineye.VerifyPhotoIntegrityResponse
+	(*IntegrityFailure)(nil),             // 1: saladineye.IntegrityFailure
+}
+var file_media_service__verify_photo_integrity_response_proto_depIdxs = []int32{
+	1, // 0: saladineye.VerifyPhotoIntegrityResponse.failures:type_name -> saladineye.IntegrityFailure
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_media_service__verify_photo_integrity_response_proto_init() }
+func file_media_service__verify_photo_integrity_response_proto_init() {
+	if File_media_service__verify_photo_integrity_response_proto != nil {
+		return
+	}
+	file_media_service__integrity_failure_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_media_service__verify_photo_integrity_response_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*VerifyPhotoIntegrityResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_media_service__verify_photo_integrity_response_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_media_service__verify_photo_integrity_response_proto_goTypes,
+		DependencyIndexes: file_media_service__verify_photo_integrity_response_proto_depIdxs,
+		MessageInfos:      file_media_service__verify_photo_integrity_response_proto_msgTypes,
+	}.Build()
+	File_media_service__verify_photo_integrity_response_proto = out.File
+	file_media_service__verify_photo_integrity_response_proto_rawDesc = nil
+	file_media_service__verify_photo_integrity_response_proto_goTypes = nil
+	file_media_service__verify_photo_integrity_response_proto_depIdxs = nil
+}
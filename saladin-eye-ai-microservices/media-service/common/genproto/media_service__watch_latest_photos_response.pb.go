@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: media_service__watch_latest_photos_response.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WatchLatestPhotosResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId    string    `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Date        string    `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Hour        int32     `protobuf:"varint,3,opt,name=hour,proto3" json:"hour,omitempty"`
+	File        *FileInfo `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	ConfirmedAt int64     `protobuf:"varint,5,opt,name=confirmed_at,json=confirmedAt,proto3" json:"confirmed_at,omitempty"`
+	// Photos skipped since the previous response because the watcher was too slow
+	Skipped int64 `protobuf:"varint,6,opt,name=skipped,proto3" json:"skipped,omitempty"`
+}
+
+func (x *WatchLatestPhotosResponse) Reset() {
+	*x = WatchLatestPhotosResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_media_service__watch_latest_photos_response_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchLatestPhotosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLatestPhotosResponse) ProtoMessage() {}
+
+func (x *WatchLatestPhotosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_service__watch_latest_photos_response_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLatestPhotosResponse.ProtoReflect.Descriptor instead.
+func (*WatchLatestPhotosResponse) Descriptor() ([]byte, []int) {
+	return file_media_service__watch_latest_photos_response_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchLatestPhotosResponse) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *WatchLatestPhotosResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *WatchLatestPhotosResponse) GetHour() int32 {
+	if x != nil {
+		return x.Hour
+	}
+	return 0
+}
+
+func (x *WatchLatestPhotosResponse) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *WatchLatestPhotosResponse) GetConfirmedAt() int64 {
+	if x != nil {
+		return x.ConfirmedAt
+	}
+	return 0
+}
+
+func (x *WatchLatestPhotosResponse) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+var File_media_service__watch_latest_photos_response_proto protoreflect.FileDescriptor
+
+var file_media_service__watch_latest_photos_response_proto_rawDesc = []byte{
+	0x0a, 0x31, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x5f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x68,
+	0x6f, 0x74, 0x6f, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x1a,
+	0x1e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xc7, 0x01, 0x0a, 0x19, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x50,
+	0x68, 0x6f, 0x74, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x68, 0x6f,
+	0x75, 0x72, 0x12, 0x28, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x73, 0x61, 0x6c, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x79, 0x65, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_media_service__watch_latest_photos_response_proto_rawDescOnce sync.Once
+	file_media_service__watch_latest_photos_response_proto_rawDescData = file_media_service__watch_latest_photos_response_proto_rawDesc
+)
+
+func file_media_service__watch_latest_photos_response_proto_rawDescGZIP() []byte {
+	file_media_service__watch_latest_photos_response_proto_rawDescOnce.Do(func() {
+		file_media_service__watch_latest_photos_response_proto_rawDescData = protoimpl.X.CompressGZIP(file_media_service__watch_latest_photos_response_proto_rawDescData)
+	})
+	return file_media_service__watch_latest_photos_response_proto_rawDescData
+}
+
+var file_media_service__watch_latest_photos_response_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_media_service__watch_latest_photos_response_proto_goTypes = []any{
+	(*WatchLatestPhotosResponse)(nil), // 0: saladineye.WatchLatestPhotosResponse
+	(*FileInfo)(nil),                  // 1: saladineye.FileInfo
+}
+var file_media_service__watch_latest_photos_response_proto_depIdxs = []int32{
+	1, // 0: saladineye.WatchLatestPhotosResponse.file:type_name -> saladineye.FileInfo
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_media_service__watch_latest_photos_response_proto_init() }
+func file_media_service__watch_latest_photos_response_proto_init() {
+	if File_media_service__watch_latest_photos_response_proto != nil {
+		return
+	}
+	file_media_service__file_info_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_media_service__watch_latest_photos_response_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchLatestPhotosResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_media_service__watch_latest_photos_response_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_media_service__watch_latest_photos_response_proto_goTypes,
+		DependencyIndexes: file_media_service__watch_latest_photos_response_proto_depIdxs,
+		MessageInfos:      file_media_service__watch_latest_photos_response_proto_msgTypes,
+	}.Build()
+	File_media_service__watch_latest_photos_response_proto = out.File
+	file_media_service__watch_latest_photos_response_proto_rawDesc = nil
+	file_media_service__watch_latest_photos_response_proto_goTypes = nil
+	file_media_service__watch_latest_photos_response_proto_depIdxs = nil
+}
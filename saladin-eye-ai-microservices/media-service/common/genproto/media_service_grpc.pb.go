@@ -19,8 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MediaService_GetPhotoUploadUrl_FullMethodName   = "/saladineye.MediaService/GetPhotoUploadUrl"
-	MediaService_ListFilesByDateHour_FullMethodName = "/saladineye.MediaService/ListFilesByDateHour"
+	MediaService_GetPhotoUploadUrl_FullMethodName      = "/saladineye.MediaService/GetPhotoUploadUrl"
+	MediaService_ListFilesByDateHour_FullMethodName    = "/saladineye.MediaService/ListFilesByDateHour"
+	MediaService_SetPrivacyMasks_FullMethodName        = "/saladineye.MediaService/SetPrivacyMasks"
+	MediaService_GetPrivacyMasks_FullMethodName        = "/saladineye.MediaService/GetPrivacyMasks"
+	MediaService_SetWatermarkSettings_FullMethodName   = "/saladineye.MediaService/SetWatermarkSettings"
+	MediaService_GetWatermarkSettings_FullMethodName   = "/saladineye.MediaService/GetWatermarkSettings"
+	MediaService_ExportPhoto_FullMethodName            = "/saladineye.MediaService/ExportPhoto"
+	MediaService_VerifyPhotoIntegrity_FullMethodName   = "/saladineye.MediaService/VerifyPhotoIntegrity"
+	MediaService_WatchLatestPhotos_FullMethodName      = "/saladineye.MediaService/WatchLatestPhotos"
+	MediaService_GetLiveViewUrl_FullMethodName         = "/saladineye.MediaService/GetLiveViewUrl"
+	MediaService_RegisterDeviceKey_FullMethodName      = "/saladineye.MediaService/RegisterDeviceKey"
+	MediaService_RevokeDeviceKey_FullMethodName        = "/saladineye.MediaService/RevokeDeviceKey"
+	MediaService_ListDeviceKeys_FullMethodName         = "/saladineye.MediaService/ListDeviceKeys"
+	MediaService_CreateFirmwareRelease_FullMethodName  = "/saladineye.MediaService/CreateFirmwareRelease"
+	MediaService_PublishFirmwareRelease_FullMethodName = "/saladineye.MediaService/PublishFirmwareRelease"
+	MediaService_ListFirmwareReleases_FullMethodName   = "/saladineye.MediaService/ListFirmwareReleases"
+	MediaService_CreateFirmwareRollout_FullMethodName  = "/saladineye.MediaService/CreateFirmwareRollout"
+	MediaService_UpdateFirmwareRollout_FullMethodName  = "/saladineye.MediaService/UpdateFirmwareRollout"
+	MediaService_GetFirmwareRollout_FullMethodName     = "/saladineye.MediaService/GetFirmwareRollout"
+	MediaService_ListDeviceClocks_FullMethodName       = "/saladineye.MediaService/ListDeviceClocks"
 )
 
 // MediaServiceClient is the client API for MediaService service.
@@ -29,6 +47,24 @@ const (
 type MediaServiceClient interface {
 	GetPhotoUploadUrl(ctx context.Context, in *GetPhotoUploadUrlRequest, opts ...grpc.CallOption) (*GetPhotoUploadUrlResponse, error)
 	ListFilesByDateHour(ctx context.Context, in *ListFilesByDateHourRequest, opts ...grpc.CallOption) (*ListFilesByDateHourResponse, error)
+	SetPrivacyMasks(ctx context.Context, in *SetPrivacyMasksRequest, opts ...grpc.CallOption) (*SetPrivacyMasksResponse, error)
+	GetPrivacyMasks(ctx context.Context, in *GetPrivacyMasksRequest, opts ...grpc.CallOption) (*GetPrivacyMasksResponse, error)
+	SetWatermarkSettings(ctx context.Context, in *SetWatermarkSettingsRequest, opts ...grpc.CallOption) (*SetWatermarkSettingsResponse, error)
+	GetWatermarkSettings(ctx context.Context, in *GetWatermarkSettingsRequest, opts ...grpc.CallOption) (*GetWatermarkSettingsResponse, error)
+	ExportPhoto(ctx context.Context, in *ExportPhotoRequest, opts ...grpc.CallOption) (*ExportPhotoResponse, error)
+	VerifyPhotoIntegrity(ctx context.Context, in *VerifyPhotoIntegrityRequest, opts ...grpc.CallOption) (*VerifyPhotoIntegrityResponse, error)
+	WatchLatestPhotos(ctx context.Context, in *WatchLatestPhotosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchLatestPhotosResponse], error)
+	GetLiveViewUrl(ctx context.Context, in *GetLiveViewUrlRequest, opts ...grpc.CallOption) (*GetLiveViewUrlResponse, error)
+	RegisterDeviceKey(ctx context.Context, in *RegisterDeviceKeyRequest, opts ...grpc.CallOption) (*RegisterDeviceKeyResponse, error)
+	RevokeDeviceKey(ctx context.Context, in *RevokeDeviceKeyRequest, opts ...grpc.CallOption) (*RevokeDeviceKeyResponse, error)
+	ListDeviceKeys(ctx context.Context, in *ListDeviceKeysRequest, opts ...grpc.CallOption) (*ListDeviceKeysResponse, error)
+	CreateFirmwareRelease(ctx context.Context, in *CreateFirmwareReleaseRequest, opts ...grpc.CallOption) (*CreateFirmwareReleaseResponse, error)
+	PublishFirmwareRelease(ctx context.Context, in *PublishFirmwareReleaseRequest, opts ...grpc.CallOption) (*PublishFirmwareReleaseResponse, error)
+	ListFirmwareReleases(ctx context.Context, in *ListFirmwareReleasesRequest, opts ...grpc.CallOption) (*ListFirmwareReleasesResponse, error)
+	CreateFirmwareRollout(ctx context.Context, in *CreateFirmwareRolloutRequest, opts ...grpc.CallOption) (*CreateFirmwareRolloutResponse, error)
+	UpdateFirmwareRollout(ctx context.Context, in *UpdateFirmwareRolloutRequest, opts ...grpc.CallOption) (*UpdateFirmwareRolloutResponse, error)
+	GetFirmwareRollout(ctx context.Context, in *GetFirmwareRolloutRequest, opts ...grpc.CallOption) (*GetFirmwareRolloutResponse, error)
+	ListDeviceClocks(ctx context.Context, in *ListDeviceClocksRequest, opts ...grpc.CallOption) (*ListDeviceClocksResponse, error)
 }
 
 type mediaServiceClient struct {
@@ -59,12 +95,219 @@ func (c *mediaServiceClient) ListFilesByDateHour(ctx context.Context, in *ListFi
 	return out, nil
 }
 
+func (c *mediaServiceClient) SetPrivacyMasks(ctx context.Context, in *SetPrivacyMasksRequest, opts ...grpc.CallOption) (*SetPrivacyMasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPrivacyMasksResponse)
+	err := c.cc.Invoke(ctx, MediaService_SetPrivacyMasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetPrivacyMasks(ctx context.Context, in *GetPrivacyMasksRequest, opts ...grpc.CallOption) (*GetPrivacyMasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPrivacyMasksResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetPrivacyMasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) SetWatermarkSettings(ctx context.Context, in *SetWatermarkSettingsRequest, opts ...grpc.CallOption) (*SetWatermarkSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetWatermarkSettingsResponse)
+	err := c.cc.Invoke(ctx, MediaService_SetWatermarkSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetWatermarkSettings(ctx context.Context, in *GetWatermarkSettingsRequest, opts ...grpc.CallOption) (*GetWatermarkSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWatermarkSettingsResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetWatermarkSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ExportPhoto(ctx context.Context, in *ExportPhotoRequest, opts ...grpc.CallOption) (*ExportPhotoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportPhotoResponse)
+	err := c.cc.Invoke(ctx, MediaService_ExportPhoto_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) VerifyPhotoIntegrity(ctx context.Context, in *VerifyPhotoIntegrityRequest, opts ...grpc.CallOption) (*VerifyPhotoIntegrityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyPhotoIntegrityResponse)
+	err := c.cc.Invoke(ctx, MediaService_VerifyPhotoIntegrity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) WatchLatestPhotos(ctx context.Context, in *WatchLatestPhotosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchLatestPhotosResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaService_ServiceDesc.Streams[0], MediaService_WatchLatestPhotos_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchLatestPhotosRequest, WatchLatestPhotosResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_WatchLatestPhotosClient = grpc.ServerStreamingClient[WatchLatestPhotosResponse]
+
+func (c *mediaServiceClient) GetLiveViewUrl(ctx context.Context, in *GetLiveViewUrlRequest, opts ...grpc.CallOption) (*GetLiveViewUrlResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLiveViewUrlResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetLiveViewUrl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) RegisterDeviceKey(ctx context.Context, in *RegisterDeviceKeyRequest, opts ...grpc.CallOption) (*RegisterDeviceKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterDeviceKeyResponse)
+	err := c.cc.Invoke(ctx, MediaService_RegisterDeviceKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) RevokeDeviceKey(ctx context.Context, in *RevokeDeviceKeyRequest, opts ...grpc.CallOption) (*RevokeDeviceKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeDeviceKeyResponse)
+	err := c.cc.Invoke(ctx, MediaService_RevokeDeviceKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ListDeviceKeys(ctx context.Context, in *ListDeviceKeysRequest, opts ...grpc.CallOption) (*ListDeviceKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeviceKeysResponse)
+	err := c.cc.Invoke(ctx, MediaService_ListDeviceKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) CreateFirmwareRelease(ctx context.Context, in *CreateFirmwareReleaseRequest, opts ...grpc.CallOption) (*CreateFirmwareReleaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFirmwareReleaseResponse)
+	err := c.cc.Invoke(ctx, MediaService_CreateFirmwareRelease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) PublishFirmwareRelease(ctx context.Context, in *PublishFirmwareReleaseRequest, opts ...grpc.CallOption) (*PublishFirmwareReleaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishFirmwareReleaseResponse)
+	err := c.cc.Invoke(ctx, MediaService_PublishFirmwareRelease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ListFirmwareReleases(ctx context.Context, in *ListFirmwareReleasesRequest, opts ...grpc.CallOption) (*ListFirmwareReleasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFirmwareReleasesResponse)
+	err := c.cc.Invoke(ctx, MediaService_ListFirmwareReleases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) CreateFirmwareRollout(ctx context.Context, in *CreateFirmwareRolloutRequest, opts ...grpc.CallOption) (*CreateFirmwareRolloutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFirmwareRolloutResponse)
+	err := c.cc.Invoke(ctx, MediaService_CreateFirmwareRollout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) UpdateFirmwareRollout(ctx context.Context, in *UpdateFirmwareRolloutRequest, opts ...grpc.CallOption) (*UpdateFirmwareRolloutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateFirmwareRolloutResponse)
+	err := c.cc.Invoke(ctx, MediaService_UpdateFirmwareRollout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetFirmwareRollout(ctx context.Context, in *GetFirmwareRolloutRequest, opts ...grpc.CallOption) (*GetFirmwareRolloutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFirmwareRolloutResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetFirmwareRollout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ListDeviceClocks(ctx context.Context, in *ListDeviceClocksRequest, opts ...grpc.CallOption) (*ListDeviceClocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeviceClocksResponse)
+	err := c.cc.Invoke(ctx, MediaService_ListDeviceClocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MediaServiceServer is the server API for MediaService service.
 // All implementations must embed UnimplementedMediaServiceServer
 // for forward compatibility.
 type MediaServiceServer interface {
 	GetPhotoUploadUrl(context.Context, *GetPhotoUploadUrlRequest) (*GetPhotoUploadUrlResponse, error)
 	ListFilesByDateHour(context.Context, *ListFilesByDateHourRequest) (*ListFilesByDateHourResponse, error)
+	SetPrivacyMasks(context.Context, *SetPrivacyMasksRequest) (*SetPrivacyMasksResponse, error)
+	GetPrivacyMasks(context.Context, *GetPrivacyMasksRequest) (*GetPrivacyMasksResponse, error)
+	SetWatermarkSettings(context.Context, *SetWatermarkSettingsRequest) (*SetWatermarkSettingsResponse, error)
+	GetWatermarkSettings(context.Context, *GetWatermarkSettingsRequest) (*GetWatermarkSettingsResponse, error)
+	ExportPhoto(context.Context, *ExportPhotoRequest) (*ExportPhotoResponse, error)
+	VerifyPhotoIntegrity(context.Context, *VerifyPhotoIntegrityRequest) (*VerifyPhotoIntegrityResponse, error)
+	WatchLatestPhotos(*WatchLatestPhotosRequest, grpc.ServerStreamingServer[WatchLatestPhotosResponse]) error
+	GetLiveViewUrl(context.Context, *GetLiveViewUrlRequest) (*GetLiveViewUrlResponse, error)
+	RegisterDeviceKey(context.Context, *RegisterDeviceKeyRequest) (*RegisterDeviceKeyResponse, error)
+	RevokeDeviceKey(context.Context, *RevokeDeviceKeyRequest) (*RevokeDeviceKeyResponse, error)
+	ListDeviceKeys(context.Context, *ListDeviceKeysRequest) (*ListDeviceKeysResponse, error)
+	CreateFirmwareRelease(context.Context, *CreateFirmwareReleaseRequest) (*CreateFirmwareReleaseResponse, error)
+	PublishFirmwareRelease(context.Context, *PublishFirmwareReleaseRequest) (*PublishFirmwareReleaseResponse, error)
+	ListFirmwareReleases(context.Context, *ListFirmwareReleasesRequest) (*ListFirmwareReleasesResponse, error)
+	CreateFirmwareRollout(context.Context, *CreateFirmwareRolloutRequest) (*CreateFirmwareRolloutResponse, error)
+	UpdateFirmwareRollout(context.Context, *UpdateFirmwareRolloutRequest) (*UpdateFirmwareRolloutResponse, error)
+	GetFirmwareRollout(context.Context, *GetFirmwareRolloutRequest) (*GetFirmwareRolloutResponse, error)
+	ListDeviceClocks(context.Context, *ListDeviceClocksRequest) (*ListDeviceClocksResponse, error)
 	mustEmbedUnimplementedMediaServiceServer()
 }
 
@@ -81,6 +324,60 @@ func (UnimplementedMediaServiceServer) GetPhotoUploadUrl(context.Context, *GetPh
 func (UnimplementedMediaServiceServer) ListFilesByDateHour(context.Context, *ListFilesByDateHourRequest) (*ListFilesByDateHourResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListFilesByDateHour not implemented")
 }
+func (UnimplementedMediaServiceServer) SetPrivacyMasks(context.Context, *SetPrivacyMasksRequest) (*SetPrivacyMasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPrivacyMasks not implemented")
+}
+func (UnimplementedMediaServiceServer) GetPrivacyMasks(context.Context, *GetPrivacyMasksRequest) (*GetPrivacyMasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrivacyMasks not implemented")
+}
+func (UnimplementedMediaServiceServer) SetWatermarkSettings(context.Context, *SetWatermarkSettingsRequest) (*SetWatermarkSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWatermarkSettings not implemented")
+}
+func (UnimplementedMediaServiceServer) GetWatermarkSettings(context.Context, *GetWatermarkSettingsRequest) (*GetWatermarkSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWatermarkSettings not implemented")
+}
+func (UnimplementedMediaServiceServer) ExportPhoto(context.Context, *ExportPhotoRequest) (*ExportPhotoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportPhoto not implemented")
+}
+func (UnimplementedMediaServiceServer) VerifyPhotoIntegrity(context.Context, *VerifyPhotoIntegrityRequest) (*VerifyPhotoIntegrityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyPhotoIntegrity not implemented")
+}
+func (UnimplementedMediaServiceServer) WatchLatestPhotos(*WatchLatestPhotosRequest, grpc.ServerStreamingServer[WatchLatestPhotosResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLatestPhotos not implemented")
+}
+func (UnimplementedMediaServiceServer) GetLiveViewUrl(context.Context, *GetLiveViewUrlRequest) (*GetLiveViewUrlResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLiveViewUrl not implemented")
+}
+func (UnimplementedMediaServiceServer) RegisterDeviceKey(context.Context, *RegisterDeviceKeyRequest) (*RegisterDeviceKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterDeviceKey not implemented")
+}
+func (UnimplementedMediaServiceServer) RevokeDeviceKey(context.Context, *RevokeDeviceKeyRequest) (*RevokeDeviceKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeDeviceKey not implemented")
+}
+func (UnimplementedMediaServiceServer) ListDeviceKeys(context.Context, *ListDeviceKeysRequest) (*ListDeviceKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceKeys not implemented")
+}
+func (UnimplementedMediaServiceServer) CreateFirmwareRelease(context.Context, *CreateFirmwareReleaseRequest) (*CreateFirmwareReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFirmwareRelease not implemented")
+}
+func (UnimplementedMediaServiceServer) PublishFirmwareRelease(context.Context, *PublishFirmwareReleaseRequest) (*PublishFirmwareReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishFirmwareRelease not implemented")
+}
+func (UnimplementedMediaServiceServer) ListFirmwareReleases(context.Context, *ListFirmwareReleasesRequest) (*ListFirmwareReleasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFirmwareReleases not implemented")
+}
+func (UnimplementedMediaServiceServer) CreateFirmwareRollout(context.Context, *CreateFirmwareRolloutRequest) (*CreateFirmwareRolloutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFirmwareRollout not implemented")
+}
+func (UnimplementedMediaServiceServer) UpdateFirmwareRollout(context.Context, *UpdateFirmwareRolloutRequest) (*UpdateFirmwareRolloutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFirmwareRollout not implemented")
+}
+func (UnimplementedMediaServiceServer) GetFirmwareRollout(context.Context, *GetFirmwareRolloutRequest) (*GetFirmwareRolloutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFirmwareRollout not implemented")
+}
+func (UnimplementedMediaServiceServer) ListDeviceClocks(context.Context, *ListDeviceClocksRequest) (*ListDeviceClocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceClocks not implemented")
+}
 func (UnimplementedMediaServiceServer) mustEmbedUnimplementedMediaServiceServer() {}
 func (UnimplementedMediaServiceServer) testEmbeddedByValue()                      {}
 
@@ -138,6 +435,323 @@ func _MediaService_ListFilesByDateHour_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MediaService_SetPrivacyMasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPrivacyMasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).SetPrivacyMasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_SetPrivacyMasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).SetPrivacyMasks(ctx, req.(*SetPrivacyMasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetPrivacyMasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPrivacyMasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetPrivacyMasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetPrivacyMasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetPrivacyMasks(ctx, req.(*GetPrivacyMasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_SetWatermarkSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWatermarkSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).SetWatermarkSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_SetWatermarkSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).SetWatermarkSettings(ctx, req.(*SetWatermarkSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetWatermarkSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWatermarkSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetWatermarkSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetWatermarkSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetWatermarkSettings(ctx, req.(*GetWatermarkSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ExportPhoto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPhotoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ExportPhoto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ExportPhoto_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ExportPhoto(ctx, req.(*ExportPhotoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_VerifyPhotoIntegrity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPhotoIntegrityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).VerifyPhotoIntegrity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_VerifyPhotoIntegrity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).VerifyPhotoIntegrity(ctx, req.(*VerifyPhotoIntegrityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_WatchLatestPhotos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLatestPhotosRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MediaServiceServer).WatchLatestPhotos(m, &grpc.GenericServerStream[WatchLatestPhotosRequest, WatchLatestPhotosResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_WatchLatestPhotosServer = grpc.ServerStreamingServer[WatchLatestPhotosResponse]
+
+func _MediaService_GetLiveViewUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLiveViewUrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetLiveViewUrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetLiveViewUrl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetLiveViewUrl(ctx, req.(*GetLiveViewUrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_RegisterDeviceKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterDeviceKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).RegisterDeviceKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_RegisterDeviceKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).RegisterDeviceKey(ctx, req.(*RegisterDeviceKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_RevokeDeviceKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDeviceKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).RevokeDeviceKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_RevokeDeviceKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).RevokeDeviceKey(ctx, req.(*RevokeDeviceKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ListDeviceKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ListDeviceKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ListDeviceKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ListDeviceKeys(ctx, req.(*ListDeviceKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_CreateFirmwareRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFirmwareReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CreateFirmwareRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CreateFirmwareRelease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CreateFirmwareRelease(ctx, req.(*CreateFirmwareReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_PublishFirmwareRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishFirmwareReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).PublishFirmwareRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_PublishFirmwareRelease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).PublishFirmwareRelease(ctx, req.(*PublishFirmwareReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ListFirmwareReleases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFirmwareReleasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ListFirmwareReleases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ListFirmwareReleases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ListFirmwareReleases(ctx, req.(*ListFirmwareReleasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_CreateFirmwareRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFirmwareRolloutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CreateFirmwareRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CreateFirmwareRollout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CreateFirmwareRollout(ctx, req.(*CreateFirmwareRolloutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_UpdateFirmwareRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFirmwareRolloutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).UpdateFirmwareRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_UpdateFirmwareRollout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).UpdateFirmwareRollout(ctx, req.(*UpdateFirmwareRolloutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetFirmwareRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFirmwareRolloutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetFirmwareRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetFirmwareRollout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetFirmwareRollout(ctx, req.(*GetFirmwareRolloutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ListDeviceClocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceClocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ListDeviceClocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ListDeviceClocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ListDeviceClocks(ctx, req.(*ListDeviceClocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MediaService_ServiceDesc is the grpc.ServiceDesc for MediaService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +767,81 @@ var MediaService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListFilesByDateHour",
 			Handler:    _MediaService_ListFilesByDateHour_Handler,
 		},
+		{
+			MethodName: "SetPrivacyMasks",
+			Handler:    _MediaService_SetPrivacyMasks_Handler,
+		},
+		{
+			MethodName: "GetPrivacyMasks",
+			Handler:    _MediaService_GetPrivacyMasks_Handler,
+		},
+		{
+			MethodName: "SetWatermarkSettings",
+			Handler:    _MediaService_SetWatermarkSettings_Handler,
+		},
+		{
+			MethodName: "GetWatermarkSettings",
+			Handler:    _MediaService_GetWatermarkSettings_Handler,
+		},
+		{
+			MethodName: "ExportPhoto",
+			Handler:    _MediaService_ExportPhoto_Handler,
+		},
+		{
+			MethodName: "VerifyPhotoIntegrity",
+			Handler:    _MediaService_VerifyPhotoIntegrity_Handler,
+		},
+		{
+			MethodName: "GetLiveViewUrl",
+			Handler:    _MediaService_GetLiveViewUrl_Handler,
+		},
+		{
+			MethodName: "RegisterDeviceKey",
+			Handler:    _MediaService_RegisterDeviceKey_Handler,
+		},
+		{
+			MethodName: "RevokeDeviceKey",
+			Handler:    _MediaService_RevokeDeviceKey_Handler,
+		},
+		{
+			MethodName: "ListDeviceKeys",
+			Handler:    _MediaService_ListDeviceKeys_Handler,
+		},
+		{
+			MethodName: "CreateFirmwareRelease",
+			Handler:    _MediaService_CreateFirmwareRelease_Handler,
+		},
+		{
+			MethodName: "PublishFirmwareRelease",
+			Handler:    _MediaService_PublishFirmwareRelease_Handler,
+		},
+		{
+			MethodName: "ListFirmwareReleases",
+			Handler:    _MediaService_ListFirmwareReleases_Handler,
+		},
+		{
+			MethodName: "CreateFirmwareRollout",
+			Handler:    _MediaService_CreateFirmwareRollout_Handler,
+		},
+		{
+			MethodName: "UpdateFirmwareRollout",
+			Handler:    _MediaService_UpdateFirmwareRollout_Handler,
+		},
+		{
+			MethodName: "GetFirmwareRollout",
+			Handler:    _MediaService_GetFirmwareRollout_Handler,
+		},
+		{
+			MethodName: "ListDeviceClocks",
+			Handler:    _MediaService_ListDeviceClocks_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLatestPhotos",
+			Handler:       _MediaService_WatchLatestPhotos_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "media_service.proto",
 }
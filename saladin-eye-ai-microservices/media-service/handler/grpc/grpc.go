@@ -2,44 +2,111 @@ package grpc
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/andypmw/saladin-eye-ai/media-service/common/cache"
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
 	"github.com/andypmw/saladin-eye-ai/media-service/common/genproto"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/clock"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/devicekey"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/feed"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/firmware"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/idempotency"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/liveview"
 	"github.com/andypmw/saladin-eye-ai/media-service/service/photo"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/privacy"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/registry"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/watermark"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type MediaService struct {
 	genproto.UnimplementedMediaServiceServer
-	photoService photo.PhotoServiceIface
+	photoService       photo.PhotoServiceIface
+	privacyService     privacy.PrivacyServiceIface
+	watermarkService   watermark.WatermarkServiceIface
+	feedService        feed.FeedServiceIface
+	liveViewService    liveview.LiveViewServiceIface
+	idempotencyService idempotency.IdempotencyServiceIface
+	deviceKeyService   devicekey.DeviceKeyServiceIface
+	firmwareService    firmware.FirmwareServiceIface
+	clockService       clock.ClockServiceIface
+	registryService    registry.RegistryServiceIface
 }
 
 func New() *MediaService {
+	// Only while the devices are being registered, see DeviceInterceptor
+	allowUnregistered := os.Getenv("ALLOW_UNREGISTERED_DEVICES") == "true"
+	if allowUnregistered {
+		log.Warn().Msg("ALLOW_UNREGISTERED_DEVICES is set, devices not registered are accepted")
+	}
+
 	photoService, err := photo.New()
 	if err != nil {
 		log.Fatal().Msgf("failed to create photo service: %v", err)
 	}
 
+	firmwareService, err := firmware.New(cache.New())
+	if err != nil {
+		log.Fatal().Msgf("failed to create firmware service: %v", err)
+	}
+
 	return &MediaService{
-		photoService: photoService,
+		photoService:       photoService,
+		privacyService:     privacy.New(cache.New()),
+		watermarkService:   watermark.New(cache.New()),
+		feedService:        feed.New(cache.New()),
+		liveViewService:    liveview.New(),
+		idempotencyService: idempotency.New(cache.New()),
+		deviceKeyService:   devicekey.New(cache.New()),
+		firmwareService:    firmwareService,
+		clockService:       clock.New(cache.New()),
+		registryService:    registry.New(cache.New(), allowUnregistered),
 	}
 }
 
+/**
+ * A retried call with the same x-idempotency-key metadata gets the response
+ * of the first call, the same upload URL and photo path.
+ */
 func (handler MediaService) GetPhotoUploadUrl(ctx context.Context, req *genproto.GetPhotoUploadUrlRequest) (*genproto.GetPhotoUploadUrlResponse, error) {
 	deviceId := strings.TrimSpace(req.DeviceId)
-	idempotencyKey := ""
 
-	uploadURL, err := handler.photoService.GenerateUploadPresignedUrl(ctx, deviceId, idempotencyKey)
+	scope := fmt.Sprintf("grpc:get-photo-upload-url:%s", deviceId)
+	responseByteArr, _, err := handler.idempotencyService.Do(ctx, scope, idempotencyKey(ctx), func(ctx context.Context) ([]byte, error) {
+		uploadURL, photoPath, err := handler.photoService.GenerateUploadPresignedUrl(ctx, deviceId)
+		if err != nil {
+			return nil, err
+		}
+
+		return proto.Marshal(&genproto.GetPhotoUploadUrlResponse{
+			DeviceId:          deviceId,
+			UploadUrl:         uploadURL,
+			OriginalPhotoPath: req.OriginalPhotoPath,
+			PhotoPath:         photoPath,
+		})
+	})
 	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Internal, "failed to generate presigned photo upload URL: %v", err)
 	}
 
-	return &genproto.GetPhotoUploadUrlResponse{
-		DeviceId:  deviceId,
-		UploadUrl: uploadURL,
-	}, nil
+	response := &genproto.GetPhotoUploadUrlResponse{}
+	if err := proto.Unmarshal(responseByteArr, response); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal GetPhotoUploadUrlResponse: %v", err)
+	}
+
+	return response, nil
 }
 
 func (handler MediaService) ListFilesByDateHour(ctx context.Context, req *genproto.ListFilesByDateHourRequest) (*genproto.ListFilesByDateHourResponse, error) {
@@ -47,7 +114,11 @@ func (handler MediaService) ListFilesByDateHour(ctx context.Context, req *genpro
 	date := strings.TrimSpace(req.Date)
 	hour := req.Hour
 
-	result, err := handler.photoService.ListObjectsByDateHour(ctx, deviceId, date, hour)
+	if req.Unmasked && !hasPermission(ctx, constants.PERMISSION_VIEW_UNMASKED_MEDIA) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_VIEW_UNMASKED_MEDIA)
+	}
+
+	result, err := handler.photoService.ListObjectsByDateHour(ctx, deviceId, date, hour, req.Unmasked)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list files by date hour: %v", err)
 	}
@@ -55,8 +126,11 @@ func (handler MediaService) ListFilesByDateHour(ctx context.Context, req *genpro
 	files := make([]*genproto.FileInfo, 0)
 	for _, obj := range result {
 		files = append(files, &genproto.FileInfo{
-			FileName:    obj.Name,
-			DownloadUrl: obj.DownloadUrl,
+			FileName:     obj.Name,
+			DownloadUrl:  obj.DownloadUrl,
+			QualityFlags: obj.QualityFlags,
+			ThumbnailUrl: obj.ThumbnailUrl,
+			Masked:       obj.Masked,
 		})
 	}
 
@@ -65,3 +139,607 @@ func (handler MediaService) ListFilesByDateHour(ctx context.Context, req *genpro
 		Files:      files,
 	}, nil
 }
+
+func (handler MediaService) SetPrivacyMasks(ctx context.Context, req *genproto.SetPrivacyMasksRequest) (*genproto.SetPrivacyMasksResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_PRIVACY_MASKS) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_PRIVACY_MASKS)
+	}
+
+	polygons := make([]privacy.Polygon, 0)
+	for _, reqPolygon := range req.Polygons {
+		polygon := privacy.Polygon{
+			Points: make([]privacy.Point, 0),
+		}
+		for _, reqPoint := range reqPolygon.Points {
+			polygon.Points = append(polygon.Points, privacy.Point{
+				X: float64(reqPoint.X),
+				Y: float64(reqPoint.Y),
+			})
+		}
+		polygons = append(polygons, polygon)
+	}
+
+	mask, err := handler.privacyService.SetMask(ctx, deviceId, polygons)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to set privacy masks: %v", err)
+	}
+
+	// The renders of the previous mask are never served again. Purging them
+	// can take a while, the new mask is in force already.
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.DERIVED_PURGE_TIMEOUT_MINUTES*time.Minute)
+		defer cancel()
+
+		if err := handler.photoService.PurgeDerived(ctx, deviceId, mask.Version-1); err != nil {
+			log.Error().Msgf("failed to purge derived images of device_id %s: %v", deviceId, err)
+		}
+	}()
+
+	return &genproto.SetPrivacyMasksResponse{
+		DeviceId: deviceId,
+		Version:  mask.Version,
+	}, nil
+}
+
+func (handler MediaService) GetPrivacyMasks(ctx context.Context, req *genproto.GetPrivacyMasksRequest) (*genproto.GetPrivacyMasksResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	mask, err := handler.privacyService.GetMask(ctx, deviceId)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get privacy masks: %v", err)
+	}
+
+	polygons := make([]*genproto.PrivacyMaskPolygon, 0)
+	for _, polygon := range mask.Polygons {
+		points := make([]*genproto.PrivacyMaskPoint, 0)
+		for _, point := range polygon.Points {
+			points = append(points, &genproto.PrivacyMaskPoint{
+				X: float32(point.X),
+				Y: float32(point.Y),
+			})
+		}
+		polygons = append(polygons, &genproto.PrivacyMaskPolygon{
+			Points: points,
+		})
+	}
+
+	return &genproto.GetPrivacyMasksResponse{
+		DeviceId: deviceId,
+		Version:  mask.Version,
+		Polygons: polygons,
+	}, nil
+}
+
+func (handler MediaService) SetWatermarkSettings(ctx context.Context, req *genproto.SetWatermarkSettingsRequest) (*genproto.SetWatermarkSettingsResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_WATERMARK) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_WATERMARK)
+	}
+
+	if req.Settings == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "missing settings")
+	}
+
+	settings, err := handler.watermarkService.SetSettings(ctx, deviceId, watermark.Settings{
+		Enabled:    req.Settings.Enabled,
+		DeviceName: req.Settings.DeviceName,
+		Timezone:   req.Settings.Timezone,
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to set watermark settings: %v", err)
+	}
+
+	return &genproto.SetWatermarkSettingsResponse{
+		DeviceId: deviceId,
+		Settings: watermarkSettingsToProto(settings),
+	}, nil
+}
+
+func (handler MediaService) GetWatermarkSettings(ctx context.Context, req *genproto.GetWatermarkSettingsRequest) (*genproto.GetWatermarkSettingsResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	settings, err := handler.watermarkService.GetSettings(ctx, deviceId)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get watermark settings: %v", err)
+	}
+
+	return &genproto.GetWatermarkSettingsResponse{
+		DeviceId: deviceId,
+		Settings: watermarkSettingsToProto(settings),
+	}, nil
+}
+
+func (handler MediaService) ExportPhoto(ctx context.Context, req *genproto.ExportPhotoRequest) (*genproto.ExportPhotoResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+	date := strings.TrimSpace(req.Date)
+	fileName := strings.TrimSpace(req.FileName)
+
+	if req.Unmasked && !hasPermission(ctx, constants.PERMISSION_VIEW_UNMASKED_MEDIA) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_VIEW_UNMASKED_MEDIA)
+	}
+
+	exported, err := handler.photoService.ExportPhoto(ctx, deviceId, date, req.Hour, fileName, req.Unmasked)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to export photo: %v", err)
+	}
+
+	return &genproto.ExportPhotoResponse{
+		DeviceId:    deviceId,
+		FileName:    exported.Name,
+		DownloadUrl: exported.DownloadUrl,
+		Masked:      exported.Masked,
+		Watermarked: exported.Watermarked,
+	}, nil
+}
+
+func watermarkSettingsToProto(settings *watermark.Settings) *genproto.WatermarkSettings {
+	return &genproto.WatermarkSettings{
+		Enabled:    settings.Enabled,
+		DeviceName: settings.DeviceName,
+		Timezone:   settings.Timezone,
+		Version:    settings.Version,
+	}
+}
+
+func (handler MediaService) VerifyPhotoIntegrity(ctx context.Context, req *genproto.VerifyPhotoIntegrityRequest) (*genproto.VerifyPhotoIntegrityResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+	photoPath := strings.TrimSpace(req.PhotoPath)
+
+	if !hasPermission(ctx, constants.PERMISSION_VERIFY_INTEGRITY) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_VERIFY_INTEGRITY)
+	}
+
+	result, err := handler.photoService.VerifyIntegrity(ctx, deviceId, photoPath, req.StartIndex, int(req.Limit))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to verify photo integrity: %v", err)
+	}
+
+	failures := make([]*genproto.IntegrityFailure, 0)
+	for _, failure := range result.Failures {
+		failures = append(failures, &genproto.IntegrityFailure{
+			Index:     failure.Index,
+			PhotoPath: failure.PhotoPath,
+			Reason:    failure.Reason,
+		})
+	}
+
+	return &genproto.VerifyPhotoIntegrityResponse{
+		DeviceId:       deviceId,
+		Valid:          result.IsValid(),
+		CheckedEntries: result.CheckedEntries,
+		Failures:       failures,
+		NextIndex:      result.NextIndex,
+	}, nil
+}
+
+/**
+ * Hand out a short-lived URL of the MJPEG live view of the device, served by
+ * the HTTP server.
+ */
+func (handler MediaService) GetLiveViewUrl(ctx context.Context, req *genproto.GetLiveViewUrlRequest) (*genproto.GetLiveViewUrlResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	if req.Unmasked && !hasPermission(ctx, constants.PERMISSION_VIEW_UNMASKED_MEDIA) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_VIEW_UNMASKED_MEDIA)
+	}
+
+	liveViewURL, claims, err := handler.liveViewService.IssueUrl(deviceId, req.Unmasked, req.MaxFps)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to issue live view URL: %v", err)
+	}
+
+	return &genproto.GetLiveViewUrlResponse{
+		DeviceId:  deviceId,
+		Url:       liveViewURL,
+		ExpiresAt: claims.ExpiresAt,
+		MaxFps:    claims.MaxFps,
+	}, nil
+}
+
+/**
+ * Stream the photos of the devices as soon as they are confirmed, starting
+ * with the latest photo of every watched device.
+ *
+ * A watcher that reads slower than the devices upload skips photos, the
+ * number of skipped photos is in the next response.
+ */
+func (handler MediaService) WatchLatestPhotos(req *genproto.WatchLatestPhotosRequest, stream genproto.MediaService_WatchLatestPhotosServer) error {
+	ctx := stream.Context()
+
+	if req.Unmasked && !hasPermission(ctx, constants.PERMISSION_VIEW_UNMASKED_MEDIA) {
+		return status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_VIEW_UNMASKED_MEDIA)
+	}
+
+	deviceIds := make([]string, 0)
+	for _, deviceId := range req.DeviceIds {
+		deviceId = strings.TrimSpace(deviceId)
+		if len(deviceId) != 9 {
+			return status.Errorf(codes.InvalidArgument, "invalid device_id %s length %d", deviceId, len(deviceId))
+		}
+		deviceIds = append(deviceIds, deviceId)
+	}
+
+	log.Info().Msgf("WatchLatestPhotos started for devices %v", deviceIds)
+
+	// Subscribe before getting the latest photos, so nothing is missed in between
+	sub := handler.feedService.Subscribe(deviceIds)
+	defer handler.feedService.Unsubscribe(sub)
+
+	for _, deviceId := range deviceIds {
+		latest, err := handler.feedService.GetLatest(ctx, deviceId)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get latest photo: %v", err)
+		}
+
+		if latest != nil {
+			if err := handler.sendLatestPhoto(stream, latest, 0, req.Unmasked); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msgf("WatchLatestPhotos stopped for devices %v", deviceIds)
+			return nil
+		case event := <-sub.C:
+			if err := handler.sendLatestPhoto(stream, &event, sub.Dropped(), req.Unmasked); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (handler MediaService) sendLatestPhoto(stream genproto.MediaService_WatchLatestPhotosServer, event *feed.PhotoConfirmedEvent, skipped int64, unmasked bool) error {
+	// [Device ID]/[YYYY-MM-DD]/[HH]/[mm]-[ss].jpg
+	parts := strings.Split(event.PhotoPath, "/")
+	if len(parts) != 4 {
+		log.Error().Msgf("invalid photo path in photo confirmed event: %s", event.PhotoPath)
+		return nil
+	}
+	hour, _ := strconv.Atoi(parts[2])
+
+	obj, err := handler.photoService.GetObjectFile(stream.Context(), event.DeviceId, event.PhotoPath, event.QualityFlags, unmasked)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get photo: %v", err)
+	}
+
+	return stream.Send(&genproto.WatchLatestPhotosResponse{
+		DeviceId: event.DeviceId,
+		Date:     parts[1],
+		Hour:     int32(hour),
+		File: &genproto.FileInfo{
+			FileName:     obj.Name,
+			DownloadUrl:  obj.DownloadUrl,
+			QualityFlags: obj.QualityFlags,
+			ThumbnailUrl: obj.ThumbnailUrl,
+			Masked:       obj.Masked,
+		},
+		ConfirmedAt: event.ConfirmedAt,
+		Skipped:     skipped,
+	})
+}
+
+/**
+ * Register a key the device signs its MQTT requests with. For an HMAC key the
+ * secret is in the response, the only time it is returned.
+ */
+func (handler MediaService) RegisterDeviceKey(ctx context.Context, req *genproto.RegisterDeviceKeyRequest) (*genproto.RegisterDeviceKeyResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_DEVICE_KEYS) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_DEVICE_KEYS)
+	}
+
+	key, err := handler.deviceKeyService.RegisterKey(ctx, deviceId, strings.TrimSpace(req.Algorithm), req.PublicKey)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to register device key: %v", err)
+	}
+
+	response := &genproto.RegisterDeviceKeyResponse{
+		DeviceId: deviceId,
+		Key:      deviceKeyToProto(key),
+	}
+	if key.Algorithm == devicekey.ALGORITHM_HMAC_SHA256 {
+		response.Secret = key.Key
+	}
+
+	return response, nil
+}
+
+func (handler MediaService) RevokeDeviceKey(ctx context.Context, req *genproto.RevokeDeviceKeyRequest) (*genproto.RevokeDeviceKeyResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+	keyId := strings.TrimSpace(req.KeyId)
+
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_DEVICE_KEYS) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_DEVICE_KEYS)
+	}
+
+	if err := handler.deviceKeyService.RevokeKey(ctx, deviceId, keyId); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke device key: %v", err)
+	}
+
+	return &genproto.RevokeDeviceKeyResponse{
+		DeviceId: deviceId,
+		KeyId:    keyId,
+	}, nil
+}
+
+func (handler MediaService) ListDeviceKeys(ctx context.Context, req *genproto.ListDeviceKeysRequest) (*genproto.ListDeviceKeysResponse, error) {
+	deviceId := strings.TrimSpace(req.DeviceId)
+
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_DEVICE_KEYS) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_DEVICE_KEYS)
+	}
+
+	keys, err := handler.deviceKeyService.ListKeys(ctx, deviceId)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list device keys: %v", err)
+	}
+
+	response := &genproto.ListDeviceKeysResponse{
+		DeviceId: deviceId,
+		Keys:     make([]*genproto.DeviceKey, 0, len(keys)),
+	}
+	for _, key := range keys {
+		response.Keys = append(response.Keys, deviceKeyToProto(key))
+	}
+
+	return response, nil
+}
+
+// Never the HMAC secret, only the Ed25519 public key
+func deviceKeyToProto(key *devicekey.Key) *genproto.DeviceKey {
+	deviceKey := &genproto.DeviceKey{
+		KeyId:     key.KeyId,
+		Algorithm: key.Algorithm,
+		CreatedAt: key.CreatedAt,
+	}
+	if key.Algorithm == devicekey.ALGORITHM_ED25519 {
+		deviceKey.PublicKey = key.Key
+	}
+
+	return deviceKey
+}
+
+/**
+ * Create a firmware release. The image is PUT to the upload URL in the
+ * response, then the release is published once its checksum is verified.
+ */
+func (handler MediaService) CreateFirmwareRelease(ctx context.Context, req *genproto.CreateFirmwareReleaseRequest) (*genproto.CreateFirmwareReleaseResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_FIRMWARE) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_FIRMWARE)
+	}
+
+	release, uploadUrl, err := handler.firmwareService.CreateRelease(ctx, strings.TrimSpace(req.Version), strings.ToLower(strings.TrimSpace(req.Sha256)), req.Size, strings.TrimSpace(req.Notes))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create firmware release: %v", err)
+	}
+
+	return &genproto.CreateFirmwareReleaseResponse{
+		Release:   firmwareReleaseToProto(release),
+		UploadUrl: uploadUrl,
+	}, nil
+}
+
+func (handler MediaService) PublishFirmwareRelease(ctx context.Context, req *genproto.PublishFirmwareReleaseRequest) (*genproto.PublishFirmwareReleaseResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_FIRMWARE) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_FIRMWARE)
+	}
+
+	release, err := handler.firmwareService.PublishRelease(ctx, strings.TrimSpace(req.Version))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to publish firmware release: %v", err)
+	}
+
+	return &genproto.PublishFirmwareReleaseResponse{
+		Release: firmwareReleaseToProto(release),
+	}, nil
+}
+
+func (handler MediaService) ListFirmwareReleases(ctx context.Context, req *genproto.ListFirmwareReleasesRequest) (*genproto.ListFirmwareReleasesResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_FIRMWARE) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_FIRMWARE)
+	}
+
+	releases, err := handler.firmwareService.ListReleases(ctx)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list firmware releases: %v", err)
+	}
+
+	response := &genproto.ListFirmwareReleasesResponse{
+		Releases: make([]*genproto.FirmwareRelease, 0, len(releases)),
+	}
+	for _, release := range releases {
+		response.Releases = append(response.Releases, firmwareReleaseToProto(release))
+	}
+
+	return response, nil
+}
+
+func (handler MediaService) CreateFirmwareRollout(ctx context.Context, req *genproto.CreateFirmwareRolloutRequest) (*genproto.CreateFirmwareRolloutResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_FIRMWARE) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_FIRMWARE)
+	}
+
+	deviceIds := req.DeviceIds
+
+	owner := strings.TrimSpace(req.Owner)
+	location := strings.TrimSpace(req.Location)
+	tag := strings.TrimSpace(req.Tag)
+	if owner != "" || location != "" || tag != "" {
+		groupDeviceIds, err := handler.registryService.ListDeviceIds(ctx, owner, location, tag)
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return nil, err
+			}
+			return nil, status.Errorf(codes.Internal, "failed to list devices: %v", err)
+		}
+		if len(groupDeviceIds) == 0 {
+			return nil, status.Errorf(codes.NotFound, "no enabled device matches the owner, location and tag")
+		}
+
+		deviceIds = append(slices.Clone(deviceIds), groupDeviceIds...)
+	}
+
+	rollout, err := handler.firmwareService.CreateRollout(ctx, strings.TrimSpace(req.Version), deviceIds, req.Percentage, req.FailureThresholdPercent, req.MinFailures)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create firmware rollout: %v", err)
+	}
+
+	return &genproto.CreateFirmwareRolloutResponse{
+		Rollout: firmwareRolloutToProto(rollout),
+	}, nil
+}
+
+func (handler MediaService) UpdateFirmwareRollout(ctx context.Context, req *genproto.UpdateFirmwareRolloutRequest) (*genproto.UpdateFirmwareRolloutResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_FIRMWARE) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_FIRMWARE)
+	}
+
+	rollout, err := handler.firmwareService.UpdateRollout(ctx, strings.TrimSpace(req.RolloutId), req.Percentage, strings.TrimSpace(req.Status))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update firmware rollout: %v", err)
+	}
+
+	return &genproto.UpdateFirmwareRolloutResponse{
+		Rollout: firmwareRolloutToProto(rollout),
+	}, nil
+}
+
+func (handler MediaService) GetFirmwareRollout(ctx context.Context, req *genproto.GetFirmwareRolloutRequest) (*genproto.GetFirmwareRolloutResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_MANAGE_FIRMWARE) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_MANAGE_FIRMWARE)
+	}
+
+	rollout, devices, err := handler.firmwareService.GetRollout(ctx, strings.TrimSpace(req.RolloutId))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get firmware rollout: %v", err)
+	}
+
+	response := &genproto.GetFirmwareRolloutResponse{
+		Rollout: firmwareRolloutToProto(rollout),
+		Devices: make([]*genproto.FirmwareDeviceStatus, 0, len(devices)),
+	}
+	for _, device := range devices {
+		response.Devices = append(response.Devices, &genproto.FirmwareDeviceStatus{
+			DeviceId:        device.DeviceId,
+			Status:          device.Status,
+			ProgressPercent: device.ProgressPercent,
+			Message:         device.Message,
+			UpdatedAt:       device.UpdatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func firmwareReleaseToProto(release *firmware.Release) *genproto.FirmwareRelease {
+	return &genproto.FirmwareRelease{
+		Version:     release.Version,
+		Sha256:      release.Sha256,
+		Size:        release.Size,
+		Status:      release.Status,
+		Notes:       release.Notes,
+		CreatedAt:   release.CreatedAt,
+		PublishedAt: release.PublishedAt,
+	}
+}
+
+func firmwareRolloutToProto(rollout *firmware.Rollout) *genproto.FirmwareRollout {
+	return &genproto.FirmwareRollout{
+		RolloutId:               rollout.RolloutId,
+		Version:                 rollout.Version,
+		DeviceIds:               rollout.DeviceIds,
+		Percentage:              rollout.Percentage,
+		FailureThresholdPercent: rollout.FailureThresholdPercent,
+		MinFailures:             rollout.MinFailures,
+		Status:                  rollout.Status,
+		HaltReason:              rollout.HaltReason,
+		SucceededCount:          rollout.SucceededCount,
+		FailedCount:             rollout.FailedCount,
+		CreatedAt:               rollout.CreatedAt,
+		UpdatedAt:               rollout.UpdatedAt,
+	}
+}
+
+// The devices with a bad clock, as measured by the get-server-time MQTT method
+func (handler MediaService) ListDeviceClocks(ctx context.Context, req *genproto.ListDeviceClocksRequest) (*genproto.ListDeviceClocksResponse, error) {
+	if !hasPermission(ctx, constants.PERMISSION_VIEW_DEVICE_CLOCKS) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", constants.PERMISSION_VIEW_DEVICE_CLOCKS)
+	}
+
+	clocks, err := handler.clockService.List(ctx, req.MinSkewMs, req.Limit)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list device clocks: %v", err)
+	}
+
+	response := &genproto.ListDeviceClocksResponse{
+		Clocks: make([]*genproto.DeviceClock, 0, len(clocks)),
+	}
+	for _, deviceClock := range clocks {
+		response.Clocks = append(response.Clocks, &genproto.DeviceClock{
+			DeviceId:    deviceClock.DeviceId,
+			SkewMs:      deviceClock.SkewMs,
+			RoundTripMs: deviceClock.RoundTripMs,
+			MeasuredAt:  deviceClock.MeasuredAt,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	googleGrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/genproto"
+)
+
+// The keys of a disabled device must still be manageable, to revoke them
+var registeredOnlyMethods = map[string]bool{
+	genproto.MediaService_RegisterDeviceKey_FullMethodName: true,
+	genproto.MediaService_RevokeDeviceKey_FullMethodName:   true,
+	genproto.MediaService_ListDeviceKeys_FullMethodName:    true,
+}
+
+/**
+ * DeviceInterceptor rejects the calls for a device that is not registered in
+ * camera-service, or is disabled, before they reach their handler. Every
+ * request with a device_id is checked.
+ */
+func (handler MediaService) DeviceInterceptor(ctx context.Context, req any, info *googleGrpc.UnaryServerInfo, next googleGrpc.UnaryHandler) (any, error) {
+	request, ok := req.(interface{ GetDeviceId() string })
+	if !ok {
+		return next(ctx, req)
+	}
+
+	deviceId := strings.TrimSpace(request.GetDeviceId())
+	if len(deviceId) != 9 {
+		// The handler tells what is wrong with it
+		return next(ctx, req)
+	}
+
+	check := handler.registryService.Check
+	if registeredOnlyMethods[info.FullMethod] {
+		check = handler.registryService.CheckRegistered
+	}
+
+	if err := check(ctx, deviceId); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Unavailable, "failed to check device: %v", err)
+	}
+
+	return next(ctx, req)
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+func hasPermission(ctx context.Context, permission string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, value := range md.Get(constants.GRPC_METADATA_PERMISSIONS) {
+		for _, granted := range strings.Split(value, ",") {
+			if strings.TrimSpace(granted) == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func idempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(constants.GRPC_METADATA_IDEMPOTENCY_KEY)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(values[0])
+}
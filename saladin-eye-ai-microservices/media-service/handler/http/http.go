@@ -0,0 +1,207 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/cache"
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/feed"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/liveview"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/photo"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/registry"
+)
+
+const mjpegBoundary = "saladin-eye-frame"
+
+type LiveViewHandler struct {
+	photoService    photo.PhotoServiceIface
+	feedService     feed.FeedServiceIface
+	liveViewService liveview.LiveViewServiceIface
+	registryService registry.RegistryServiceIface
+}
+
+func New() *LiveViewHandler {
+	// Only while the devices are being registered, like the gRPC and MQTT handlers
+	allowUnregistered := os.Getenv("ALLOW_UNREGISTERED_DEVICES") == "true"
+	if allowUnregistered {
+		log.Warn().Msg("ALLOW_UNREGISTERED_DEVICES is set, devices not registered are accepted")
+	}
+
+	photoService, err := photo.New()
+	if err != nil {
+		log.Fatal().Msgf("failed to create photo service: %v", err)
+	}
+
+	return &LiveViewHandler{
+		photoService:    photoService,
+		feedService:     feed.New(cache.New()),
+		liveViewService: liveview.New(),
+		registryService: registry.New(cache.New(), allowUnregistered),
+	}
+}
+
+func (handler *LiveViewHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /live/{deviceId}", handler.serveLiveView)
+}
+
+/**
+ * Stream the photos of the device as they are confirmed, as an MJPEG stream
+ * (multipart/x-mixed-replace) that browsers play in a plain <img>.
+ *
+ * The URL comes from the GetLiveViewUrl RPC, its token is passed in the
+ * "token" query parameter or as a bearer token. The viewer can lower the frame
+ * rate of the token with the "fps" query parameter, never raise it. Photos
+ * confirmed faster than that are skipped, the newest one is sent.
+ *
+ * The stream ends when the token expires, the viewer asks for a new URL to
+ * go on watching. It also ends once the device is disabled or removed from
+ * the registry, checked every LIVE_VIEW_REGISTRY_CHECK_SECONDS.
+ */
+func (handler *LiveViewHandler) serveLiveView(w http.ResponseWriter, r *http.Request) {
+	deviceId := r.PathValue("deviceId")
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+
+	claims, err := handler.liveViewService.VerifyToken(token, time.Now())
+	if err != nil {
+		log.Warn().Msgf("live view of device_id %s refused: %v", deviceId, err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if claims.DeviceId != deviceId {
+		log.Warn().Msgf("live view of device_id %s refused: token is for device_id %s", deviceId, claims.DeviceId)
+		http.Error(w, "live view token is for another device", http.StatusForbidden)
+		return
+	}
+
+	if err := handler.registryService.Check(r.Context(), deviceId); err != nil {
+		if _, ok := status.FromError(err); !ok {
+			http.Error(w, "failed to check device", http.StatusInternalServerError)
+			return
+		}
+		log.Warn().Msgf("live view of device_id %s refused: %v", deviceId, err)
+		http.Error(w, "device is not registered or is disabled", http.StatusForbidden)
+		return
+	}
+
+	fps := claims.MaxFps
+	if requested := r.URL.Query().Get("fps"); requested != "" {
+		value, err := strconv.Atoi(requested)
+		if err != nil || value <= 0 {
+			http.Error(w, fmt.Sprintf("invalid fps: %s", requested), http.StatusBadRequest)
+			return
+		}
+		fps = min(fps, int32(value))
+	}
+	interval := time.Second / time.Duration(fps)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info().Msgf("live view of device_id %s started at %d fps", deviceId, fps)
+	defer log.Info().Msgf("live view of device_id %s stopped", deviceId)
+
+	// Subscribe before getting the latest photo, so nothing is missed in between
+	sub := handler.feedService.Subscribe([]string{deviceId})
+	defer handler.feedService.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary="+mjpegBoundary)
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx := r.Context()
+	var lastSent time.Time
+
+	latest, err := handler.feedService.GetLatest(ctx, deviceId)
+	if err != nil {
+		log.Error().Msgf("failed to get latest photo: %v", err)
+		return
+	}
+	if latest != nil {
+		if err := handler.writeFrame(ctx, w, flusher, latest, claims.Unmasked); err != nil {
+			return
+		}
+		lastSent = time.Now()
+	}
+
+	expired := time.NewTimer(time.Until(time.Unix(claims.ExpiresAt, 0)))
+	defer expired.Stop()
+
+	registryCheck := time.NewTicker(constants.LIVE_VIEW_REGISTRY_CHECK_SECONDS * time.Second)
+	defer registryCheck.Stop()
+
+	var pending *feed.PhotoConfirmedEvent
+	var wait <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-expired.C:
+			log.Info().Msgf("live view token of device_id %s expired", deviceId)
+			return
+		case <-registryCheck.C:
+			if err := handler.registryService.Check(ctx, deviceId); err != nil {
+				log.Warn().Msgf("live view of device_id %s ended: %v", deviceId, err)
+				return
+			}
+		case event := <-sub.C:
+			pending = &event
+			if wait == nil {
+				wait = time.After(time.Until(lastSent.Add(interval)))
+			}
+		case <-wait:
+			wait = nil
+			if pending == nil {
+				continue
+			}
+
+			if err := handler.writeFrame(ctx, w, flusher, pending, claims.Unmasked); err != nil {
+				return
+			}
+			pending = nil
+			lastSent = time.Now()
+		}
+	}
+}
+
+// A frame that can't be rendered is skipped, only a failed write to the
+// viewer ends the stream
+func (handler *LiveViewHandler) writeFrame(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, event *feed.PhotoConfirmedEvent, unmasked bool) error {
+	frame, err := handler.photoService.GetFrame(ctx, event.DeviceId, event.PhotoPath, unmasked)
+	if err != nil {
+		log.Error().Msgf("failed to get live view frame %s: %v", event.PhotoPath, err)
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, "--%s\r\nContent-Type: image/jpeg\r\nContent-Length: %d\r\n\r\n", mjpegBoundary, len(frame)); err != nil {
+		return err
+	}
+	if _, err := w.Write(frame); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte("\r\n")); err != nil {
+		return err
+	}
+	flusher.Flush()
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package mqtt
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/genproto"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/devicekey"
+)
+
+const signedRequestVersion = "SALADIN-EYE-REQUEST-V1"
+
+/**
+ * Authenticate the request as coming from the device in its topic, and
+ * return the payload of the method.
+ *
+ * Once a key of the device is registered its payloads must be a
+ * SignedRequest. A device without key is only accepted while
+ * MQTT_ALLOW_UNSIGNED_REQUESTS is set, for the devices not provisioned yet.
+ *
+ * The timestamp of a signed request is kept on the request, for the methods
+ * registered with RegisterClockSync it is the device clock to correct.
+ */
+func (router *Router) authenticate(ctx context.Context, req *Request, msg *Message) ([]byte, error) {
+	hasKeys, err := router.deviceKeyService.HasKeys(ctx, req.DeviceId)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to authenticate request: %v", err)
+	}
+
+	if !hasKeys {
+		if router.allowUnsigned {
+			log.Warn().Msgf("accepting unsigned %s request of device_id %s without key", req.Method, req.DeviceId)
+			return msg.Payload, nil
+		}
+
+		return nil, status.Errorf(codes.Unauthenticated, "no key registered for device_id %s", req.DeviceId)
+	}
+
+	signed := &genproto.SignedRequest{}
+	if err := proto.Unmarshal(msg.Payload, signed); err != nil || len(signed.Signature) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "request of device_id %s is not signed", req.DeviceId)
+	}
+
+	err = router.deviceKeyService.Authenticate(ctx, req.DeviceId, devicekey.SignedRequest{
+		KeyId:        signed.KeyId,
+		Timestamp:    signed.Timestamp,
+		Nonce:        signed.Nonce,
+		SignedData:   signedData(msg, signed),
+		Signature:    signed.Signature,
+		AnyTimestamp: router.routes[req.Method].anyTimestamp,
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Unavailable, "failed to authenticate request: %v", err)
+	}
+
+	req.SignedAt = signed.Timestamp
+
+	return signed.Payload, nil
+}
+
+// notAuthenticated hides why the request is rejected, a sender must not learn
+// whether a device is registered, disabled or has keys. Only a failure of the
+// server is told as it is, the device should retry.
+func notAuthenticated(err error) error {
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.NotFound, codes.PermissionDenied:
+		return status.Errorf(codes.Unauthenticated, "request not authenticated")
+	default:
+		return err
+	}
+}
+
+// The data the signature is over, see SignedRequest. The topic binds the
+// signature to the method, the device and the idempotency key.
+func signedData(msg *Message, signed *genproto.SignedRequest) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(signedRequestVersion + "\n")
+	buffer.WriteString(msg.Topic + "\n")
+	buffer.WriteString(msg.ResponseTopic + "\n")
+	buffer.WriteString(strconv.FormatInt(signed.Timestamp, 10) + "\n")
+	buffer.WriteString(hex.EncodeToString(signed.Nonce) + "\n")
+	buffer.Write(signed.Payload)
+
+	return buffer.Bytes()
+}
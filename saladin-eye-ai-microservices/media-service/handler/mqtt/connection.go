@@ -0,0 +1,71 @@
+package mqtt
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+// ConnectionState of the broker connection, for the health check
+type ConnectionState struct {
+	Connected  bool      `json:"connected"`
+	Since      time.Time `json:"since"`
+	LastError  string    `json:"last_error,omitempty"`
+	Reconnects int64     `json:"reconnects"`
+}
+
+type connectionTracker struct {
+	mu    sync.Mutex
+	state ConnectionState
+	// Connected at least once, the next connect is a reconnect
+	connectedBefore bool
+}
+
+func (tracker *connectionTracker) connected() {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	if tracker.connectedBefore {
+		tracker.state.Reconnects++
+		metricReconnects.Add(1)
+	}
+	tracker.connectedBefore = true
+	tracker.state.Connected = true
+	tracker.state.Since = time.Now()
+	metricConnected.Set(1)
+}
+
+func (tracker *connectionTracker) disconnected(err error) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	if tracker.state.Connected || tracker.state.Since.IsZero() {
+		tracker.state.Since = time.Now()
+	}
+	tracker.state.Connected = false
+	if err != nil {
+		tracker.state.LastError = err.Error()
+	}
+	metricConnected.Set(0)
+}
+
+func (tracker *connectionTracker) get() ConnectionState {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	return tracker.state
+}
+
+// Exponential, with up to 20% jitter so the instances don't all reconnect at
+// the same time after a broker restart
+func reconnectBackoff(attempt int) time.Duration {
+	delay := constants.MQTT_RECONNECT_MIN_SECONDS * time.Second
+	for i := 0; i < attempt && delay < constants.MQTT_RECONNECT_MAX_SECONDS*time.Second; i++ {
+		delay *= 2
+	}
+	delay = min(delay, constants.MQTT_RECONNECT_MAX_SECONDS*time.Second)
+
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
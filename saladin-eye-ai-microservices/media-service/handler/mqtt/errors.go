@@ -0,0 +1,59 @@
+package mqtt
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/media-service/common/genproto"
+)
+
+var errorResponseName = string((&genproto.ErrorResponse{}).ProtoReflect().Descriptor().Name())
+
+/**
+ * Build the ErrorResponse of a failed request. The services return gRPC status
+ * errors for what the device got wrong, those are not worth retrying as is.
+ * Any other error is on the server side, Redis or the object storage being
+ * down for example, and the device should retry after a while.
+ */
+func errorResponse(req *Request, err error) *genproto.ErrorResponse {
+	code := codes.Internal
+	message := err.Error()
+	var statusErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &statusErr) {
+		code = statusErr.GRPCStatus().Code()
+		message = statusErr.GRPCStatus().Message()
+	}
+
+	// The device only has room for a short message
+	if len(message) > constants.MQTT_ERROR_MESSAGE_MAX_LENGTH {
+		message = message[:constants.MQTT_ERROR_MESSAGE_MAX_LENGTH]
+		for !utf8.ValidString(message) {
+			message = message[:len(message)-1]
+		}
+	}
+
+	response := &genproto.ErrorResponse{
+		DeviceId:       req.DeviceId,
+		Method:         req.Method,
+		IdempotencyKey: req.IdempotencyKey,
+		Code:           uint32(code),
+		Message:        message,
+		ServerTimeMs:   time.Now().UnixMilli(),
+	}
+
+	switch code {
+	case codes.ResourceExhausted:
+		response.Retryable = true
+		response.RetryAfterSeconds = constants.MQTT_ERROR_RESOURCE_EXHAUSTED_RETRY_AFTER_SECONDS
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DeadlineExceeded, codes.Aborted:
+		response.Retryable = true
+		response.RetryAfterSeconds = constants.MQTT_ERROR_RETRY_AFTER_SECONDS
+	}
+
+	return response
+}
@@ -0,0 +1,185 @@
+package mqtt
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+// Message is a request received from a device. The properties are only set
+// by MQTT 5 clients, MQTT 3.1.1 requests only have the topic and the payload.
+type Message struct {
+	Topic      string
+	Payload    []byte
+	ReceivedAt time.Time
+
+	ResponseTopic   string
+	CorrelationData []byte
+	UserProperties  map[string]string
+	// Seconds the device waits for the response, 0 when it waits forever
+	ExpiryInterval uint32
+
+	// Acks the request to the broker, nil when the transport has nothing to
+	// ack. Until then the broker sends it again after a reconnect.
+	ack func()
+}
+
+// acknowledge the request once it is processed, or will never be
+func (msg *Message) acknowledge() {
+	if msg.ack != nil {
+		msg.ack()
+	}
+}
+
+// Reply is what is published back to the device. The MQTT 3.1.1 transport only
+// publishes the topic and the payload.
+type Reply struct {
+	Topic           string
+	Payload         []byte
+	CorrelationData []byte
+	UserProperties  map[string]string
+	ExpiryInterval  uint32
+}
+
+// Request is what the topic and the properties of an MQTT request tell about it
+type Request struct {
+	Method         string
+	DeviceId       string
+	IdempotencyKey string
+
+	// MQTT 5 only, where the device wants the response
+	ResponseTopic string
+
+	// When the request got to the server, before it waited in the queue
+	ReceivedAt time.Time
+
+	// Unix time in seconds by the device clock, from its SignedRequest, 0
+	// when the request is not signed
+	SignedAt int64
+}
+
+/**
+ * Parse the request from its topic, for MQTT 3.1.1 clients:
+ *
+ *	saladin-eye/server/media-service/request/[method-name]/[device-id]/[idempotency-key]
+ *
+ * It will be like URL path for REST API.
+ *
+ * MQTT 5 clients set the Response Topic property, and can leave the
+ * idempotency key out of the topic:
+ *
+ *	saladin-eye/server/media-service/request/[method-name]/[device-id]
+ *
+ * The key is then the idempotency-key user property, or the Correlation Data
+ * as hex. The response topic must be under the device's own topics.
+ */
+func ParseRequest(msg *Message) (*Request, error) {
+	if !strings.HasPrefix(msg.Topic, constants.MQTT_TOPIC_REQUEST_PREFIX+"/") {
+		return nil, fmt.Errorf("invalid topic format: %s", msg.Topic)
+	}
+
+	topicParts := strings.Split(strings.TrimPrefix(msg.Topic, constants.MQTT_TOPIC_REQUEST_PREFIX+"/"), "/")
+
+	req := &Request{ReceivedAt: msg.ReceivedAt}
+	switch {
+	case len(topicParts) == 3:
+		req.IdempotencyKey = topicParts[2]
+	case len(topicParts) == 2 && msg.ResponseTopic != "":
+		req.IdempotencyKey = msg.UserProperties[constants.MQTT_USER_PROPERTY_IDEMPOTENCY_KEY]
+		if req.IdempotencyKey == "" && len(msg.CorrelationData) > 0 {
+			req.IdempotencyKey = hex.EncodeToString(msg.CorrelationData)
+		}
+	default:
+		return nil, fmt.Errorf("invalid topic format: %s", msg.Topic)
+	}
+
+	req.Method = topicParts[0]
+	req.DeviceId = topicParts[1]
+
+	if len(req.DeviceId) != 9 {
+		return nil, fmt.Errorf("invalid device_id %s length %d", req.DeviceId, len(req.DeviceId))
+	}
+
+	if req.IdempotencyKey == "" {
+		return nil, fmt.Errorf("no idempotency key for request on topic: %s", msg.Topic)
+	}
+
+	if msg.ResponseTopic != "" {
+		devicePrefix := fmt.Sprintf(constants.MQTT_TOPIC_DEVICE_PREFIX_FORMAT, req.DeviceId)
+		if !strings.HasPrefix(msg.ResponseTopic, devicePrefix) || strings.ContainsAny(msg.ResponseTopic, "+#") {
+			return nil, fmt.Errorf("response topic %s is not a topic of device_id %s", msg.ResponseTopic, req.DeviceId)
+		}
+		req.ResponseTopic = msg.ResponseTopic
+	}
+
+	return req, nil
+}
+
+// Only protobuf payloads of the current protocol version are understood, a
+// request without the user properties is taken as one
+func validateUserProperties(msg *Message) error {
+	if contentType, ok := msg.UserProperties[constants.MQTT_USER_PROPERTY_CONTENT_TYPE]; ok && contentType != constants.MQTT_CONTENT_TYPE_PROTOBUF {
+		return status.Errorf(codes.InvalidArgument, "unsupported content type: %s", contentType)
+	}
+
+	if version, ok := msg.UserProperties[constants.MQTT_USER_PROPERTY_PROTOCOL_VERSION]; ok && version != constants.MQTT_PROTOCOL_VERSION {
+		return status.Errorf(codes.InvalidArgument, "unsupported protocol version: %s", version)
+	}
+
+	return nil
+}
+
+// ResponseTopic is where the response of the request is published
+func ResponseTopic(req *Request) string {
+	if req.ResponseTopic != "" {
+		return req.ResponseTopic
+	}
+
+	return fmt.Sprintf(constants.MQTT_TOPIC_RESPONSE_FORMAT, req.DeviceId, req.Method)
+}
+
+// ErrorTopic is where the ErrorResponse of the request is published. MQTT 5
+// clients get it on their response topic, told apart by the message-type
+// user property.
+func ErrorTopic(req *Request) string {
+	if req.ResponseTopic != "" {
+		return req.ResponseTopic
+	}
+
+	return ResponseTopic(req) + constants.MQTT_TOPIC_ERROR_SUFFIX
+}
+
+// The deadline of the request, when the device stops waiting for its response
+func expiresAt(msg *Message) (time.Time, bool) {
+	if msg.ExpiryInterval == 0 {
+		return time.Time{}, false
+	}
+
+	return msg.ReceivedAt.Add(time.Duration(msg.ExpiryInterval) * time.Second), true
+}
+
+func newReply(topic string, payload []byte, messageType string, msg *Message) *Reply {
+	reply := &Reply{
+		Topic:           topic,
+		Payload:         payload,
+		CorrelationData: msg.CorrelationData,
+		UserProperties: map[string]string{
+			constants.MQTT_USER_PROPERTY_CONTENT_TYPE:     constants.MQTT_CONTENT_TYPE_PROTOBUF,
+			constants.MQTT_USER_PROPERTY_PROTOCOL_VERSION: constants.MQTT_PROTOCOL_VERSION,
+			constants.MQTT_USER_PROPERTY_MESSAGE_TYPE:     messageType,
+		},
+	}
+
+	// The response is useless to the device once the request has expired
+	if deadline, ok := expiresAt(msg); ok {
+		reply.ExpiryInterval = uint32(max(1, time.Until(deadline).Round(time.Second)/time.Second))
+	}
+
+	return reply
+}
@@ -0,0 +1,37 @@
+package mqtt
+
+import "expvar"
+
+// Published under "mqtt-handler" by expvar, see /debug/vars of the metrics
+// server
+var (
+	metrics = expvar.NewMap("mqtt-handler")
+
+	metricReceived     = new(expvar.Int)
+	metricProcessed    = new(expvar.Int)
+	metricFailed       = new(expvar.Int)
+	metricRejectedFull = new(expvar.Int)
+	metricDroppedLate  = new(expvar.Int)
+	metricTimedOut     = new(expvar.Int)
+	metricQueueDepth   = new(expvar.Int)
+	metricBusyWorkers  = new(expvar.Int)
+	metricProcessingMs = new(expvar.Int)
+	metricQueueWaitMs  = new(expvar.Int)
+	metricConnected    = new(expvar.Int)
+	metricReconnects   = new(expvar.Int)
+)
+
+func init() {
+	metrics.Set("received", metricReceived)
+	metrics.Set("processed", metricProcessed)
+	metrics.Set("failed", metricFailed)
+	metrics.Set("rejected_queue_full", metricRejectedFull)
+	metrics.Set("dropped_late", metricDroppedLate)
+	metrics.Set("timed_out", metricTimedOut)
+	metrics.Set("queue_depth", metricQueueDepth)
+	metrics.Set("busy_workers", metricBusyWorkers)
+	metrics.Set("processing_ms_total", metricProcessingMs)
+	metrics.Set("queue_wait_ms_total", metricQueueWaitMs)
+	metrics.Set("connected", metricConnected)
+	metrics.Set("reconnects", metricReconnects)
+}
@@ -4,144 +4,375 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
+	"strconv"
+	"time"
 
+	"github.com/andypmw/saladin-eye-ai/media-service/common/cache"
 	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
 	"github.com/andypmw/saladin-eye-ai/media-service/common/genproto"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/clock"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/deviceconfig"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/devicekey"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/firmware"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/idempotency"
 	"github.com/andypmw/saladin-eye-ai/media-service/service/photo"
-	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/registry"
+	"github.com/andypmw/saladin-eye-ai/mqttconn"
 	"github.com/rs/zerolog/log"
-	"google.golang.org/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type MqttHandlerIface interface {
 	Start()
-	messageHandler(client mqtt.Client, msg mqtt.Message)
-	handleGetPhotoUploadUrl(deviceId, idempotencyKey string, requestByteArr []byte) (string, []byte, error)
+	Health() ConnectionState
+	messageHandler(msg *Message)
 }
 
 type MqttHandler struct {
-	clientId      string
-	brokerAddress string
-	username      string
-	password      string
-	client        mqtt.Client
-	photoService  photo.PhotoServiceIface
+	transport       transport
+	topicFilter     string
+	router          *Router
+	pool            *workerPool
+	rejections      chan *Reply
+	photoService    photo.PhotoServiceIface
+	firmwareService firmware.FirmwareServiceIface
+	clockService    clock.ClockServiceIface
+	configService   deviceconfig.DeviceConfigServiceIface
 }
 
 func New() MqttHandlerIface {
-	clientId := os.Getenv("MQTT_CLIENT_ID")
+	sharedGroup := os.Getenv("MQTT_SHARED_SUBSCRIPTION_GROUP")
 	broker := os.Getenv("MQTT_BROKER")
 	username := os.Getenv("MQTT_USERNAME")
 	password := os.Getenv("MQTT_PASSWORD")
+	protocolVersion := os.Getenv("MQTT_PROTOCOL_VERSION")
 
 	if broker == "" || username == "" || password == "" {
 		log.Fatal().Msg("MQTT_BROKER, MQTT_USERNAME, and MQTT_PASSWORD environment variables must be set")
 	}
 
+	workers := intFromEnv("MQTT_WORKERS", constants.MQTT_DEFAULT_WORKERS)
+	queueSize := intFromEnv("MQTT_QUEUE_SIZE", constants.MQTT_DEFAULT_QUEUE_SIZE)
+	requestTimeout := time.Duration(intFromEnv("MQTT_REQUEST_TIMEOUT_SECONDS", constants.MQTT_DEFAULT_REQUEST_TIMEOUT_SECONDS)) * time.Second
+	sessionExpiry := intFromEnv("MQTT_SESSION_EXPIRY_SECONDS", constants.MQTT_DEFAULT_SESSION_EXPIRY_SECONDS)
+
+	// Persistent by default, the broker keeps the requests for the handler
+	// while it reconnects or restarts
+	cleanSession := mqttconn.CleanSessionFromEnv()
+	clientId, err := mqttconn.ClientIdFromEnv("media-service-mqtt")
+	if err != nil {
+		log.Fatal().Msgf("invalid MQTT client id: %v", err)
+	}
+
+	// Only while rolling out the device keys, a device without key can then
+	// still send unsigned requests
+	allowUnsigned := os.Getenv("MQTT_ALLOW_UNSIGNED_REQUESTS") == "true"
+	if allowUnsigned {
+		log.Warn().Msg("MQTT_ALLOW_UNSIGNED_REQUESTS is set, devices without key are not authenticated")
+	}
+
+	// Only while the devices are being registered, an unknown device can
+	// then still send requests. A disabled device never can.
+	allowUnregistered := os.Getenv("ALLOW_UNREGISTERED_DEVICES") == "true"
+	if allowUnregistered {
+		log.Warn().Msg("ALLOW_UNREGISTERED_DEVICES is set, devices not registered are accepted")
+	}
+
+	tlsConfig, err := mqttconn.TLSConfigFromEnv()
+	if err != nil {
+		log.Fatal().Msgf("invalid MQTT TLS config: %v", err)
+	}
+
 	photoService, err := photo.New()
 	if err != nil {
 		log.Fatal().Msgf("failed to create photo service: %v", err)
 	}
 
-	return &MqttHandler{
-		clientId:      clientId,
-		brokerAddress: broker,
-		username:      username,
-		password:      password,
-		photoService:  photoService,
+	firmwareService, err := firmware.New(cache.New())
+	if err != nil {
+		log.Fatal().Msgf("failed to create firmware service: %v", err)
+	}
+
+	// The broker holds back the requests the workers and the queue have no
+	// room for, instead of the queue rejecting them
+	receiveMaximum := workers + queueSize
+	if receiveMaximum > 65535 {
+		receiveMaximum = 65535
+	}
+
+	opts := transportOptions{
+		clientId:       clientId,
+		brokerAddress:  broker,
+		username:       username,
+		password:       password,
+		cleanSession:   cleanSession,
+		sessionExpiry:  uint32(sessionExpiry),
+		receiveMaximum: uint16(receiveMaximum),
+		tlsConfig:      tlsConfig,
+	}
+
+	handler := &MqttHandler{
+		topicFilter:     constants.MQTT_TOPIC_SUBSCRIBE,
+		router:          NewRouter(idempotency.New(cache.New()), devicekey.New(cache.New()), registry.New(cache.New(), allowUnregistered), allowUnsigned),
+		rejections:      make(chan *Reply, queueSize),
+		photoService:    photoService,
+		firmwareService: firmwareService,
+		clockService:    clock.New(cache.New()),
+		configService:   deviceconfig.New(cache.New()),
+	}
+	handler.pool = newWorkerPool(workers, queueSize, requestTimeout, handler.processMessage)
+
+	if sharedGroup != "" {
+		handler.topicFilter = fmt.Sprintf(constants.MQTT_SHARED_SUBSCRIPTION_FORMAT, sharedGroup, constants.MQTT_TOPIC_SUBSCRIBE)
 	}
+	log.Info().Msgf("MQTT client id %s, subscribing to %s", clientId, handler.topicFilter)
+
+	switch protocolVersion {
+	case "", "3", "3.1.1", "4":
+		handler.transport = newTransportV3(opts)
+	case "5":
+		handler.transport = newTransportV5(opts)
+	default:
+		log.Fatal().Msgf("unsupported MQTT_PROTOCOL_VERSION %s, must be 3.1.1 or 5", protocolVersion)
+	}
+
+	// The MQTT methods, by the method name in the request topic
+	Register(handler.router, "get-photo-upload-url", handler.handleGetPhotoUploadUrl)
+	Register(handler.router, "confirm-photo-upload", handler.handleConfirmPhotoUpload)
+	Register(handler.router, "get-firmware-update", handler.handleGetFirmwareUpdate)
+	Register(handler.router, "report-firmware-update", handler.handleReportFirmwareUpdate)
+	Register(handler.router, "get-device-config", handler.handleGetDeviceConfig)
+	RegisterClockSync(handler.router, "get-server-time", handler.handleGetServerTime)
+
+	return handler
 }
 
-func (handler *MqttHandler) Start() {
-	opts := mqtt.NewClientOptions().AddBroker(handler.brokerAddress)
-	opts.SetClientID(handler.clientId)
-	opts.SetUsername(handler.username)
-	opts.SetPassword(handler.password)
-	opts.SetCleanSession(true)
+func intFromEnv(name string, defaultValue int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
 
-	handler.client = mqtt.NewClient(opts)
-	if token := handler.client.Connect(); token.Wait() && token.Error() != nil {
-		log.Fatal().Msgf("failed to connect to MQTT broker: %v", token.Error())
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Fatal().Msgf("invalid %s %s, must be a positive number", name, value)
 	}
 
-	if token := handler.client.Subscribe(constants.MQTT_TOPIC_SUBSCRIBE, 0, handler.messageHandler); token.Wait() && token.Error() != nil {
-		log.Fatal().Msgf("failed to subscribe to MQTT topic: %v", token.Error())
+	return parsed
+}
+
+func (handler *MqttHandler) Start() {
+	handler.pool.Start()
+	go handler.publishRejections()
+
+	// Only fails on an invalid config, the transport keeps reconnecting to
+	// the broker
+	if err := handler.transport.Connect(handler.topicFilter, handler.messageHandler); err != nil {
+		log.Fatal().Msgf("failed to start MQTT handler: %v", err)
 	}
 
 	// Block main thread so that the application continues running
 	select {}
 }
 
-// Sample topic name:
-//
-//	saladin-eye/server/media-service/request/[method-name]/[device-id]/[idempotency-key]
-//
-// We need to check the last part, to know what kind of request it is.
-// It will be like URL path for REST API.
-func (handler *MqttHandler) messageHandler(client mqtt.Client, msg mqtt.Message) {
-	log.Info().Msgf("received message on topic: %s", msg.Topic())
+// Health is the state of the broker connection
+func (handler *MqttHandler) Health() ConnectionState {
+	return handler.transport.State()
+}
 
-	topicParts := strings.Split(msg.Topic(), "/")
+// Called by the MQTT client, only queues the request so the client is never
+// blocked
+func (handler *MqttHandler) messageHandler(msg *Message) {
+	log.Info().Msgf("received message on topic: %s", msg.Topic)
+	metricReceived.Add(1)
 
-	if len(topicParts) != 7 {
-		log.Error().Msgf("Invalid topic format: %s", msg.Topic())
+	if handler.pool.Submit(msg) {
 		return
 	}
 
-	methodName := topicParts[len(topicParts)-3]
-	deviceId := topicParts[len(topicParts)-2]
-	idempotencyKey := topicParts[len(topicParts)-1]
-
-	log.Info().Msgf("method name %s deviceId %s idempotencyKey %s", methodName, deviceId, idempotencyKey)
+	metricRejectedFull.Add(1)
+	log.Warn().Msgf("request queue full, rejecting request on %s", msg.Topic)
 
-	switch methodName {
-	case "get-photo-upload-url":
-		responseTopic, responseByteArr, err := handler.handleGetPhotoUploadUrl(deviceId, idempotencyKey, msg.Payload())
-		if err != nil {
-			log.Error().Msgf("failed to handle GetPhotoUploadUrl: %v", err)
-			return
-		}
+	// The device is told to retry, the broker has nothing to send again
+	defer msg.acknowledge()
 
-		qos := byte(1)
-		if token := client.Publish(responseTopic, qos, false, responseByteArr); token.Wait() && token.Error() != nil {
-			log.Error().Msgf("failed to publish MQTT message: %v", token.Error())
-			return
-		}
+	reply, _ := handler.router.Reject(msg, status.Errorf(codes.ResourceExhausted, "server busy"))
+	if reply == nil {
+		return
+	}
 
-		log.Info().Msgf("published response to MQTT topic: %s", responseTopic)
+	select {
+	case handler.rejections <- reply:
 	default:
-		log.Error().Msgf("unknown method name: %s", methodName)
+		log.Warn().Msgf("rejection queue full, not answering request on %s", msg.Topic)
+	}
+}
+
+func (handler *MqttHandler) processMessage(ctx context.Context, msg *Message) {
+	// A failed request is still answered, with an ErrorResponse on the error
+	// topic, so the device does not wait for a response that never comes
+	reply, err := handler.router.Dispatch(ctx, msg)
+	if err != nil {
+		metricFailed.Add(1)
+	} else {
+		metricProcessed.Add(1)
+	}
+
+	if reply == nil {
 		return
 	}
+	if err != nil {
+		log.Warn().Msgf("answering request on %s with an error: %v", msg.Topic, err)
+	}
+
+	handler.publish(reply)
+}
+
+// The busy answers are published one at a time, apart from the workers
+func (handler *MqttHandler) publishRejections() {
+	for reply := range handler.rejections {
+		handler.publish(reply)
+	}
 }
 
-func (handler *MqttHandler) handleGetPhotoUploadUrl(deviceId, idempotencyKey string, requestByteArr []byte) (string, []byte, error) {
-	request := &genproto.GetPhotoUploadUrlRequest{}
-	if err := proto.Unmarshal(requestByteArr, request); err != nil {
-		log.Error().Msgf("failed to unmarshal protobuf GetPhotoUploadUrlRequest: %v", err)
-		return "", nil, fmt.Errorf("failed to unmarshal protobuf GetPhotoUploadUrlRequest: %w", err)
+func (handler *MqttHandler) publish(reply *Reply) {
+	// Even when the request has timed out, the device gets its answer
+	ctx, cancel := context.WithTimeout(context.Background(), constants.MQTT_PUBLISH_TIMEOUT_SECONDS*time.Second)
+	defer cancel()
+
+	if err := handler.transport.Publish(ctx, reply); err != nil {
+		log.Error().Msgf("failed to publish MQTT message: %v", err)
+		return
 	}
 
-	uploadURL, err := handler.photoService.GenerateUploadPresignedUrl(context.Background(), deviceId, idempotencyKey)
+	log.Info().Msgf("published response to MQTT topic: %s", reply.Topic)
+}
+
+func (handler *MqttHandler) handleGetPhotoUploadUrl(ctx context.Context, req *Request, request *genproto.GetPhotoUploadUrlRequest) (*genproto.GetPhotoUploadUrlResponse, error) {
+	uploadURL, photoPath, err := handler.photoService.GenerateUploadPresignedUrl(ctx, req.DeviceId)
 	if err != nil {
 		log.Error().Msgf("failed to generate upload presigned URL: %v", err)
-		return "", nil, fmt.Errorf("failed to generate upload presigned URL: %w", err)
+		return nil, fmt.Errorf("failed to generate upload presigned URL: %w", err)
 	}
 
-	response := &genproto.GetPhotoUploadUrlResponse{
-		DeviceId:          deviceId,
+	return &genproto.GetPhotoUploadUrlResponse{
+		DeviceId:          req.DeviceId,
 		UploadUrl:         uploadURL,
 		OriginalPhotoPath: request.OriginalPhotoPath,
+		PhotoPath:         photoPath,
+	}, nil
+}
+
+func (handler *MqttHandler) handleConfirmPhotoUpload(ctx context.Context, req *Request, request *genproto.ConfirmPhotoUploadRequest) (*genproto.ConfirmPhotoUploadResponse, error) {
+	qualityFlags, err := handler.photoService.ConfirmUpload(ctx, req.DeviceId, request.PhotoPath)
+	if err != nil {
+		log.Error().Msgf("failed to confirm photo upload: %v", err)
+		return nil, fmt.Errorf("failed to confirm photo upload: %w", err)
+	}
+
+	return &genproto.ConfirmPhotoUploadResponse{
+		DeviceId:     req.DeviceId,
+		PhotoPath:    request.PhotoPath,
+		QualityFlags: qualityFlags,
+	}, nil
+}
+
+func (handler *MqttHandler) handleGetFirmwareUpdate(ctx context.Context, req *Request, request *genproto.GetFirmwareUpdateRequest) (*genproto.GetFirmwareUpdateResponse, error) {
+	update, err := handler.firmwareService.GetUpdate(ctx, req.DeviceId, request.CurrentVersion)
+	if err != nil {
+		log.Error().Msgf("failed to get firmware update: %v", err)
+		return nil, fmt.Errorf("failed to get firmware update: %w", err)
+	}
+
+	if update == nil {
+		return &genproto.GetFirmwareUpdateResponse{
+			DeviceId: req.DeviceId,
+		}, nil
+	}
+
+	return &genproto.GetFirmwareUpdateResponse{
+		DeviceId:        req.DeviceId,
+		UpdateAvailable: true,
+		RolloutId:       update.RolloutId,
+		Version:         update.Version,
+		DownloadUrl:     update.DownloadUrl,
+		Sha256:          update.Sha256,
+		Size:            update.Size,
+	}, nil
+}
+
+func (handler *MqttHandler) handleReportFirmwareUpdate(ctx context.Context, req *Request, request *genproto.ReportFirmwareUpdateRequest) (*genproto.ReportFirmwareUpdateResponse, error) {
+	rollout, err := handler.firmwareService.Report(ctx, req.DeviceId, firmware.Report{
+		RolloutId:       request.RolloutId,
+		Version:         request.Version,
+		Status:          request.Status,
+		ProgressPercent: request.ProgressPercent,
+		Message:         request.Message,
+	})
+	if err != nil {
+		log.Error().Msgf("failed to report firmware update: %v", err)
+		return nil, fmt.Errorf("failed to report firmware update: %w", err)
 	}
 
-	responseByteArr, err := proto.Marshal(response)
+	return &genproto.ReportFirmwareUpdateResponse{
+		DeviceId:      req.DeviceId,
+		RolloutId:     rollout.RolloutId,
+		RolloutStatus: rollout.Status,
+	}, nil
+}
+
+// The configuration camera-service keeps for the device, the device also gets
+// it retained on its config topic
+func (handler *MqttHandler) handleGetDeviceConfig(ctx context.Context, req *Request, request *genproto.GetDeviceConfigRequest) (*genproto.GetDeviceConfigResponse, error) {
+	config, err := handler.configService.Get(ctx, req.DeviceId)
 	if err != nil {
-		log.Error().Msgf("failed to marshal GetPhotoUploadUrlResponse: %v", err)
-		return "", nil, fmt.Errorf("failed to marshal GetPhotoUploadUrlResponse: %w", err)
+		log.Error().Msgf("failed to get device config: %v", err)
+		return nil, fmt.Errorf("failed to get device config: %w", err)
 	}
 
-	responseTopic := fmt.Sprintf("saladin-eye/device/%s/response/media-service/%s", deviceId, "get-photo-upload-url")
+	return &genproto.GetDeviceConfigResponse{
+		DeviceId: req.DeviceId,
+		Config:   config,
+	}, nil
+}
+
+/**
+ * The server time, with the receive and send times the device takes the
+ * offset of its clock from. The skew of the device clock is recorded, as the
+ * device measured it at its previous sync, or else estimated from the one-way
+ * trip of this request. The request is accepted however far the device clock
+ * is off, the estimate of a signed one is from its signed timestamp, the one
+ * the other requests of the device are rejected for.
+ */
+func (handler *MqttHandler) handleGetServerTime(ctx context.Context, req *Request, request *genproto.GetServerTimeRequest) (*genproto.GetServerTimeResponse, error) {
+	receivedAt := req.ReceivedAt
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+
+	deviceClock := &clock.Clock{
+		DeviceId:   req.DeviceId,
+		SkewMs:     request.DeviceSendTimeMs - receivedAt.UnixMilli(),
+		MeasuredAt: receivedAt.Unix(),
+	}
+	if req.SignedAt != 0 {
+		deviceClock.SkewMs = req.SignedAt*1000 - receivedAt.UnixMilli()
+	}
+	if request.LastRoundTripMs > 0 {
+		deviceClock.SkewMs = -request.LastOffsetMs
+		deviceClock.RoundTripMs = request.LastRoundTripMs
+	}
+
+	// The device gets the time even when the skew can't be recorded
+	if err := handler.clockService.Record(ctx, deviceClock); err != nil {
+		log.Warn().Msgf("failed to record clock of device_id %s: %v", req.DeviceId, err)
+	}
 
-	return responseTopic, responseByteArr, nil
+	return &genproto.GetServerTimeResponse{
+		DeviceId:            req.DeviceId,
+		DeviceSendTimeMs:    request.DeviceSendTimeMs,
+		ServerReceiveTimeMs: receivedAt.UnixMilli(),
+		ServerSendTimeMs:    time.Now().UnixMilli(),
+	}, nil
 }
@@ -0,0 +1,236 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/service/devicekey"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/idempotency"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/registry"
+)
+
+// MethodFunc handles one MQTT method, with the request payload already
+// unmarshalled
+type MethodFunc[Req proto.Message, Resp proto.Message] func(ctx context.Context, req *Request, request Req) (Resp, error)
+
+type route struct {
+	requestName  string
+	responseName string
+	handle       func(ctx context.Context, req *Request, payload []byte) (proto.Message, error)
+	// The response is never replayed, a retry is handled again
+	uncached bool
+	// The signed request is accepted whatever its timestamp
+	anyTimestamp bool
+}
+
+/**
+ * Router dispatches the MQTT requests to their method, by the method name in
+ * the topic. Every method declares its request and response protobuf types
+ * when it is registered, the router takes care of the rest:
+ *
+ *	Register(router, "get-photo-upload-url", handler.handleGetPhotoUploadUrl)
+ *
+ * The idempotency key of the topic makes every method idempotent, a retried
+ * request gets the response of the first one again, but for the methods
+ * registered with RegisterUncached. The request is authenticated first, a
+ * method only gets requests of the device in the topic, and only of a
+ * registered and enabled device. The device gets the same error whether it
+ * is unknown, disabled or its request not authentic.
+ */
+type Router struct {
+	routes             map[string]route
+	idempotencyService idempotency.IdempotencyServiceIface
+	deviceKeyService   devicekey.DeviceKeyServiceIface
+	registryService    registry.RegistryServiceIface
+	allowUnsigned      bool
+}
+
+func NewRouter(idempotencyService idempotency.IdempotencyServiceIface, deviceKeyService devicekey.DeviceKeyServiceIface, registryService registry.RegistryServiceIface, allowUnsigned bool) *Router {
+	return &Router{
+		routes:             make(map[string]route),
+		idempotencyService: idempotencyService,
+		deviceKeyService:   deviceKeyService,
+		registryService:    registryService,
+		allowUnsigned:      allowUnsigned,
+	}
+}
+
+func Register[Req proto.Message, Resp proto.Message](router *Router, method string, handle MethodFunc[Req, Resp]) {
+	register(router, method, handle, false, false)
+}
+
+// RegisterUncached registers a method whose response goes stale, like the
+// server time, a retried request is handled again instead of getting the
+// response of the first one
+func RegisterUncached[Req proto.Message, Resp proto.Message](router *Router, method string, handle MethodFunc[Req, Resp]) {
+	register(router, method, handle, true, false)
+}
+
+// RegisterClockSync registers the method a device corrects its clock with.
+// It is uncached, and its signed requests are accepted however far the
+// device clock is off, or a drifted device could never correct it.
+func RegisterClockSync[Req proto.Message, Resp proto.Message](router *Router, method string, handle MethodFunc[Req, Resp]) {
+	register(router, method, handle, true, true)
+}
+
+func register[Req proto.Message, Resp proto.Message](router *Router, method string, handle MethodFunc[Req, Resp], uncached, anyTimestamp bool) {
+	if _, exists := router.routes[method]; exists {
+		log.Fatal().Msgf("MQTT method %s registered twice", method)
+	}
+
+	// A typed nil is enough to reach the message type
+	var zero Req
+	requestType := zero.ProtoReflect().Type()
+	requestName := string(requestType.Descriptor().Name())
+	var zeroResponse Resp
+	responseName := string(zeroResponse.ProtoReflect().Descriptor().Name())
+
+	router.routes[method] = route{
+		requestName:  requestName,
+		responseName: responseName,
+		handle: func(ctx context.Context, req *Request, payload []byte) (proto.Message, error) {
+			request := requestType.New().Interface().(Req)
+			if err := proto.Unmarshal(payload, request); err != nil {
+				log.Error().Msgf("failed to unmarshal protobuf %s: %v", requestName, err)
+				return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal protobuf %s: %v", requestName, err)
+			}
+
+			return handle(ctx, req, request)
+		},
+		uncached:     uncached,
+		anyTimestamp: anyTimestamp,
+	}
+}
+
+/**
+ * Dispatch the MQTT request to its method, and return the reply to publish
+ * back to the device.
+ *
+ * When the method fails, the reply is an ErrorResponse on the error topic,
+ * and the error is returned as well. Only a request that can't be parsed gets
+ * no reply, there is no device to answer to, and neither does an expired one.
+ *
+ * A duplicate delivery, to another instance or to this one again, is answered
+ * like a retry of the device: the idempotent response is replayed, or waited
+ * for while the first delivery still runs. A signed request delivered again
+ * is still authentic, its nonce is only used up for other messages. Nothing
+ * is recorded before the request is authenticated, so a forged copy can't
+ * take the place of the request of the device.
+ */
+func (router *Router) Dispatch(ctx context.Context, msg *Message) (*Reply, error) {
+	req, err := ParseRequest(msg)
+	if err != nil {
+		log.Error().Msgf("failed to parse request: %v", err)
+		return nil, err
+	}
+
+	log.Info().Msgf("method name %s deviceId %s idempotencyKey %s", req.Method, req.DeviceId, req.IdempotencyKey)
+
+	// Authenticated before the registry is looked at, and rejected with the
+	// same error whatever the reason, which is only logged. Only the device
+	// itself can tell whether it is registered and enabled.
+	payload, err := router.authenticate(ctx, req, msg)
+	if err != nil {
+		log.Warn().Msgf("rejecting %s request of device_id %s: %v", req.Method, req.DeviceId, err)
+		return router.errorReply(req, msg, notAuthenticated(err))
+	}
+
+	if err := router.registryService.Check(ctx, req.DeviceId); err != nil {
+		if _, ok := status.FromError(err); !ok {
+			err = status.Errorf(codes.Unavailable, "failed to check device: %v", err)
+		}
+		log.Warn().Msgf("rejecting %s request of device_id %s: %v", req.Method, req.DeviceId, err)
+		return router.errorReply(req, msg, notAuthenticated(err))
+	}
+
+	if deadline, ok := expiresAt(msg); ok {
+		if time.Now().After(deadline) {
+			log.Warn().Msgf("dropping expired %s request of device_id %s", req.Method, req.DeviceId)
+			return nil, status.Errorf(codes.DeadlineExceeded, "request expired")
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	route, ok := router.routes[req.Method]
+	if !ok {
+		log.Error().Msgf("unknown method name: %s", req.Method)
+		return router.errorReply(req, msg, status.Errorf(codes.Unimplemented, "unknown method name: %s", req.Method))
+	}
+
+	if err := validateUserProperties(msg); err != nil {
+		log.Error().Msgf("invalid %s request properties: %v", req.Method, err)
+		return router.errorReply(req, msg, err)
+	}
+
+	// Without shared subscription, or while the broker moves the subscription,
+	// every instance gets the request. Only the first runs the method, the
+	// others replay its response.
+	scope := fmt.Sprintf("mqtt:%s:%s", req.Method, req.DeviceId)
+
+	var responseByteArr []byte
+	var replayed bool
+	if route.uncached {
+		responseByteArr, err = router.handle(ctx, route, req, payload)
+	} else {
+		responseByteArr, replayed, err = router.idempotencyService.Do(ctx, scope, req.IdempotencyKey, func(ctx context.Context) ([]byte, error) {
+			return router.handle(ctx, route, req, payload)
+		})
+	}
+	if err != nil {
+		return router.errorReply(req, msg, err)
+	}
+
+	if replayed {
+		log.Info().Msgf("replayed %s response for idempotencyKey %s", req.Method, req.IdempotencyKey)
+	}
+
+	return newReply(ResponseTopic(req), responseByteArr, route.responseName, msg), nil
+}
+
+/**
+ * Answer the request with the error without processing it, nothing but the
+ * topic is looked at, so it's cheap enough for an overloaded server.
+ */
+func (router *Router) Reject(msg *Message, err error) (*Reply, error) {
+	req, parseErr := ParseRequest(msg)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	return router.errorReply(req, msg, err)
+}
+
+func (router *Router) errorReply(req *Request, msg *Message, err error) (*Reply, error) {
+	errorByteArr, marshalErr := proto.Marshal(errorResponse(req, err))
+	if marshalErr != nil {
+		log.Error().Msgf("failed to marshal ErrorResponse: %v", marshalErr)
+		return nil, err
+	}
+
+	return newReply(ErrorTopic(req), errorByteArr, errorResponseName, msg), err
+}
+
+func (router *Router) handle(ctx context.Context, route route, req *Request, payload []byte) ([]byte, error) {
+	response, err := route.handle(ctx, req, payload)
+	if err != nil {
+		log.Error().Msgf("failed to handle %s: %v", route.requestName, err)
+		return nil, fmt.Errorf("failed to handle %s: %w", route.requestName, err)
+	}
+
+	responseByteArr, err := proto.Marshal(response)
+	if err != nil {
+		log.Error().Msgf("failed to marshal %s: %v", route.responseName, err)
+		return nil, fmt.Errorf("failed to marshal %s: %w", route.responseName, err)
+	}
+
+	return responseByteArr, nil
+}
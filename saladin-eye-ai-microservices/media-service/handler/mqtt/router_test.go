@@ -0,0 +1,239 @@
+package mqtt
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/media-service/common/genproto"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/devicekey"
+)
+
+const testDeviceId = "ABCDEF123"
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// fakeRedis keeps the few commands the device key service runs in maps, the
+// other commands of the interface are left nil
+type fakeRedis struct {
+	redis.Cmdable
+	hashes  map[string]map[string]string
+	strings map[string]string
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{
+		hashes:  make(map[string]map[string]string),
+		strings: make(map[string]string),
+	}
+}
+
+func (rdb *fakeRedis) HLen(ctx context.Context, key string) *redis.IntCmd {
+	return redis.NewIntResult(int64(len(rdb.hashes[key])), nil)
+}
+
+func (rdb *fakeRedis) HGet(ctx context.Context, key, field string) *redis.StringCmd {
+	value, ok := rdb.hashes[key][field]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(value, nil)
+}
+
+func (rdb *fakeRedis) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	if _, ok := rdb.strings[key]; ok {
+		return redis.NewBoolResult(false, nil)
+	}
+	rdb.strings[key] = fmt.Sprint(value)
+	return redis.NewBoolResult(true, nil)
+}
+
+func (rdb *fakeRedis) Get(ctx context.Context, key string) *redis.StringCmd {
+	value, ok := rdb.strings[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(value, nil)
+}
+
+// fakeIdempotency stores the first response of every key, like the Redis one
+// once the first delivery is done
+type fakeIdempotency struct {
+	responses map[string][]byte
+}
+
+func (is *fakeIdempotency) Do(ctx context.Context, scope, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, bool, error) {
+	if response, ok := is.responses[scope+":"+key]; ok {
+		return response, true, nil
+	}
+
+	response, err := fn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	is.responses[scope+":"+key] = response
+
+	return response, false, nil
+}
+
+// fakeRegistry answers every check with err
+type fakeRegistry struct {
+	err error
+}
+
+func (rs *fakeRegistry) Check(ctx context.Context, deviceId string) error {
+	return rs.err
+}
+
+func (rs *fakeRegistry) CheckRegistered(ctx context.Context, deviceId string) error {
+	return rs.err
+}
+
+func (rs *fakeRegistry) ListDeviceIds(ctx context.Context, owner, location, tag string) ([]string, error) {
+	return nil, rs.err
+}
+
+// newTestRouter returns a router with the HMAC key "key-1" registered for
+// the test device, and the count of the calls to its get-server-time method
+func newTestRouter(t *testing.T) (*Router, *int) {
+	return newTestRouterWithRegistry(t, &fakeRegistry{})
+}
+
+func newTestRouterWithRegistry(t *testing.T, registryService *fakeRegistry) (*Router, *int) {
+	t.Helper()
+
+	keyJson, err := json.Marshal(devicekey.Key{
+		KeyId:     "key-1",
+		Algorithm: devicekey.ALGORITHM_HMAC_SHA256,
+		Key:       testSecret,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	rdb := newFakeRedis()
+	rdb.hashes["media-service:device-keys:"+testDeviceId] = map[string]string{"key-1": string(keyJson)}
+
+	router := NewRouter(&fakeIdempotency{responses: make(map[string][]byte)}, devicekey.New(rdb), registryService, false)
+
+	calls := 0
+	Register(router, "get-server-time", func(ctx context.Context, req *Request, request *genproto.GetServerTimeRequest) (*genproto.GetServerTimeResponse, error) {
+		calls++
+		return &genproto.GetServerTimeResponse{DeviceId: req.DeviceId, ServerReceiveTimeMs: time.Now().UnixNano()}, nil
+	})
+
+	return router, &calls
+}
+
+// signedMessage signs the payload for the topic with the test secret
+func signedMessage(t *testing.T, idempotencyKey string, nonce []byte, payload []byte) *Message {
+	t.Helper()
+
+	msg := &Message{
+		Topic:      fmt.Sprintf("%s/get-server-time/%s/%s", constants.MQTT_TOPIC_REQUEST_PREFIX, testDeviceId, idempotencyKey),
+		ReceivedAt: time.Now(),
+	}
+
+	signed := &genproto.SignedRequest{
+		KeyId:     "key-1",
+		Timestamp: time.Now().Unix(),
+		Nonce:     nonce,
+		Payload:   payload,
+	}
+	mac := hmac.New(sha256.New, testSecret)
+	mac.Write(signedData(msg, signed))
+	signed.Signature = mac.Sum(nil)
+
+	signedByteArr, err := proto.Marshal(signed)
+	if err != nil {
+		t.Fatalf("failed to marshal SignedRequest: %v", err)
+	}
+	msg.Payload = signedByteArr
+
+	return msg
+}
+
+func TestDispatchSignedRequestDeliveredTwice(t *testing.T) {
+	router, calls := newTestRouter(t)
+
+	payload, err := proto.Marshal(&genproto.GetServerTimeRequest{DeviceSendTimeMs: 1})
+	if err != nil {
+		t.Fatalf("failed to marshal GetServerTimeRequest: %v", err)
+	}
+	msg := signedMessage(t, "key-a", []byte("nonce-0001"), payload)
+
+	first, err := router.Dispatch(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("first delivery: %v", err)
+	}
+
+	// The broker delivers the very same message again
+	second, err := router.Dispatch(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("second delivery: %v", err)
+	}
+
+	if *calls != 1 {
+		t.Errorf("method called %d times, want 1", *calls)
+	}
+	if first.Topic != second.Topic || string(first.Payload) != string(second.Payload) {
+		t.Errorf("second delivery got %s %x, want the replay %s %x", second.Topic, second.Payload, first.Topic, first.Payload)
+	}
+}
+
+func TestDispatchRejectsReusedNonce(t *testing.T) {
+	router, calls := newTestRouter(t)
+
+	payload, err := proto.Marshal(&genproto.GetServerTimeRequest{DeviceSendTimeMs: 1})
+	if err != nil {
+		t.Fatalf("failed to marshal GetServerTimeRequest: %v", err)
+	}
+
+	if _, err := router.Dispatch(context.Background(), signedMessage(t, "key-a", []byte("nonce-0001"), payload)); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+
+	// Another request, signed with the nonce of the first one
+	_, err = router.Dispatch(context.Background(), signedMessage(t, "key-b", []byte("nonce-0001"), payload))
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("reused nonce got %v, want Unauthenticated", err)
+	}
+
+	if *calls != 1 {
+		t.Errorf("method called %d times, want 1", *calls)
+	}
+}
+
+func TestDispatchHidesWhyRequestIsRejected(t *testing.T) {
+	payload, err := proto.Marshal(&genproto.GetServerTimeRequest{DeviceSendTimeMs: 1})
+	if err != nil {
+		t.Fatalf("failed to marshal GetServerTimeRequest: %v", err)
+	}
+
+	disabledRouter, _ := newTestRouterWithRegistry(t, &fakeRegistry{err: status.Errorf(codes.PermissionDenied, "device_id %s is disabled", testDeviceId)})
+	_, disabledErr := disabledRouter.Dispatch(context.Background(), signedMessage(t, "key-a", []byte("nonce-0001"), payload))
+
+	// Not signed, the registry is never looked at
+	router, _ := newTestRouterWithRegistry(t, &fakeRegistry{err: status.Errorf(codes.NotFound, "device_id %s is not registered", testDeviceId)})
+	_, unsignedErr := router.Dispatch(context.Background(), &Message{
+		Topic:      fmt.Sprintf("%s/get-server-time/%s/key-a", constants.MQTT_TOPIC_REQUEST_PREFIX, testDeviceId),
+		Payload:    payload,
+		ReceivedAt: time.Now(),
+	})
+
+	for _, err := range []error{disabledErr, unsignedErr} {
+		if status.Code(err) != codes.Unauthenticated || status.Convert(err).Message() != "request not authenticated" {
+			t.Errorf("rejected request got %v, want Unauthenticated request not authenticated", err)
+		}
+	}
+}
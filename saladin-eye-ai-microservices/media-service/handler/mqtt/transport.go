@@ -0,0 +1,39 @@
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+)
+
+var errNotConnected = errors.New("not connected to MQTT broker")
+
+// transport is the connection to the MQTT broker, the handler speaks MQTT
+// 3.1.1 or MQTT 5 depending on MQTT_PROTOCOL_VERSION. MQTT 3.1.1 devices keep
+// working with an MQTT 5 connection, the broker translates.
+//
+// The transport keeps the connection up by itself, reconnecting with backoff
+// and subscribing again on every connect.
+type transport interface {
+	// Connect in the background and subscribe, the received requests are
+	// passed to onMessage, which must not block. A request is acked to the
+	// broker once it is processed.
+	Connect(topicFilter string, onMessage func(msg *Message)) error
+	Publish(ctx context.Context, reply *Reply) error
+	State() ConnectionState
+}
+
+type transportOptions struct {
+	clientId      string
+	brokerAddress string
+	username      string
+	password      string
+
+	// A persistent session keeps the QoS 1 requests queued by the broker
+	// while the handler is reconnecting
+	cleanSession  bool
+	sessionExpiry uint32
+	// MQTT 5 only, the requests the broker sends before they are acked
+	receiveMaximum uint16
+	tlsConfig      *tls.Config
+}
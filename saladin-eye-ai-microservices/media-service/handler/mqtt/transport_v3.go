@@ -0,0 +1,121 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/rs/zerolog/log"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+type transportV3 struct {
+	opts    transportOptions
+	client  mqtt.Client
+	tracker connectionTracker
+}
+
+func newTransportV3(opts transportOptions) transport {
+	return &transportV3{
+		opts: opts,
+	}
+}
+
+func (t *transportV3) Connect(topicFilter string, onMessage func(msg *Message)) error {
+	handler := func(client mqtt.Client, msg mqtt.Message) {
+		onMessage(&Message{
+			Topic:      msg.Topic(),
+			Payload:    msg.Payload(),
+			ReceivedAt: time.Now(),
+			ack:        msg.Ack,
+		})
+	}
+
+	opts := mqtt.NewClientOptions().AddBroker(t.opts.brokerAddress)
+	opts.SetClientID(t.opts.clientId)
+	opts.SetUsername(t.opts.username)
+	opts.SetPassword(t.opts.password)
+	opts.SetCleanSession(t.opts.cleanSession)
+	opts.SetKeepAlive(constants.MQTT_KEEP_ALIVE_SECONDS * time.Second)
+	opts.SetTLSConfig(t.opts.tlsConfig)
+
+	// Acked once processed, a request lost with the instance is sent again
+	opts.SetAutoAckDisabled(true)
+
+	// paho backs off exponentially up to the max interval, for the first
+	// connect and for every reconnect
+	opts.SetConnectRetry(true)
+	opts.SetConnectRetryInterval(constants.MQTT_RECONNECT_MIN_SECONDS * time.Second)
+	opts.SetAutoReconnect(true)
+	opts.SetMaxReconnectInterval(constants.MQTT_RECONNECT_MAX_SECONDS * time.Second)
+
+	// Also receives the requests the broker queued for the persistent session,
+	// which can arrive before the subscription is made again
+	opts.SetDefaultPublishHandler(handler)
+
+	// The broker may have dropped the session, subscribe on every connect
+	opts.SetOnConnectHandler(func(client mqtt.Client) {
+		log.Info().Msgf("connected to MQTT broker %s", t.opts.brokerAddress)
+		t.tracker.connected()
+
+		go t.subscribe(client, topicFilter, handler)
+	})
+	opts.SetConnectionLostHandler(func(client mqtt.Client, err error) {
+		log.Error().Msgf("MQTT connection lost: %v", err)
+		t.tracker.disconnected(err)
+	})
+	opts.SetReconnectingHandler(func(client mqtt.Client, opts *mqtt.ClientOptions) {
+		log.Warn().Msgf("reconnecting to MQTT broker %s", t.opts.brokerAddress)
+	})
+
+	t.tracker.disconnected(nil)
+	t.client = mqtt.NewClient(opts)
+
+	// With connect retry the token only completes once connected
+	token := t.client.Connect()
+	go func() {
+		if token.Wait() && token.Error() != nil {
+			log.Error().Msgf("failed to connect to MQTT broker: %v", token.Error())
+			t.tracker.disconnected(token.Error())
+		}
+	}()
+
+	return nil
+}
+
+// Retry until subscribed, or the connection is lost and the next connect
+// subscribes again
+func (t *transportV3) subscribe(client mqtt.Client, topicFilter string, handler mqtt.MessageHandler) {
+	for attempt := 0; client.IsConnectionOpen(); attempt++ {
+		token := client.Subscribe(topicFilter, constants.MQTT_SUBSCRIBE_QOS, handler)
+		if token.Wait() && token.Error() == nil {
+			log.Info().Msgf("subscribed to MQTT topic %s", topicFilter)
+			return
+		}
+
+		log.Error().Msgf("failed to subscribe to MQTT topic %s: %v", topicFilter, token.Error())
+		time.Sleep(reconnectBackoff(attempt))
+	}
+}
+
+func (t *transportV3) Publish(ctx context.Context, reply *Reply) error {
+	if !t.client.IsConnectionOpen() {
+		return errNotConnected
+	}
+
+	qos := byte(1)
+	token := t.client.Publish(reply.Topic, qos, false, reply.Payload)
+
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return fmt.Errorf("failed to publish MQTT message: %w", ctx.Err())
+	}
+}
+
+func (t *transportV3) State() ConnectionState {
+	return t.tracker.get()
+}
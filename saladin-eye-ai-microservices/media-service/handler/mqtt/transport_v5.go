@@ -0,0 +1,153 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/media-service/internal/mqtt5"
+)
+
+type transportV5 struct {
+	opts    transportOptions
+	tracker connectionTracker
+
+	mu     sync.RWMutex
+	client *mqtt5.Client
+}
+
+func newTransportV5(opts transportOptions) transport {
+	return &transportV5{
+		opts: opts,
+	}
+}
+
+func (t *transportV5) Connect(topicFilter string, onMessage func(msg *Message)) error {
+	handler := func(msg *mqtt5.Message) {
+		userProperties := make(map[string]string, len(msg.UserProperties))
+		for _, userProperty := range msg.UserProperties {
+			userProperties[userProperty.Key] = userProperty.Value
+		}
+
+		onMessage(&Message{
+			Topic:           msg.Topic,
+			Payload:         msg.Payload,
+			ReceivedAt:      time.Now(),
+			ResponseTopic:   msg.ResponseTopic,
+			CorrelationData: msg.CorrelationData,
+			UserProperties:  userProperties,
+			ExpiryInterval:  msg.MessageExpiry,
+			ack:             msg.Ack,
+		})
+	}
+
+	t.tracker.disconnected(nil)
+	go t.run(topicFilter, handler)
+
+	return nil
+}
+
+/**
+ * Keep the connection up, the client does not reconnect by itself. Every
+ * connect subscribes again, the broker may have dropped the session, and
+ * failed attempts back off exponentially.
+ */
+func (t *transportV5) run(topicFilter string, handler func(msg *mqtt5.Message)) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			delay := reconnectBackoff(attempt - 1)
+			log.Warn().Msgf("reconnecting to MQTT broker %s in %s", t.opts.brokerAddress, delay)
+			time.Sleep(delay)
+		}
+
+		client, err := t.connect(topicFilter, handler)
+		if err != nil {
+			log.Error().Msgf("failed to connect to MQTT broker: %v", err)
+			t.tracker.disconnected(err)
+			continue
+		}
+
+		log.Info().Msgf("connected to MQTT broker %s, session present %t", t.opts.brokerAddress, client.SessionPresent)
+		t.tracker.connected()
+		attempt = 0
+
+		<-client.Done()
+		log.Error().Msgf("MQTT connection lost: %v", client.Err())
+		t.tracker.disconnected(client.Err())
+
+		t.mu.Lock()
+		t.client = nil
+		t.mu.Unlock()
+	}
+}
+
+func (t *transportV5) connect(topicFilter string, handler func(msg *mqtt5.Message)) (*mqtt5.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.MQTT_CONNECT_TIMEOUT_SECONDS*time.Second)
+	defer cancel()
+
+	client, err := mqtt5.Connect(ctx, mqtt5.Options{
+		Server:         t.opts.brokerAddress,
+		ClientId:       t.opts.clientId,
+		Username:       t.opts.username,
+		Password:       t.opts.password,
+		KeepAlive:      constants.MQTT_KEEP_ALIVE_SECONDS * time.Second,
+		CleanStart:     t.opts.cleanSession,
+		SessionExpiry:  t.opts.sessionExpiry,
+		ReceiveMaximum: t.opts.receiveMaximum,
+		TLSConfig:      t.opts.tlsConfig,
+	}, handler)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Subscribe(ctx, topicFilter, constants.MQTT_SUBSCRIBE_QOS); err != nil {
+		client.Disconnect()
+		return nil, fmt.Errorf("failed to subscribe to MQTT topic: %w", err)
+	}
+
+	t.mu.Lock()
+	t.client = client
+	t.mu.Unlock()
+
+	return client, nil
+}
+
+func (t *transportV5) State() ConnectionState {
+	return t.tracker.get()
+}
+
+func (t *transportV5) Publish(ctx context.Context, reply *Reply) error {
+	// Sorted, so a replayed response is published the same way
+	keys := make([]string, 0, len(reply.UserProperties))
+	for key := range reply.UserProperties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	userProperties := make([]mqtt5.UserProperty, 0, len(keys))
+	for _, key := range keys {
+		userProperties = append(userProperties, mqtt5.UserProperty{Key: key, Value: reply.UserProperties[key]})
+	}
+
+	t.mu.RLock()
+	client := t.client
+	t.mu.RUnlock()
+	if client == nil {
+		return errNotConnected
+	}
+
+	return client.Publish(ctx, &mqtt5.Message{
+		Topic:           reply.Topic,
+		Payload:         reply.Payload,
+		QoS:             1,
+		ContentType:     constants.MQTT_CONTENT_TYPE_PROTOBUF,
+		CorrelationData: reply.CorrelationData,
+		UserProperties:  userProperties,
+		MessageExpiry:   reply.ExpiryInterval,
+	})
+}
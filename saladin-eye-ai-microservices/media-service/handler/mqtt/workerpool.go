@@ -0,0 +1,89 @@
+package mqtt
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+/**
+ * The requests are queued by the MQTT client callback and processed by a fixed
+ * number of workers, so a slow Redis or object storage does not stall the
+ * MQTT client, and the load on them stays bounded.
+ *
+ * A request gets the timeout from when it was received, the time it waited in
+ * the queue included. A request that waited longer than that is dropped, the
+ * device has given up on it.
+ *
+ * A request is acked to the broker once processed or dropped, not when it is
+ * queued, a request lost with the instance is sent again by the broker.
+ */
+type workerPool struct {
+	jobs    chan *Message
+	workers int
+	timeout time.Duration
+	process func(ctx context.Context, msg *Message)
+}
+
+func newWorkerPool(workers, queueSize int, timeout time.Duration, process func(ctx context.Context, msg *Message)) *workerPool {
+	return &workerPool{
+		jobs:    make(chan *Message, queueSize),
+		workers: workers,
+		timeout: timeout,
+		process: process,
+	}
+}
+
+func (pool *workerPool) Start() {
+	for i := 0; i < pool.workers; i++ {
+		go pool.work()
+	}
+}
+
+// Submit queues the request, false when the queue is full
+func (pool *workerPool) Submit(msg *Message) bool {
+	select {
+	case pool.jobs <- msg:
+		metricQueueDepth.Add(1)
+		return true
+	default:
+		return false
+	}
+}
+
+func (pool *workerPool) work() {
+	for msg := range pool.jobs {
+		metricQueueDepth.Add(-1)
+
+		wait := time.Since(msg.ReceivedAt)
+		metricQueueWaitMs.Add(wait.Milliseconds())
+
+		if wait >= pool.timeout {
+			metricDroppedLate.Add(1)
+			log.Warn().Msgf("dropping request on %s, waited %v in the queue", msg.Topic, wait)
+			msg.acknowledge()
+			continue
+		}
+
+		pool.run(msg)
+		msg.acknowledge()
+	}
+}
+
+func (pool *workerPool) run(msg *Message) {
+	metricBusyWorkers.Add(1)
+	defer metricBusyWorkers.Add(-1)
+
+	ctx, cancel := context.WithDeadline(context.Background(), msg.ReceivedAt.Add(pool.timeout))
+	defer cancel()
+
+	start := time.Now()
+	pool.process(ctx, msg)
+	metricProcessingMs.Add(time.Since(start).Milliseconds())
+
+	if ctx.Err() == context.DeadlineExceeded {
+		metricTimedOut.Add(1)
+		log.Warn().Msgf("request on %s timed out after %v", msg.Topic, time.Since(msg.ReceivedAt))
+	}
+}
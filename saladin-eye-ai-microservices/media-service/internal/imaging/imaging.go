@@ -0,0 +1,86 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+)
+
+const JpegQuality = 85
+
+func DecodeJpeg(data []byte) (image.Image, error) {
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JPEG: %w", err)
+	}
+
+	return img, nil
+}
+
+func EncodeJpeg(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: JpegQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode JPEG: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ToRGBA returns a mutable copy of the image, with the origin at (0, 0).
+func ToRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+
+	return rgba
+}
+
+// Resize scales the image down so its longest side is at most maxDimension,
+// averaging the source pixels covered by each destination pixel.
+// Images that are already small enough are returned as they are.
+func Resize(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	if srcWidth <= maxDimension && srcHeight <= maxDimension {
+		return img
+	}
+
+	dstWidth, dstHeight := maxDimension, srcHeight*maxDimension/srcWidth
+	if srcHeight > srcWidth {
+		dstWidth, dstHeight = srcWidth*maxDimension/srcHeight, maxDimension
+	}
+	dstWidth = max(dstWidth, 1)
+	dstHeight = max(dstHeight, 1)
+
+	src := ToRGBA(img)
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := y*srcHeight/dstHeight, max((y+1)*srcHeight/dstHeight, y*srcHeight/dstHeight+1)
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := x*srcWidth/dstWidth, max((x+1)*srcWidth/dstWidth, x*srcWidth/dstWidth+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					i := src.PixOffset(sx, sy)
+					r += int(src.Pix[i])
+					g += int(src.Pix[i+1])
+					b += int(src.Pix[i+2])
+					a += int(src.Pix[i+3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,600 @@
+package mqtt5
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+/**
+ * A small MQTT 5 client, only what the services need: QoS 0 and 1, the
+ * request/response properties, user properties and message expiry.
+ *
+ * Messages are received up to QoS 1, QoS 2 subscriptions are not supported.
+ * The client does not reconnect, Done is closed when the connection is lost
+ * and a new client has to be connected.
+ *
+ * The limits the broker sets in its CONNACK are kept: no more QoS 1 messages
+ * in flight than its Receive Maximum, none bigger than its Maximum Packet
+ * Size.
+ */
+type Options struct {
+	// tcp:// or mqtt:// for plain connections, ssl://, tls:// or mqtts:// for TLS
+	Server         string
+	ClientId       string
+	Username       string
+	Password       string
+	KeepAlive      time.Duration
+	ConnectTimeout time.Duration
+	CleanStart     bool
+	// Seconds the broker keeps the session after the connection is lost
+	SessionExpiry uint32
+	// QoS 1 messages the broker sends before waiting for their acks, 0 for
+	// the MQTT default of 65535
+	ReceiveMaximum uint16
+	TLSConfig      *tls.Config
+}
+
+type Message struct {
+	Topic           string
+	Payload         []byte
+	QoS             byte
+	Retain          bool
+	ContentType     string
+	ResponseTopic   string
+	CorrelationData []byte
+	UserProperties  []UserProperty
+	// Seconds until the message expires, 0 when it does not
+	MessageExpiry uint32
+
+	// Set on a received QoS 1 message, to ack it
+	client   *Client
+	packetId uint16
+	acked    bool
+}
+
+/**
+ * Ack the received message once it is processed. A QoS 1 message not acked
+ * is sent again by the broker after a reconnect, and counts against the
+ * ReceiveMaximum until then.
+ *
+ * MQTT 5 wants the PUBACKs in the order the messages were received, an ack is
+ * held back until the messages before it are acked too.
+ */
+func (msg *Message) Ack() {
+	if msg.client != nil {
+		msg.client.ack(msg)
+	}
+}
+
+type ack struct {
+	reasonCodes  []byte
+	reasonString string
+}
+
+// A packet id waiting for its ack, a QoS 1 message holds one of the send quota
+// until then
+type pending struct {
+	acked chan ack
+	quota bool
+}
+
+type Client struct {
+	opts      Options
+	conn      net.Conn
+	onMessage func(*Message)
+
+	// Set from the CONNACK
+	SessionPresent bool
+	ClientId       string
+	sendQuota      chan struct{}
+	maxPacketSize  uint32
+
+	writeMu sync.Mutex
+
+	mu       sync.Mutex
+	nextId   uint16
+	inflight map[uint16]*pending
+
+	// The received QoS 1 messages not acked yet, in the order received
+	ackMu   sync.Mutex
+	unacked []*Message
+
+	pingMu      sync.Mutex
+	pingPending bool
+
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+/**
+ * Connect to the broker. The received messages are passed to onMessage one at
+ * a time, by the goroutine reading the connection, so onMessage must not
+ * block: it hands the message over, and the message is acked with Ack once
+ * processed.
+ */
+func Connect(ctx context.Context, opts Options, onMessage func(*Message)) (*Client, error) {
+	if opts.ConnectTimeout == 0 {
+		opts.ConnectTimeout = 30 * time.Second
+	}
+
+	conn, err := dial(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		opts:      opts,
+		conn:      conn,
+		onMessage: onMessage,
+		ClientId:  opts.ClientId,
+		inflight:  make(map[uint16]*pending),
+		done:      make(chan struct{}),
+	}
+
+	reader := bufio.NewReader(conn)
+	if err := client.handshake(reader); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	go client.readLoop(reader)
+	if opts.KeepAlive > 0 {
+		go client.keepAlive()
+	}
+
+	return client, nil
+}
+
+func dial(ctx context.Context, opts Options) (net.Conn, error) {
+	serverURL, err := url.Parse(opts.Server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MQTT server %s: %w", opts.Server, err)
+	}
+
+	dialer := &net.Dialer{Timeout: opts.ConnectTimeout}
+
+	switch serverURL.Scheme {
+	case "tcp", "mqtt":
+		host := serverURL.Host
+		if serverURL.Port() == "" {
+			host = net.JoinHostPort(serverURL.Hostname(), "1883")
+		}
+		return dialer.DialContext(ctx, "tcp", host)
+	case "ssl", "tls", "mqtts":
+		host := serverURL.Host
+		if serverURL.Port() == "" {
+			host = net.JoinHostPort(serverURL.Hostname(), "8883")
+		}
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: opts.TLSConfig}
+		return tlsDialer.DialContext(ctx, "tcp", host)
+	default:
+		return nil, fmt.Errorf("unsupported MQTT server scheme: %s", serverURL.Scheme)
+	}
+}
+
+func (client *Client) handshake(reader *bufio.Reader) error {
+	flags := byte(0)
+	if client.opts.CleanStart {
+		flags |= 0x02
+	}
+	if client.opts.Username != "" {
+		flags |= 0x80
+	}
+	if client.opts.Password != "" {
+		flags |= 0x40
+	}
+
+	e := &encoder{}
+	e.string("MQTT")
+	e.byte(5)
+	e.byte(flags)
+	e.uint16(uint16(client.opts.KeepAlive / time.Second))
+	e.properties(&properties{sessionExpiry: client.opts.SessionExpiry, receiveMaximum: client.opts.ReceiveMaximum})
+	e.string(client.opts.ClientId)
+	if client.opts.Username != "" {
+		e.string(client.opts.Username)
+	}
+	if client.opts.Password != "" {
+		e.string(client.opts.Password)
+	}
+
+	client.conn.SetDeadline(time.Now().Add(client.opts.ConnectTimeout))
+	defer client.conn.SetDeadline(time.Time{})
+
+	if _, err := client.conn.Write(e.packet(packetConnect, 0)); err != nil {
+		return fmt.Errorf("failed to send MQTT CONNECT: %w", err)
+	}
+
+	packetType, _, body, err := readPacket(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read MQTT CONNACK: %w", err)
+	}
+	if packetType != packetConnack {
+		return fmt.Errorf("expected MQTT CONNACK, got packet type %d", packetType)
+	}
+
+	d := &decoder{data: body}
+	ackFlags := d.byte()
+	reasonCode := d.byte()
+	props := d.properties()
+	if d.err != nil {
+		return fmt.Errorf("failed to decode MQTT CONNACK: %w", d.err)
+	}
+
+	if reasonCode >= 0x80 {
+		return fmt.Errorf("MQTT connection refused, reason code 0x%02x %s", reasonCode, props.reasonString)
+	}
+
+	client.SessionPresent = ackFlags&0x01 != 0
+	if props.assignedClientId != "" {
+		client.ClientId = props.assignedClientId
+	}
+	if props.serverKeepAlive > 0 {
+		client.opts.KeepAlive = time.Duration(props.serverKeepAlive) * time.Second
+	}
+
+	receiveMaximum := int(props.receiveMaximum)
+	if receiveMaximum == 0 {
+		receiveMaximum = 65535
+	}
+	client.sendQuota = make(chan struct{}, receiveMaximum)
+	client.maxPacketSize = props.maxPacketSize
+
+	return nil
+}
+
+func (client *Client) Subscribe(ctx context.Context, topicFilter string, qos byte) error {
+	if qos > 1 {
+		return errors.New("QoS 2 subscriptions are not supported")
+	}
+
+	packetId, acked := client.register(false)
+
+	e := &encoder{}
+	e.uint16(packetId)
+	e.properties(&properties{})
+	e.string(topicFilter)
+	e.byte(qos)
+
+	if err := client.write(e.packet(packetSubscribe, 0x02)); err != nil {
+		client.unregister(packetId)
+		return err
+	}
+
+	result, err := client.wait(ctx, packetId, acked)
+	if err != nil {
+		return err
+	}
+
+	if len(result.reasonCodes) != 1 || result.reasonCodes[0] >= 0x80 {
+		return fmt.Errorf("MQTT subscription to %s refused, reason codes %v %s", topicFilter, result.reasonCodes, result.reasonString)
+	}
+
+	return nil
+}
+
+/**
+ * Publish the message, a QoS 1 message is only sent once the broker has
+ * acknowledged it. It waits while the broker's Receive Maximum of QoS 1
+ * messages are in flight.
+ */
+func (client *Client) Publish(ctx context.Context, msg *Message) error {
+	if msg.QoS > 1 {
+		return errors.New("QoS 2 publishing is not supported")
+	}
+
+	flags := msg.QoS << 1
+	if msg.Retain {
+		flags |= 0x01
+	}
+
+	e := &encoder{}
+	e.string(msg.Topic)
+
+	var packetId uint16
+	var acked chan ack
+	if msg.QoS > 0 {
+		select {
+		case client.sendQuota <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-client.done:
+			return client.err
+		}
+
+		packetId, acked = client.register(true)
+		e.uint16(packetId)
+	}
+
+	e.properties(&properties{
+		messageExpiry:   msg.MessageExpiry,
+		contentType:     msg.ContentType,
+		responseTopic:   msg.ResponseTopic,
+		correlationData: msg.CorrelationData,
+		userProperties:  msg.UserProperties,
+	})
+	e.buf.Write(msg.Payload)
+
+	packet := e.packet(packetPublish, flags)
+	if client.maxPacketSize > 0 && len(packet) > int(client.maxPacketSize) {
+		if msg.QoS > 0 {
+			client.unregister(packetId)
+		}
+		return fmt.Errorf("MQTT message to %s is %d bytes, over the broker maximum packet size of %d", msg.Topic, len(packet), client.maxPacketSize)
+	}
+
+	if err := client.write(packet); err != nil {
+		if msg.QoS > 0 {
+			client.unregister(packetId)
+		}
+		return err
+	}
+
+	if msg.QoS == 0 {
+		return nil
+	}
+
+	result, err := client.wait(ctx, packetId, acked)
+	if err != nil {
+		return err
+	}
+
+	if len(result.reasonCodes) > 0 && result.reasonCodes[0] >= 0x80 {
+		return fmt.Errorf("MQTT publish to %s refused, reason code 0x%02x %s", msg.Topic, result.reasonCodes[0], result.reasonString)
+	}
+
+	return nil
+}
+
+// Disconnect normally, the broker discards the will message
+func (client *Client) Disconnect() {
+	e := &encoder{}
+	e.byte(0x00)
+	e.properties(&properties{})
+	client.write(e.packet(packetDisconnect, 0))
+	client.close(errors.New("disconnected"))
+}
+
+// Done is closed when the connection is lost, Err tells why
+func (client *Client) Done() <-chan struct{} {
+	return client.done
+}
+
+func (client *Client) Err() error {
+	select {
+	case <-client.done:
+		return client.err
+	default:
+		return nil
+	}
+}
+
+func (client *Client) readLoop(reader *bufio.Reader) {
+	for {
+		packetType, flags, body, err := readPacket(reader)
+		if err != nil {
+			client.close(fmt.Errorf("MQTT connection lost: %w", err))
+			return
+		}
+
+		d := &decoder{data: body}
+
+		switch packetType {
+		case packetPublish:
+			msg, packetId := decodePublish(d, flags)
+			if d.err != nil {
+				client.close(fmt.Errorf("failed to decode MQTT PUBLISH: %w", d.err))
+				return
+			}
+
+			switch msg.QoS {
+			case 1:
+				msg.client = client
+				msg.packetId = packetId
+
+				client.ackMu.Lock()
+				client.unacked = append(client.unacked, msg)
+				client.ackMu.Unlock()
+			case 2:
+				// Refuse it, the subscriptions never ask for QoS 2
+				e := &encoder{}
+				e.uint16(packetId)
+				e.byte(0x9E)
+				e.properties(&properties{})
+				client.write(e.packet(packetPubrec, 0))
+				continue
+			}
+
+			client.onMessage(msg)
+		case packetPuback:
+			packetId := d.uint16()
+			result := ack{reasonCodes: []byte{0x00}}
+			if d.remaining() > 0 {
+				result.reasonCodes[0] = d.byte()
+			}
+			if d.remaining() > 0 {
+				result.reasonString = d.properties().reasonString
+			}
+			client.deliver(packetId, result)
+		case packetSuback:
+			packetId := d.uint16()
+			props := d.properties()
+			client.deliver(packetId, ack{reasonCodes: d.take(d.remaining()), reasonString: props.reasonString})
+		case packetPingresp:
+			client.pingMu.Lock()
+			client.pingPending = false
+			client.pingMu.Unlock()
+		case packetDisconnect:
+			reasonCode := byte(0)
+			reasonString := ""
+			if d.remaining() > 0 {
+				reasonCode = d.byte()
+			}
+			if d.remaining() > 0 {
+				reasonString = d.properties().reasonString
+			}
+			client.close(fmt.Errorf("MQTT broker disconnected, reason code 0x%02x %s", reasonCode, reasonString))
+			return
+		}
+	}
+}
+
+func decodePublish(d *decoder, flags byte) (*Message, uint16) {
+	msg := &Message{
+		QoS:    (flags >> 1) & 0x03,
+		Retain: flags&0x01 != 0,
+	}
+
+	msg.Topic = d.string()
+
+	var packetId uint16
+	if msg.QoS > 0 {
+		packetId = d.uint16()
+	}
+
+	props := d.properties()
+	msg.MessageExpiry = props.messageExpiry
+	msg.ContentType = props.contentType
+	msg.ResponseTopic = props.responseTopic
+	msg.CorrelationData = props.correlationData
+	msg.UserProperties = props.userProperties
+	msg.Payload = append([]byte(nil), d.take(d.remaining())...)
+
+	return msg, packetId
+}
+
+func (client *Client) keepAlive() {
+	ticker := time.NewTicker(client.opts.KeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-client.done:
+			return
+		case <-ticker.C:
+			client.pingMu.Lock()
+			missed := client.pingPending
+			client.pingPending = true
+			client.pingMu.Unlock()
+
+			if missed {
+				client.close(errors.New("MQTT ping response timed out"))
+				return
+			}
+
+			client.write((&encoder{}).packet(packetPingreq, 0))
+		}
+	}
+}
+
+func (client *Client) write(packet []byte) error {
+	client.writeMu.Lock()
+	defer client.writeMu.Unlock()
+
+	select {
+	case <-client.done:
+		return client.err
+	default:
+	}
+
+	if _, err := client.conn.Write(packet); err != nil {
+		client.close(fmt.Errorf("MQTT connection lost: %w", err))
+		return err
+	}
+
+	return nil
+}
+
+func (client *Client) ack(msg *Message) {
+	client.ackMu.Lock()
+	defer client.ackMu.Unlock()
+
+	msg.acked = true
+	for len(client.unacked) > 0 && client.unacked[0].acked {
+		e := &encoder{}
+		e.uint16(client.unacked[0].packetId)
+		client.write(e.packet(packetPuback, 0))
+
+		client.unacked[0] = nil
+		client.unacked = client.unacked[1:]
+	}
+}
+
+func (client *Client) register(quota bool) (uint16, chan ack) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	for {
+		client.nextId++
+		if client.nextId == 0 {
+			continue
+		}
+		if _, used := client.inflight[client.nextId]; !used {
+			break
+		}
+	}
+
+	acked := make(chan ack, 1)
+	client.inflight[client.nextId] = &pending{acked: acked, quota: quota}
+
+	return client.nextId, acked
+}
+
+func (client *Client) unregister(packetId uint16) {
+	client.mu.Lock()
+	entry, ok := client.inflight[packetId]
+	delete(client.inflight, packetId)
+	client.mu.Unlock()
+
+	if ok && entry.quota {
+		<-client.sendQuota
+	}
+}
+
+func (client *Client) deliver(packetId uint16, result ack) {
+	client.mu.Lock()
+	entry, ok := client.inflight[packetId]
+	delete(client.inflight, packetId)
+	client.mu.Unlock()
+
+	if ok {
+		if entry.quota {
+			<-client.sendQuota
+		}
+		entry.acked <- result
+	}
+}
+
+func (client *Client) wait(ctx context.Context, packetId uint16, acked chan ack) (ack, error) {
+	select {
+	case result := <-acked:
+		return result, nil
+	case <-ctx.Done():
+		// A message already sent is in flight for the broker until it acks
+		// it, it keeps its place in the send quota until then
+		client.mu.Lock()
+		if entry, ok := client.inflight[packetId]; ok && !entry.quota {
+			delete(client.inflight, packetId)
+		}
+		client.mu.Unlock()
+		return ack{}, ctx.Err()
+	case <-client.done:
+		return ack{}, client.err
+	}
+}
+
+func (client *Client) close(err error) {
+	client.closeOnce.Do(func() {
+		client.err = err
+		close(client.done)
+		client.conn.Close()
+	})
+}
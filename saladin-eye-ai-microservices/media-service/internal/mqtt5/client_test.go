@@ -0,0 +1,765 @@
+package mqtt5
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeBroker accepts connections on a loopback port and hands each one to
+// the test, which plays the broker side packet by packet
+type fakeBroker struct {
+	t        *testing.T
+	listener net.Listener
+	conns    chan *brokerConn
+}
+
+type brokerConn struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func newFakeBroker(t *testing.T) *fakeBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	broker := &fakeBroker{
+		t:        t,
+		listener: listener,
+		conns:    make(chan *brokerConn, 4),
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			broker.conns <- &brokerConn{t: t, conn: conn, reader: bufio.NewReader(conn)}
+		}
+	}()
+
+	t.Cleanup(func() { listener.Close() })
+
+	return broker
+}
+
+func (broker *fakeBroker) server() string {
+	return "tcp://" + broker.listener.Addr().String()
+}
+
+func (broker *fakeBroker) accept() *brokerConn {
+	broker.t.Helper()
+
+	select {
+	case bc := <-broker.conns:
+		broker.t.Cleanup(func() { bc.conn.Close() })
+		return bc
+	case <-time.After(5 * time.Second):
+		broker.t.Fatalf("no connection to the fake broker")
+		return nil
+	}
+}
+
+func (bc *brokerConn) read(want byte) (byte, *decoder) {
+	bc.t.Helper()
+
+	bc.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	packetType, flags, body, err := readPacket(bc.reader)
+	if err != nil {
+		bc.t.Fatalf("broker failed to read packet: %v", err)
+	}
+	if packetType != want {
+		bc.t.Fatalf("broker read packet type %d, want %d", packetType, want)
+	}
+
+	return flags, &decoder{data: body}
+}
+
+func (bc *brokerConn) write(packet []byte) {
+	bc.t.Helper()
+
+	if _, err := bc.conn.Write(packet); err != nil {
+		bc.t.Fatalf("broker failed to write packet: %v", err)
+	}
+}
+
+// Read the CONNECT and accept it with the reason code and the properties
+func (bc *brokerConn) connack(sessionPresent bool, reasonCode byte, props *encoder) *decoder {
+	bc.t.Helper()
+
+	_, d := bc.read(packetConnect)
+
+	e := &encoder{}
+	if sessionPresent {
+		e.byte(0x01)
+	} else {
+		e.byte(0x00)
+	}
+	e.byte(reasonCode)
+	if props == nil {
+		props = &encoder{}
+	}
+	e.varint(props.buf.Len())
+	e.buf.Write(props.buf.Bytes())
+	bc.write(e.packet(packetConnack, 0))
+
+	return d
+}
+
+// Read the SUBSCRIBE and answer it with the reason code
+func (bc *brokerConn) suback(reasonCode byte) string {
+	bc.t.Helper()
+
+	flags, d := bc.read(packetSubscribe)
+	if flags != 0x02 {
+		bc.t.Errorf("SUBSCRIBE flags %x, want 0x02", flags)
+	}
+	packetId := d.uint16()
+	d.properties()
+	topicFilter := d.string()
+	d.byte()
+	if d.err != nil {
+		bc.t.Fatalf("failed to decode SUBSCRIBE: %v", d.err)
+	}
+
+	e := &encoder{}
+	e.uint16(packetId)
+	e.properties(&properties{})
+	e.byte(reasonCode)
+	bc.write(e.packet(packetSuback, 0))
+
+	return topicFilter
+}
+
+func connect(t *testing.T, broker *fakeBroker, opts Options, onMessage func(*Message)) (*Client, *brokerConn) {
+	t.Helper()
+
+	opts.Server = broker.server()
+	if opts.ConnectTimeout == 0 {
+		opts.ConnectTimeout = 5 * time.Second
+	}
+
+	type result struct {
+		client *Client
+		err    error
+	}
+	connected := make(chan result, 1)
+	go func() {
+		client, err := Connect(context.Background(), opts, onMessage)
+		connected <- result{client, err}
+	}()
+
+	bc := broker.accept()
+	bc.connack(false, 0x00, nil)
+
+	r := <-connected
+	if r.err != nil {
+		t.Fatalf("failed to connect: %v", r.err)
+	}
+	t.Cleanup(func() { r.client.close(nil) })
+
+	return r.client, bc
+}
+
+func TestConnect(t *testing.T) {
+	broker := newFakeBroker(t)
+
+	connected := make(chan error, 1)
+	var client *Client
+	go func() {
+		var err error
+		client, err = Connect(context.Background(), Options{
+			Server:         broker.server(),
+			ClientId:       "media-service-1",
+			Username:       "user",
+			Password:       "secret",
+			KeepAlive:      30 * time.Second,
+			ConnectTimeout: 5 * time.Second,
+			SessionExpiry:  3600,
+		}, func(*Message) {})
+		connected <- err
+	}()
+
+	bc := broker.accept()
+
+	props := &encoder{}
+	props.byte(propAssignedClientId)
+	props.string("assigned-id")
+	props.byte(propServerKeepAlive)
+	props.uint16(60)
+	d := bc.connack(true, 0x00, props)
+
+	if protocol := d.string(); protocol != "MQTT" {
+		t.Errorf("CONNECT protocol %q", protocol)
+	}
+	if version := d.byte(); version != 5 {
+		t.Errorf("CONNECT version %d", version)
+	}
+	if flags := d.byte(); flags != 0xC0 {
+		t.Errorf("CONNECT flags %x, want username and password without clean start", flags)
+	}
+	if keepAlive := d.uint16(); keepAlive != 30 {
+		t.Errorf("CONNECT keep alive %d", keepAlive)
+	}
+	if sessionExpiry := d.properties().sessionExpiry; sessionExpiry != 3600 {
+		t.Errorf("CONNECT session expiry %d", sessionExpiry)
+	}
+	if clientId, username, password := d.string(), d.string(), d.string(); clientId != "media-service-1" || username != "user" || password != "secret" {
+		t.Errorf("CONNECT payload %q %q %q", clientId, username, password)
+	}
+	if d.err != nil || d.remaining() != 0 {
+		t.Errorf("CONNECT decoded with %v, %d bytes left", d.err, d.remaining())
+	}
+
+	if err := <-connected; err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer client.close(nil)
+
+	if !client.SessionPresent || client.ClientId != "assigned-id" || client.opts.KeepAlive != 60*time.Second {
+		t.Errorf("CONNACK applied as session present %t, client id %q, keep alive %s", client.SessionPresent, client.ClientId, client.opts.KeepAlive)
+	}
+}
+
+func TestConnectRefused(t *testing.T) {
+	broker := newFakeBroker(t)
+
+	connected := make(chan error, 1)
+	go func() {
+		_, err := Connect(context.Background(), Options{Server: broker.server(), ConnectTimeout: 5 * time.Second}, func(*Message) {})
+		connected <- err
+	}()
+
+	props := &encoder{}
+	props.byte(propReasonString)
+	props.string("not authorized")
+	broker.accept().connack(false, 0x87, props)
+
+	err := <-connected
+	if err == nil || !strings.Contains(err.Error(), "0x87") || !strings.Contains(err.Error(), "not authorized") {
+		t.Errorf("refused connection returned %v", err)
+	}
+}
+
+func TestConnectTimeout(t *testing.T) {
+	broker := newFakeBroker(t)
+
+	connected := make(chan error, 1)
+	go func() {
+		_, err := Connect(context.Background(), Options{Server: broker.server(), ConnectTimeout: 100 * time.Millisecond}, func(*Message) {})
+		connected <- err
+	}()
+
+	// Never answer the CONNECT
+	broker.accept()
+
+	select {
+	case err := <-connected:
+		if err == nil {
+			t.Errorf("connected without a CONNACK")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("connect did not time out")
+	}
+}
+
+func TestConnectUnsupportedScheme(t *testing.T) {
+	if _, err := Connect(context.Background(), Options{Server: "ws://127.0.0.1:1"}, func(*Message) {}); err == nil {
+		t.Errorf("connected with an unsupported scheme")
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	broker := newFakeBroker(t)
+	client, bc := connect(t, broker, Options{}, func(*Message) {})
+
+	tests := []struct {
+		name       string
+		reasonCode byte
+		wantErr    bool
+	}{
+		{"granted QoS 1", 0x01, false},
+		{"granted QoS 0", 0x00, false},
+		{"not authorized", 0x87, true},
+	}
+
+	for _, tt := range tests {
+		subscribed := make(chan error, 1)
+		go func() {
+			subscribed <- client.Subscribe(context.Background(), "saladin-eye/device/+/request/#", 1)
+		}()
+
+		if topicFilter := bc.suback(tt.reasonCode); topicFilter != "saladin-eye/device/+/request/#" {
+			t.Errorf("%s: subscribed to %q", tt.name, topicFilter)
+		}
+
+		if err := <-subscribed; (err != nil) != tt.wantErr {
+			t.Errorf("%s: subscribe returned %v", tt.name, err)
+		}
+	}
+
+	if err := client.Subscribe(context.Background(), "a", 2); err == nil {
+		t.Errorf("subscribed with QoS 2")
+	}
+}
+
+func TestReceive(t *testing.T) {
+	broker := newFakeBroker(t)
+
+	received := make(chan *Message, 1)
+	_, bc := connect(t, broker, Options{}, func(msg *Message) { received <- msg })
+
+	e := &encoder{}
+	e.string("saladin-eye/device/ABCDE1234/request/get-server-time")
+	e.uint16(42)
+	e.properties(&properties{
+		responseTopic:   "saladin-eye/device/ABCDE1234/response",
+		correlationData: []byte("correlation"),
+		userProperties:  []UserProperty{{Key: "k", Value: "v"}},
+		messageExpiry:   30,
+	})
+	e.buf.WriteString("payload")
+	bc.write(e.packet(packetPublish, 0x02))
+
+	var msg *Message
+	select {
+	case msg = <-received:
+		if msg.Topic != "saladin-eye/device/ABCDE1234/request/get-server-time" || string(msg.Payload) != "payload" || msg.QoS != 1 ||
+			msg.ResponseTopic != "saladin-eye/device/ABCDE1234/response" || string(msg.CorrelationData) != "correlation" ||
+			len(msg.UserProperties) != 1 || msg.MessageExpiry != 30 {
+			t.Errorf("received %+v", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("message not received")
+	}
+
+	// A QoS 1 message is acknowledged with its packet id, once processed
+	msg.Ack()
+	_, d := bc.read(packetPuback)
+	if packetId := d.uint16(); packetId != 42 {
+		t.Errorf("PUBACK packet id %d, want 42", packetId)
+	}
+}
+
+func TestAckInReceivedOrder(t *testing.T) {
+	broker := newFakeBroker(t)
+
+	received := make(chan *Message, 3)
+	_, bc := connect(t, broker, Options{}, func(msg *Message) { received <- msg })
+
+	for packetId := uint16(1); packetId <= 3; packetId++ {
+		e := &encoder{}
+		e.string("a")
+		e.uint16(packetId)
+		e.properties(&properties{})
+		bc.write(e.packet(packetPublish, 0x02))
+	}
+
+	msgs := make([]*Message, 3)
+	for i := range msgs {
+		select {
+		case msgs[i] = <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("message %d not received", i+1)
+		}
+	}
+
+	// Processed out of order, the first one last
+	msgs[2].Ack()
+	msgs[1].Ack()
+
+	bc.conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, _, _, err := readPacket(bc.reader); err == nil {
+		t.Fatalf("PUBACK sent before the first message was acked")
+	}
+
+	msgs[0].Ack()
+	for want := uint16(1); want <= 3; want++ {
+		_, d := bc.read(packetPuback)
+		if packetId := d.uint16(); packetId != want {
+			t.Errorf("PUBACK packet id %d, want %d", packetId, want)
+		}
+	}
+}
+
+func TestReceiveQoS2Refused(t *testing.T) {
+	broker := newFakeBroker(t)
+
+	received := make(chan *Message, 1)
+	_, bc := connect(t, broker, Options{}, func(msg *Message) { received <- msg })
+
+	e := &encoder{}
+	e.string("a")
+	e.uint16(7)
+	e.properties(&properties{})
+	bc.write(e.packet(packetPublish, 0x04))
+
+	_, d := bc.read(packetPubrec)
+	if packetId, reasonCode := d.uint16(), d.byte(); packetId != 7 || reasonCode != 0x9E {
+		t.Errorf("PUBREC packet id %d reason code 0x%02x", packetId, reasonCode)
+	}
+
+	select {
+	case msg := <-received:
+		t.Errorf("QoS 2 message passed on: %+v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestPublish(t *testing.T) {
+	broker := newFakeBroker(t)
+	client, bc := connect(t, broker, Options{}, func(*Message) {})
+
+	// QoS 0 is sent without waiting
+	if err := client.Publish(context.Background(), &Message{Topic: "a", Payload: []byte("fire and forget")}); err != nil {
+		t.Fatalf("QoS 0 publish returned %v", err)
+	}
+	flags, d := bc.read(packetPublish)
+	if msg, _ := decodePublish(d, flags); d.err != nil || msg.QoS != 0 || string(msg.Payload) != "fire and forget" {
+		t.Errorf("QoS 0 published as %+v, %v", msg, d.err)
+	}
+
+	tests := []struct {
+		name       string
+		reasonCode byte
+		wantErr    bool
+	}{
+		{"success", 0x00, false},
+		{"no matching subscribers", 0x10, false},
+		{"not authorized", 0x87, true},
+	}
+
+	for _, tt := range tests {
+		published := make(chan error, 1)
+		go func() {
+			published <- client.Publish(context.Background(), &Message{
+				Topic:           "saladin-eye/device/ABCDE1234/response",
+				Payload:         []byte("reply"),
+				QoS:             1,
+				CorrelationData: []byte("correlation"),
+			})
+		}()
+
+		flags, d := bc.read(packetPublish)
+		msg, packetId := decodePublish(d, flags)
+		if d.err != nil || msg.QoS != 1 || packetId == 0 || string(msg.CorrelationData) != "correlation" {
+			t.Errorf("%s: published as %+v packet id %d, %v", tt.name, msg, packetId, d.err)
+		}
+
+		e := &encoder{}
+		e.uint16(packetId)
+		e.byte(tt.reasonCode)
+		e.properties(&properties{})
+		bc.write(e.packet(packetPuback, 0))
+
+		if err := <-published; (err != nil) != tt.wantErr {
+			t.Errorf("%s: publish returned %v", tt.name, err)
+		}
+	}
+}
+
+func TestPublishContextCanceled(t *testing.T) {
+	broker := newFakeBroker(t)
+	client, bc := connect(t, broker, Options{}, func(*Message) {})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	published := make(chan error, 1)
+	go func() {
+		published <- client.Publish(ctx, &Message{Topic: "a", QoS: 1})
+	}()
+
+	// Never acknowledge it
+	bc.read(packetPublish)
+
+	if err := <-published; err != context.DeadlineExceeded {
+		t.Errorf("unacknowledged publish returned %v", err)
+	}
+
+	// In flight for the broker until it acks it late
+	client.mu.Lock()
+	var packetId uint16
+	for id := range client.inflight {
+		packetId = id
+	}
+	client.mu.Unlock()
+	if packetId == 0 || len(client.sendQuota) != 1 {
+		t.Fatalf("canceled publish not in flight, send quota %d", len(client.sendQuota))
+	}
+
+	e := &encoder{}
+	e.uint16(packetId)
+	bc.write(e.packet(packetPuback, 0))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		client.mu.Lock()
+		inflight := len(client.inflight)
+		client.mu.Unlock()
+		if inflight == 0 && len(client.sendQuota) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d packet ids still in flight, send quota %d", inflight, len(client.sendQuota))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPublishReceiveMaximum(t *testing.T) {
+	broker := newFakeBroker(t)
+
+	connected := make(chan *Client, 1)
+	go func() {
+		client, err := Connect(context.Background(), Options{Server: broker.server(), ConnectTimeout: 5 * time.Second}, func(*Message) {})
+		if err != nil {
+			t.Errorf("failed to connect: %v", err)
+		}
+		connected <- client
+	}()
+
+	bc := broker.accept()
+	props := &encoder{}
+	props.byte(propReceiveMaximum)
+	props.uint16(1)
+	bc.connack(false, 0x00, props)
+	client := <-connected
+	if client == nil {
+		t.FailNow()
+	}
+	t.Cleanup(func() { client.close(nil) })
+
+	published := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			published <- client.Publish(context.Background(), &Message{Topic: "a", QoS: 1})
+		}()
+	}
+
+	flags, d := bc.read(packetPublish)
+	_, packetId := decodePublish(d, flags)
+
+	// Only one message in flight at a time
+	bc.conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, _, _, err := readPacket(bc.reader); err == nil {
+		t.Fatalf("second message sent over the broker receive maximum")
+	}
+
+	e := &encoder{}
+	e.uint16(packetId)
+	bc.write(e.packet(packetPuback, 0))
+
+	flags, d = bc.read(packetPublish)
+	_, packetId = decodePublish(d, flags)
+	e = &encoder{}
+	e.uint16(packetId)
+	bc.write(e.packet(packetPuback, 0))
+
+	for i := 0; i < 2; i++ {
+		if err := <-published; err != nil {
+			t.Errorf("publish returned %v", err)
+		}
+	}
+}
+
+func TestPublishMaximumPacketSize(t *testing.T) {
+	broker := newFakeBroker(t)
+
+	connected := make(chan *Client, 1)
+	go func() {
+		client, err := Connect(context.Background(), Options{Server: broker.server(), ConnectTimeout: 5 * time.Second}, func(*Message) {})
+		if err != nil {
+			t.Errorf("failed to connect: %v", err)
+		}
+		connected <- client
+	}()
+
+	bc := broker.accept()
+	props := &encoder{}
+	props.byte(propMaxPacketSize)
+	props.uint32(64)
+	bc.connack(false, 0x00, props)
+	client := <-connected
+	if client == nil {
+		t.FailNow()
+	}
+	t.Cleanup(func() { client.close(nil) })
+
+	if err := client.Publish(context.Background(), &Message{Topic: "a", QoS: 1, Payload: make([]byte, 64)}); err == nil {
+		t.Errorf("message over the maximum packet size published")
+	}
+	if len(client.sendQuota) != 0 {
+		t.Errorf("refused message holds the send quota")
+	}
+
+	published := make(chan error, 1)
+	go func() {
+		published <- client.Publish(context.Background(), &Message{Topic: "a", Payload: []byte("small")})
+	}()
+	bc.read(packetPublish)
+	if err := <-published; err != nil {
+		t.Errorf("small message publish returned %v", err)
+	}
+}
+
+func TestConnectionLost(t *testing.T) {
+	broker := newFakeBroker(t)
+	client, bc := connect(t, broker, Options{}, func(*Message) {})
+
+	published := make(chan error, 1)
+	go func() {
+		published <- client.Publish(context.Background(), &Message{Topic: "a", QoS: 1})
+	}()
+	bc.read(packetPublish)
+
+	bc.conn.Close()
+
+	select {
+	case <-client.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Done not closed after the connection was lost")
+	}
+
+	if client.Err() == nil {
+		t.Errorf("no error after the connection was lost")
+	}
+	if err := <-published; err == nil {
+		t.Errorf("publish in flight returned no error after the connection was lost")
+	}
+	if err := client.Publish(context.Background(), &Message{Topic: "a"}); err == nil {
+		t.Errorf("publish after the connection was lost returned no error")
+	}
+}
+
+func TestBrokerDisconnect(t *testing.T) {
+	broker := newFakeBroker(t)
+	client, bc := connect(t, broker, Options{}, func(*Message) {})
+
+	e := &encoder{}
+	e.byte(0x8E)
+	inner := &encoder{}
+	inner.byte(propReasonString)
+	inner.string("session taken over")
+	e.varint(inner.buf.Len())
+	e.buf.Write(inner.buf.Bytes())
+	bc.write(e.packet(packetDisconnect, 0))
+
+	select {
+	case <-client.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Done not closed after the broker disconnected")
+	}
+
+	if err := client.Err(); err == nil || !strings.Contains(err.Error(), "0x8e") || !strings.Contains(err.Error(), "session taken over") {
+		t.Errorf("broker disconnect returned %v", err)
+	}
+}
+
+func TestMalformedPacketClosesConnection(t *testing.T) {
+	broker := newFakeBroker(t)
+	client, bc := connect(t, broker, Options{}, func(*Message) {})
+
+	// A PUBLISH with a topic longer than the packet
+	bc.write([]byte{packetPublish << 4, 0x03, 0x00, 0x09, 'a'})
+
+	select {
+	case <-client.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Done not closed after a malformed packet")
+	}
+
+	if err := client.Err(); err == nil || !strings.Contains(err.Error(), "PUBLISH") {
+		t.Errorf("malformed packet returned %v", err)
+	}
+}
+
+func TestKeepAlive(t *testing.T) {
+	broker := newFakeBroker(t)
+	client, bc := connect(t, broker, Options{KeepAlive: 50 * time.Millisecond}, func(*Message) {})
+
+	// Answered pings keep the connection up
+	for i := 0; i < 3; i++ {
+		bc.read(packetPingreq)
+		bc.write((&encoder{}).packet(packetPingresp, 0))
+	}
+
+	// An unanswered one closes it
+	bc.read(packetPingreq)
+
+	select {
+	case <-client.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Done not closed after the ping timed out")
+	}
+
+	if err := client.Err(); err == nil || !strings.Contains(err.Error(), "ping") {
+		t.Errorf("ping timeout returned %v", err)
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	broker := newFakeBroker(t)
+	client, bc := connect(t, broker, Options{}, func(*Message) {})
+
+	client.Disconnect()
+
+	_, d := bc.read(packetDisconnect)
+	if reasonCode := d.byte(); reasonCode != 0x00 {
+		t.Errorf("DISCONNECT reason code 0x%02x", reasonCode)
+	}
+
+	select {
+	case <-client.Done():
+	default:
+		t.Errorf("Done not closed after disconnecting")
+	}
+}
+
+// The client does not reconnect, the caller connects a new one once Done is
+// closed, and the broker resumes the session
+func TestReconnect(t *testing.T) {
+	broker := newFakeBroker(t)
+	client, bc := connect(t, broker, Options{ClientId: "media-service-1", SessionExpiry: 3600}, func(*Message) {})
+
+	bc.conn.Close()
+	<-client.Done()
+
+	reconnected := make(chan *Client, 1)
+	go func() {
+		client, err := Connect(context.Background(), Options{
+			Server:         broker.server(),
+			ClientId:       "media-service-1",
+			ConnectTimeout: 5 * time.Second,
+			SessionExpiry:  3600,
+		}, func(*Message) {})
+		if err != nil {
+			t.Errorf("failed to reconnect: %v", err)
+		}
+		reconnected <- client
+	}()
+
+	d := broker.accept().connack(true, 0x00, nil)
+	d.string()
+	d.byte()
+	if flags := d.byte(); flags&0x02 != 0 {
+		t.Errorf("reconnect asked for a clean start")
+	}
+
+	client = <-reconnected
+	if client == nil {
+		return
+	}
+	defer client.close(nil)
+
+	if !client.SessionPresent {
+		t.Errorf("session not resumed after reconnecting")
+	}
+}
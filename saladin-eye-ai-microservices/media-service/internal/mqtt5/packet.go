@@ -0,0 +1,342 @@
+package mqtt5
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Control packet types
+const (
+	packetConnect    = 1
+	packetConnack    = 2
+	packetPublish    = 3
+	packetPuback     = 4
+	packetPubrec     = 5
+	packetSubscribe  = 8
+	packetSuback     = 9
+	packetPingreq    = 12
+	packetPingresp   = 13
+	packetDisconnect = 14
+)
+
+// Property identifiers, only the ones this client reads or writes are named,
+// the others are skipped by their type
+const (
+	propMessageExpiry    = 0x02
+	propContentType      = 0x03
+	propResponseTopic    = 0x08
+	propCorrelationData  = 0x09
+	propSessionExpiry    = 0x11
+	propAssignedClientId = 0x12
+	propServerKeepAlive  = 0x13
+	propReasonString     = 0x1F
+	propReceiveMaximum   = 0x21
+	propUserProperty     = 0x26
+	propMaxPacketSize    = 0x27
+)
+
+// Size of the value of every property, by identifier
+const (
+	kindByte = iota
+	kindUint16
+	kindUint32
+	kindVarint
+	kindString
+	kindBinary
+	kindStringPair
+)
+
+var propertyKinds = map[byte]int{
+	0x01: kindByte, 0x02: kindUint32, 0x03: kindString, 0x08: kindString,
+	0x09: kindBinary, 0x0B: kindVarint, 0x11: kindUint32, 0x12: kindString,
+	0x13: kindUint16, 0x15: kindString, 0x16: kindBinary, 0x17: kindByte,
+	0x18: kindUint32, 0x19: kindByte, 0x1A: kindString, 0x1C: kindString,
+	0x1F: kindString, 0x21: kindUint16, 0x22: kindUint16, 0x23: kindUint16,
+	0x24: kindByte, 0x25: kindByte, 0x26: kindStringPair, 0x27: kindUint32,
+	0x28: kindByte, 0x29: kindByte, 0x2A: kindByte,
+}
+
+const maxRemainingLength = 268435455
+
+var errMalformed = errors.New("malformed MQTT packet")
+
+type UserProperty struct {
+	Key   string
+	Value string
+}
+
+// The properties of the packets this client sends and receives
+type properties struct {
+	messageExpiry    uint32
+	contentType      string
+	responseTopic    string
+	correlationData  []byte
+	userProperties   []UserProperty
+	sessionExpiry    uint32
+	assignedClientId string
+	serverKeepAlive  uint16
+	reasonString     string
+	receiveMaximum   uint16
+	maxPacketSize    uint32
+}
+
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) byte(b byte) {
+	e.buf.WriteByte(b)
+}
+
+func (e *encoder) uint16(v uint16) {
+	e.buf.Write(binary.BigEndian.AppendUint16(nil, v))
+}
+
+func (e *encoder) uint32(v uint32) {
+	e.buf.Write(binary.BigEndian.AppendUint32(nil, v))
+}
+
+func (e *encoder) varint(v int) {
+	for {
+		b := byte(v % 128)
+		v /= 128
+		if v > 0 {
+			b |= 0x80
+		}
+		e.buf.WriteByte(b)
+		if v == 0 {
+			return
+		}
+	}
+}
+
+func (e *encoder) binary(data []byte) {
+	e.uint16(uint16(len(data)))
+	e.buf.Write(data)
+}
+
+func (e *encoder) string(s string) {
+	e.binary([]byte(s))
+}
+
+func (e *encoder) properties(props *properties) {
+	inner := &encoder{}
+	if props.messageExpiry > 0 {
+		inner.byte(propMessageExpiry)
+		inner.uint32(props.messageExpiry)
+	}
+	if props.contentType != "" {
+		inner.byte(propContentType)
+		inner.string(props.contentType)
+	}
+	if props.responseTopic != "" {
+		inner.byte(propResponseTopic)
+		inner.string(props.responseTopic)
+	}
+	if props.correlationData != nil {
+		inner.byte(propCorrelationData)
+		inner.binary(props.correlationData)
+	}
+	if props.sessionExpiry > 0 {
+		inner.byte(propSessionExpiry)
+		inner.uint32(props.sessionExpiry)
+	}
+	if props.receiveMaximum > 0 {
+		inner.byte(propReceiveMaximum)
+		inner.uint16(props.receiveMaximum)
+	}
+	for _, userProperty := range props.userProperties {
+		inner.byte(propUserProperty)
+		inner.string(userProperty.Key)
+		inner.string(userProperty.Value)
+	}
+
+	e.varint(inner.buf.Len())
+	e.buf.Write(inner.buf.Bytes())
+}
+
+// packet returns the whole packet, the fixed header and this encoder's content
+func (e *encoder) packet(packetType, flags byte) []byte {
+	header := &encoder{}
+	header.byte(packetType<<4 | flags)
+	header.varint(e.buf.Len())
+	return append(header.buf.Bytes(), e.buf.Bytes()...)
+}
+
+type decoder struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (d *decoder) take(n int) []byte {
+	if d.err != nil || n < 0 || d.pos+n > len(d.data) {
+		d.err = errMalformed
+		return nil
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *decoder) remaining() int {
+	return len(d.data) - d.pos
+}
+
+func (d *decoder) byte() byte {
+	b := d.take(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (d *decoder) uint16() uint16 {
+	b := d.take(2)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b)
+}
+
+func (d *decoder) uint32() uint32 {
+	b := d.take(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (d *decoder) varint() int {
+	value, multiplier := 0, 1
+	for i := 0; i < 4; i++ {
+		b := d.byte()
+		value += int(b&0x7F) * multiplier
+		if b&0x80 == 0 {
+			return value
+		}
+		multiplier *= 128
+	}
+	d.err = errMalformed
+	return 0
+}
+
+// An empty value is not nil, so empty correlation data is still sent back
+func (d *decoder) binary() []byte {
+	b := d.take(int(d.uint16()))
+	if d.err != nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
+
+func (d *decoder) string() string {
+	return string(d.take(int(d.uint16())))
+}
+
+func (d *decoder) properties() *properties {
+	props := &properties{}
+	inner := &decoder{data: d.take(d.varint())}
+	if d.err != nil {
+		return props
+	}
+
+	for inner.remaining() > 0 && inner.err == nil {
+		id := inner.byte()
+		switch id {
+		case propMessageExpiry:
+			props.messageExpiry = inner.uint32()
+		case propContentType:
+			props.contentType = inner.string()
+		case propResponseTopic:
+			props.responseTopic = inner.string()
+		case propCorrelationData:
+			props.correlationData = inner.binary()
+		case propSessionExpiry:
+			props.sessionExpiry = inner.uint32()
+		case propAssignedClientId:
+			props.assignedClientId = inner.string()
+		case propServerKeepAlive:
+			props.serverKeepAlive = inner.uint16()
+		case propReasonString:
+			props.reasonString = inner.string()
+		case propReceiveMaximum:
+			props.receiveMaximum = inner.uint16()
+		case propMaxPacketSize:
+			props.maxPacketSize = inner.uint32()
+		case propUserProperty:
+			props.userProperties = append(props.userProperties, UserProperty{Key: inner.string(), Value: inner.string()})
+		default:
+			kind, ok := propertyKinds[id]
+			if !ok {
+				inner.err = fmt.Errorf("unknown MQTT property 0x%02x", id)
+				break
+			}
+			skipProperty(inner, kind)
+		}
+	}
+
+	if inner.err != nil {
+		d.err = inner.err
+	}
+
+	return props
+}
+
+func skipProperty(d *decoder, kind int) {
+	switch kind {
+	case kindByte:
+		d.take(1)
+	case kindUint16:
+		d.take(2)
+	case kindUint32:
+		d.take(4)
+	case kindVarint:
+		d.varint()
+	case kindString, kindBinary:
+		d.take(int(d.uint16()))
+	case kindStringPair:
+		d.take(int(d.uint16()))
+		d.take(int(d.uint16()))
+	}
+}
+
+// readPacket reads one control packet, and returns its type, its flags and
+// everything after the fixed header
+func readPacket(r *bufio.Reader) (byte, byte, []byte, error) {
+	first, err := r.ReadByte()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, 0, nil, errMalformed
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		length += int(b&0x7F) * multiplier
+		if b&0x80 == 0 {
+			break
+		}
+		multiplier *= 128
+	}
+
+	if length > maxRemainingLength {
+		return 0, 0, nil, errMalformed
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, 0, nil, err
+	}
+
+	return first >> 4, first & 0x0F, body, nil
+}
@@ -0,0 +1,321 @@
+package mqtt5
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestVarintRoundTrip(t *testing.T) {
+	tests := []struct {
+		value   int
+		encoded []byte
+	}{
+		{0, []byte{0x00}},
+		{1, []byte{0x01}},
+		{127, []byte{0x7F}},
+		{128, []byte{0x80, 0x01}},
+		{16383, []byte{0xFF, 0x7F}},
+		{16384, []byte{0x80, 0x80, 0x01}},
+		{2097151, []byte{0xFF, 0xFF, 0x7F}},
+		{2097152, []byte{0x80, 0x80, 0x80, 0x01}},
+		{maxRemainingLength, []byte{0xFF, 0xFF, 0xFF, 0x7F}},
+	}
+
+	for _, tt := range tests {
+		e := &encoder{}
+		e.varint(tt.value)
+		if !bytes.Equal(e.buf.Bytes(), tt.encoded) {
+			t.Errorf("varint(%d) encoded as %x, want %x", tt.value, e.buf.Bytes(), tt.encoded)
+		}
+
+		d := &decoder{data: tt.encoded}
+		if got := d.varint(); got != tt.value || d.err != nil {
+			t.Errorf("varint %x decoded as %d, %v, want %d", tt.encoded, got, d.err, tt.value)
+		}
+		if d.remaining() != 0 {
+			t.Errorf("varint %x left %d bytes", tt.encoded, d.remaining())
+		}
+	}
+}
+
+func TestVarintMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", []byte{}},
+		{"continuation without next byte", []byte{0x80}},
+		{"truncated after three bytes", []byte{0xFF, 0xFF, 0xFF}},
+		{"five bytes", []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x01}},
+	}
+
+	for _, tt := range tests {
+		d := &decoder{data: tt.data}
+		d.varint()
+		if d.err == nil {
+			t.Errorf("%s: varint %x decoded without error", tt.name, tt.data)
+		}
+	}
+}
+
+func TestPropertiesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		props properties
+	}{
+		{"empty", properties{}},
+		{"message expiry", properties{messageExpiry: 30}},
+		{"request response", properties{
+			contentType:     "application/x-protobuf",
+			responseTopic:   "saladin-eye/device/ABCDE1234/response",
+			correlationData: []byte{0x01, 0x02, 0x03},
+		}},
+		{"empty correlation data", properties{correlationData: []byte{}}},
+		{"session expiry", properties{sessionExpiry: 3600}},
+		{"user properties", properties{userProperties: []UserProperty{
+			{Key: "request-id", Value: "abc"},
+			{Key: "request-id", Value: "repeated keys are kept"},
+			{Key: "empty", Value: ""},
+		}}},
+	}
+
+	for _, tt := range tests {
+		e := &encoder{}
+		e.properties(&tt.props)
+
+		d := &decoder{data: e.buf.Bytes()}
+		got := d.properties()
+		if d.err != nil {
+			t.Errorf("%s: failed to decode properties: %v", tt.name, d.err)
+			continue
+		}
+		if d.remaining() != 0 {
+			t.Errorf("%s: properties left %d bytes", tt.name, d.remaining())
+		}
+		if !reflect.DeepEqual(*got, tt.props) {
+			t.Errorf("%s: properties decoded as %+v, want %+v", tt.name, *got, tt.props)
+		}
+	}
+}
+
+// The CONNACK properties are only read by the client, they are written by hand
+func TestPropertiesConnack(t *testing.T) {
+	inner := &encoder{}
+	inner.byte(propAssignedClientId)
+	inner.string("assigned-id")
+	inner.byte(propServerKeepAlive)
+	inner.uint16(45)
+	inner.byte(propReasonString)
+	inner.string("welcome")
+
+	e := &encoder{}
+	e.varint(inner.buf.Len())
+	e.buf.Write(inner.buf.Bytes())
+
+	d := &decoder{data: e.buf.Bytes()}
+	props := d.properties()
+	if d.err != nil {
+		t.Fatalf("failed to decode properties: %v", d.err)
+	}
+
+	if props.assignedClientId != "assigned-id" || props.serverKeepAlive != 45 || props.reasonString != "welcome" {
+		t.Errorf("properties decoded as %+v", *props)
+	}
+}
+
+func TestPropertiesSkipUnread(t *testing.T) {
+	inner := &encoder{}
+	// Payload format indicator, byte
+	inner.byte(0x01)
+	inner.byte(0x01)
+	// Subscription identifier, varint
+	inner.byte(0x0B)
+	inner.varint(16384)
+	// Topic alias maximum, uint16
+	inner.byte(0x22)
+	inner.uint16(10)
+	// Maximum packet size, uint32
+	inner.byte(0x27)
+	inner.uint32(1 << 20)
+	// Authentication data, binary
+	inner.byte(0x16)
+	inner.binary([]byte{0xAA, 0xBB})
+	inner.byte(propContentType)
+	inner.string("text/plain")
+
+	e := &encoder{}
+	e.varint(inner.buf.Len())
+	e.buf.Write(inner.buf.Bytes())
+
+	d := &decoder{data: e.buf.Bytes()}
+	props := d.properties()
+	if d.err != nil {
+		t.Fatalf("failed to decode properties: %v", d.err)
+	}
+	if props.contentType != "text/plain" {
+		t.Errorf("content type decoded as %q after the skipped properties", props.contentType)
+	}
+}
+
+func TestPropertiesMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"missing length", []byte{}},
+		{"length past the end", []byte{0x05, propMessageExpiry, 0x00}},
+		{"truncated uint32", []byte{0x03, propMessageExpiry, 0x00, 0x00}},
+		{"string length past the end", []byte{0x04, propContentType, 0x00, 0x05, 'a'}},
+		{"user property without value", []byte{0x04, propUserProperty, 0x00, 0x01, 'k'}},
+		{"unknown property", []byte{0x02, 0x7F, 0x00}},
+		{"truncated skipped property", []byte{0x02, 0x27, 0x00}},
+	}
+
+	for _, tt := range tests {
+		d := &decoder{data: tt.data}
+		d.properties()
+		if d.err == nil {
+			t.Errorf("%s: properties %x decoded without error", tt.name, tt.data)
+		}
+	}
+}
+
+func TestPublishRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      Message
+		packetId uint16
+	}{
+		{"QoS 0", Message{Topic: "a/b", Payload: []byte("hello")}, 0},
+		{"QoS 1 retained", Message{Topic: "a/b", Payload: []byte{0x00, 0x01}, QoS: 1, Retain: true}, 7},
+		{"empty payload", Message{Topic: "a", QoS: 1}, 65535},
+		{"request", Message{
+			Topic:           "saladin-eye/device/ABCDE1234/request/get-server-time",
+			Payload:         []byte{0x0A, 0x00},
+			QoS:             1,
+			ContentType:     "application/x-protobuf",
+			ResponseTopic:   "saladin-eye/device/ABCDE1234/response",
+			CorrelationData: []byte("correlation"),
+			UserProperties:  []UserProperty{{Key: "k", Value: "v"}},
+			MessageExpiry:   60,
+		}, 1},
+	}
+
+	for _, tt := range tests {
+		flags := tt.msg.QoS << 1
+		if tt.msg.Retain {
+			flags |= 0x01
+		}
+
+		e := &encoder{}
+		e.string(tt.msg.Topic)
+		if tt.msg.QoS > 0 {
+			e.uint16(tt.packetId)
+		}
+		e.properties(&properties{
+			messageExpiry:   tt.msg.MessageExpiry,
+			contentType:     tt.msg.ContentType,
+			responseTopic:   tt.msg.ResponseTopic,
+			correlationData: tt.msg.CorrelationData,
+			userProperties:  tt.msg.UserProperties,
+		})
+		e.buf.Write(tt.msg.Payload)
+
+		packetType, gotFlags, body, err := readPacket(bufio.NewReader(bytes.NewReader(e.packet(packetPublish, flags))))
+		if err != nil {
+			t.Errorf("%s: failed to read packet: %v", tt.name, err)
+			continue
+		}
+		if packetType != packetPublish || gotFlags != flags {
+			t.Errorf("%s: read packet type %d flags %x, want %d %x", tt.name, packetType, gotFlags, packetPublish, flags)
+		}
+
+		d := &decoder{data: body}
+		got, packetId := decodePublish(d, gotFlags)
+		if d.err != nil {
+			t.Errorf("%s: failed to decode PUBLISH: %v", tt.name, d.err)
+			continue
+		}
+		if packetId != tt.packetId {
+			t.Errorf("%s: packet id decoded as %d, want %d", tt.name, packetId, tt.packetId)
+		}
+		if !reflect.DeepEqual(*got, tt.msg) {
+			t.Errorf("%s: message decoded as %+v, want %+v", tt.name, *got, tt.msg)
+		}
+	}
+}
+
+func TestPublishMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags byte
+		body  []byte
+	}{
+		{"empty", 0x00, []byte{}},
+		{"truncated topic", 0x00, []byte{0x00, 0x05, 'a', 'b'}},
+		{"QoS 1 without packet id", 0x02, []byte{0x00, 0x01, 'a', 0x00}},
+		{"missing properties", 0x00, []byte{0x00, 0x01, 'a'}},
+		{"properties past the end", 0x00, []byte{0x00, 0x01, 'a', 0x09, 0x02}},
+	}
+
+	for _, tt := range tests {
+		d := &decoder{data: tt.body}
+		decodePublish(d, tt.flags)
+		if d.err == nil {
+			t.Errorf("%s: PUBLISH %x decoded without error", tt.name, tt.body)
+		}
+	}
+}
+
+func TestReadPacket(t *testing.T) {
+	// Remaining length on two bytes
+	body := bytes.Repeat([]byte{0xAB}, 200)
+	e := &encoder{}
+	e.buf.Write(body)
+
+	packetType, flags, got, err := readPacket(bufio.NewReader(bytes.NewReader(e.packet(packetSubscribe, 0x02))))
+	if err != nil {
+		t.Fatalf("failed to read packet: %v", err)
+	}
+	if packetType != packetSubscribe || flags != 0x02 || !bytes.Equal(got, body) {
+		t.Errorf("read packet type %d flags %x body of %d bytes", packetType, flags, len(got))
+	}
+
+	// Two packets one after the other
+	reader := bufio.NewReader(bytes.NewReader(append((&encoder{}).packet(packetPingreq, 0), (&encoder{}).packet(packetPingresp, 0)...)))
+	for _, want := range []byte{packetPingreq, packetPingresp} {
+		packetType, _, body, err := readPacket(reader)
+		if err != nil || packetType != want || len(body) != 0 {
+			t.Errorf("read packet type %d body %x %v, want %d", packetType, body, err, want)
+		}
+	}
+	if _, _, _, err := readPacket(reader); err != io.EOF {
+		t.Errorf("read past the last packet returned %v, want EOF", err)
+	}
+}
+
+func TestReadPacketMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", []byte{}, io.EOF},
+		{"missing remaining length", []byte{0x30}, io.EOF},
+		{"truncated remaining length", []byte{0x30, 0x80}, io.EOF},
+		{"remaining length of five bytes", []byte{0x30, 0xFF, 0xFF, 0xFF, 0xFF, 0x01}, errMalformed},
+		{"truncated body", []byte{0x30, 0x05, 0x00, 0x01}, io.ErrUnexpectedEOF},
+		{"missing body", []byte{0x30, 0x02}, io.EOF},
+	}
+
+	for _, tt := range tests {
+		_, _, _, err := readPacket(bufio.NewReader(bytes.NewReader(tt.data)))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: read packet %x returned %v, want %v", tt.name, tt.data, err, tt.want)
+		}
+	}
+}
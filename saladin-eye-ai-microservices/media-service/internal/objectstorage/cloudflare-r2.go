@@ -1,9 +1,11 @@
 package objectstorage
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -170,3 +172,82 @@ func (objs *CloudflareR2) ListHourByDate(ctx context.Context, deviceId, date str
 
 	return hourList, nil
 }
+
+// GetObject downloads the object, it fails when the object is larger than
+// maxSize bytes rather than reading it all into memory
+func (objs *CloudflareR2) GetObject(ctx context.Context, path string, maxSize int64) ([]byte, error) {
+	resp, err := objs.s3Client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(objs.bucketName),
+		Key:    aws.String(path),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object body: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("object %s is larger than %d bytes", path, maxSize)
+	}
+
+	return data, nil
+}
+
+func (objs *CloudflareR2) PutObject(ctx context.Context, path string, data []byte, contentType string) error {
+	_, err := objs.s3Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(objs.bucketName),
+		Key:         aws.String(path),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteObjectsByPrefix deletes every object under the prefix, a page of the
+// listing at a time, and returns how many were deleted
+func (objs *CloudflareR2) DeleteObjectsByPrefix(ctx context.Context, prefix string) (int, error) {
+	deleted := 0
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(objs.bucketName),
+		Prefix: aws.String(prefix),
+	}
+
+	for {
+		resp, err := objs.s3Client.ListObjectsV2WithContext(ctx, input)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		// A page has at most 1000 objects, as many as one delete can take
+		if len(resp.Contents) > 0 {
+			objects := make([]*s3.ObjectIdentifier, 0, len(resp.Contents))
+			for _, item := range resp.Contents {
+				objects = append(objects, &s3.ObjectIdentifier{Key: item.Key})
+			}
+
+			_, err := objs.s3Client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String(objs.bucketName),
+				Delete: &s3.Delete{
+					Objects: objects,
+					Quiet:   aws.Bool(true),
+				},
+			})
+			if err != nil {
+				return deleted, fmt.Errorf("failed to delete objects: %w", err)
+			}
+			deleted += len(objects)
+		}
+
+		if !aws.BoolValue(resp.IsTruncated) {
+			return deleted, nil
+		}
+		input.ContinuationToken = resp.NextContinuationToken
+	}
+}
@@ -9,4 +9,7 @@ type ObjectStorageIface interface {
 	ListObjectsByPrefix(ctx context.Context, prefix string) ([]string, error)
 	ListDate(ctx context.Context, deviceId string) ([]string, error)
 	ListHourByDate(ctx context.Context, deviceId, date string) ([]string, error)
+	GetObject(ctx context.Context, path string, maxSize int64) ([]byte, error)
+	PutObject(ctx context.Context, path string, data []byte, contentType string) error
+	DeleteObjectsByPrefix(ctx context.Context, prefix string) (int, error)
 }
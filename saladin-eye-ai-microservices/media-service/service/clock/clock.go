@@ -0,0 +1,134 @@
+package clock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+// The devices by the absolute skew of their clock, to list the worst first
+const skewsRedisKey = "media-service:device-clock-skews"
+
+type ClockServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) ClockServiceIface {
+	return &ClockServiceImpl{
+		rdb: rdb,
+	}
+}
+
+func clockRedisKey(deviceId string) string {
+	return fmt.Sprintf("media-service:device-clock:%s", deviceId)
+}
+
+/**
+ * Record the last measured clock of the device:
+ *   media-service:device-clock:[deviceId]
+ *
+ * A device that stops syncing drops out after DEVICE_CLOCK_TTL_DAYS.
+ */
+func (cs *ClockServiceImpl) Record(ctx context.Context, clock *Clock) error {
+	clockJson, err := json.Marshal(clock)
+	if err != nil {
+		log.Error().Msgf("failed to marshal device clock: %v", err)
+		return fmt.Errorf("failed to marshal device clock: %w", err)
+	}
+
+	_, err = cs.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, clockRedisKey(clock.DeviceId), clockJson, constants.DEVICE_CLOCK_TTL_DAYS*24*time.Hour)
+		pipe.ZAdd(ctx, skewsRedisKey, redis.Z{Score: float64(absMs(clock.SkewMs)), Member: clock.DeviceId})
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to set device clock in Redis: %v", err)
+		return fmt.Errorf("failed to set device clock in Redis: %w", err)
+	}
+
+	if absMs(clock.SkewMs) >= constants.DEVICE_CLOCK_SKEW_THRESHOLD_MS {
+		log.Warn().Msgf("clock of device_id %s is off by %d ms", clock.DeviceId, clock.SkewMs)
+	}
+
+	return nil
+}
+
+// The devices whose clock is off by at least minSkewMs, the worst first
+func (cs *ClockServiceImpl) List(ctx context.Context, minSkewMs uint32, limit uint32) ([]*Clock, error) {
+	if minSkewMs == 0 {
+		minSkewMs = constants.DEVICE_CLOCK_SKEW_THRESHOLD_MS
+	}
+
+	if limit == 0 {
+		limit = constants.DEVICE_CLOCK_DEFAULT_LIST_SIZE
+	}
+	if limit > constants.DEVICE_CLOCK_MAX_LIST_SIZE {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be at most %d", constants.DEVICE_CLOCK_MAX_LIST_SIZE)
+	}
+
+	deviceIds, err := cs.rdb.ZRevRangeByScore(ctx, skewsRedisKey, &redis.ZRangeBy{
+		Min:   fmt.Sprint(minSkewMs),
+		Max:   "+inf",
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get device clock skews from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device clock skews from Redis: %w", err)
+	}
+
+	clocks := make([]*Clock, 0, len(deviceIds))
+	if len(deviceIds) == 0 {
+		return clocks, nil
+	}
+
+	keys := make([]string, 0, len(deviceIds))
+	for _, deviceId := range deviceIds {
+		keys = append(keys, clockRedisKey(deviceId))
+	}
+
+	values, err := cs.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get device clocks from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device clocks from Redis: %w", err)
+	}
+
+	expired := make([]interface{}, 0)
+	for i, value := range values {
+		clockJson, ok := value.(string)
+		if !ok {
+			expired = append(expired, deviceIds[i])
+			continue
+		}
+
+		var clock Clock
+		if err := json.Unmarshal([]byte(clockJson), &clock); err != nil {
+			log.Error().Msgf("failed to unmarshal device clock: %v", err)
+			return nil, fmt.Errorf("failed to unmarshal device clock: %w", err)
+		}
+		clocks = append(clocks, &clock)
+	}
+
+	// The clock has expired, the device is not in the list anymore
+	if len(expired) > 0 {
+		if err := cs.rdb.ZRem(ctx, skewsRedisKey, expired...).Err(); err != nil {
+			log.Warn().Msgf("failed to remove expired device clocks from Redis: %v", err)
+		}
+	}
+
+	return clocks, nil
+}
+
+func absMs(ms int64) int64 {
+	if ms < 0 {
+		return -ms
+	}
+	return ms
+}
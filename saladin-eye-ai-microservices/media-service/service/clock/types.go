@@ -0,0 +1,18 @@
+package clock
+
+import "context"
+
+// Clock is the skew of a device clock, device time minus server time. The
+// round trip is 0 when the skew is only estimated from the one-way trip of a
+// request, the estimate is then off by the time the request took.
+type Clock struct {
+	DeviceId    string `json:"device_id"`
+	SkewMs      int64  `json:"skew_ms"`
+	RoundTripMs uint32 `json:"round_trip_ms"`
+	MeasuredAt  int64  `json:"measured_at"`
+}
+
+type ClockServiceIface interface {
+	Record(ctx context.Context, clock *Clock) error
+	List(ctx context.Context, minSkewMs uint32, limit uint32) ([]*Clock, error)
+}
@@ -0,0 +1,57 @@
+package deviceconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/genproto"
+)
+
+type DeviceConfigServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+/**
+ * The configurations of the devices are kept by camera-service, a DeviceConfig
+ * per device:
+ *   saladin-eye:camera-service:device-config:[deviceId]
+ *
+ * Only read here, for the devices asking for it over MQTT.
+ */
+func New(rdb redis.Cmdable) DeviceConfigServiceIface {
+	return &DeviceConfigServiceImpl{
+		rdb: rdb,
+	}
+}
+
+func deviceConfigRedisKey(deviceId string) string {
+	return fmt.Sprintf("saladin-eye:camera-service:device-config:%s", deviceId)
+}
+
+/**
+ * Return the configuration of the device, nil when it has none and keeps its
+ * built-in defaults.
+ */
+func (ds *DeviceConfigServiceImpl) Get(ctx context.Context, deviceId string) (*genproto.DeviceConfig, error) {
+	configByteArr, err := ds.rdb.Get(ctx, deviceConfigRedisKey(deviceId)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		log.Error().Msgf("failed to get device config from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device config from Redis: %w", err)
+	}
+
+	config := &genproto.DeviceConfig{}
+	if err := proto.Unmarshal(configByteArr, config); err != nil {
+		log.Error().Msgf("failed to unmarshal DeviceConfig: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal DeviceConfig: %w", err)
+	}
+
+	return config, nil
+}
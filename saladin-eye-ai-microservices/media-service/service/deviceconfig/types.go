@@ -0,0 +1,11 @@
+package deviceconfig
+
+import (
+	"context"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/genproto"
+)
+
+type DeviceConfigServiceIface interface {
+	Get(ctx context.Context, deviceId string) (*genproto.DeviceConfig, error)
+}
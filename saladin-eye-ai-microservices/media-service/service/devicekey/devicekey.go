@@ -0,0 +1,273 @@
+package devicekey
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+type DeviceKeyServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) DeviceKeyServiceIface {
+	return &DeviceKeyServiceImpl{
+		rdb: rdb,
+	}
+}
+
+func keysRedisKey(deviceId string) string {
+	return fmt.Sprintf("media-service:device-keys:%s", deviceId)
+}
+
+func validateDeviceId(deviceId string) error {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	return nil
+}
+
+/**
+ * Register a new key of the device, stored in the device's hash:
+ *   media-service:device-keys:[deviceId]
+ *
+ * The HMAC secret is generated here, the caller provisions it on the device.
+ * An Ed25519 key is the public key of a key pair made on the device.
+ *
+ * A device can have up to DEVICE_KEY_MAX_KEYS keys, so a new key can be
+ * rolled out before the old one is revoked.
+ */
+func (ds *DeviceKeyServiceImpl) RegisterKey(ctx context.Context, deviceId, algorithm string, publicKey []byte) (*Key, error) {
+	if err := validateDeviceId(deviceId); err != nil {
+		return nil, err
+	}
+
+	key := &Key{
+		Algorithm: algorithm,
+		CreatedAt: time.Now().UTC().Unix(),
+	}
+
+	switch algorithm {
+	case ALGORITHM_HMAC_SHA256:
+		if len(publicKey) > 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "HMAC secrets are generated by the server, public_key must be empty")
+		}
+
+		key.Key = make([]byte, constants.DEVICE_KEY_HMAC_SECRET_LENGTH)
+		if _, err := rand.Read(key.Key); err != nil {
+			return nil, fmt.Errorf("failed to generate HMAC secret: %w", err)
+		}
+	case ALGORITHM_ED25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid Ed25519 public key length: %d", len(publicKey))
+		}
+		key.Key = publicKey
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported algorithm: %s", algorithm)
+	}
+
+	keyIdBytes := make([]byte, 8)
+	if _, err := rand.Read(keyIdBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate key id: %w", err)
+	}
+	key.KeyId = hex.EncodeToString(keyIdBytes)
+
+	count, err := ds.rdb.HLen(ctx, keysRedisKey(deviceId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to count device keys in Redis: %v", err)
+		return nil, fmt.Errorf("failed to count device keys in Redis: %w", err)
+	}
+	if count >= constants.DEVICE_KEY_MAX_KEYS {
+		return nil, status.Errorf(codes.FailedPrecondition, "device_id %s already has %d keys, revoke one first", deviceId, count)
+	}
+
+	keyJson, err := json.Marshal(key)
+	if err != nil {
+		log.Error().Msgf("failed to marshal device key: %v", err)
+		return nil, fmt.Errorf("failed to marshal device key: %w", err)
+	}
+
+	if err := ds.rdb.HSet(ctx, keysRedisKey(deviceId), key.KeyId, keyJson).Err(); err != nil {
+		log.Error().Msgf("failed to set device key in Redis: %v", err)
+		return nil, fmt.Errorf("failed to set device key in Redis: %w", err)
+	}
+
+	log.Info().Msgf("registered %s key %s of device_id %s", algorithm, key.KeyId, deviceId)
+
+	return key, nil
+}
+
+// Revoke the key, the requests signed with it are rejected from now on
+func (ds *DeviceKeyServiceImpl) RevokeKey(ctx context.Context, deviceId, keyId string) error {
+	if err := validateDeviceId(deviceId); err != nil {
+		return err
+	}
+
+	deleted, err := ds.rdb.HDel(ctx, keysRedisKey(deviceId), keyId).Result()
+	if err != nil {
+		log.Error().Msgf("failed to delete device key from Redis: %v", err)
+		return fmt.Errorf("failed to delete device key from Redis: %w", err)
+	}
+	if deleted == 0 {
+		return status.Errorf(codes.NotFound, "key %s of device_id %s not found", keyId, deviceId)
+	}
+
+	log.Info().Msgf("revoked key %s of device_id %s", keyId, deviceId)
+
+	return nil
+}
+
+// The keys of the device, oldest first
+func (ds *DeviceKeyServiceImpl) ListKeys(ctx context.Context, deviceId string) ([]*Key, error) {
+	if err := validateDeviceId(deviceId); err != nil {
+		return nil, err
+	}
+
+	keysJson, err := ds.rdb.HGetAll(ctx, keysRedisKey(deviceId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get device keys from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device keys from Redis: %w", err)
+	}
+
+	keys := make([]*Key, 0, len(keysJson))
+	for _, keyJson := range keysJson {
+		var key Key
+		if err := json.Unmarshal([]byte(keyJson), &key); err != nil {
+			log.Error().Msgf("failed to unmarshal device key: %v", err)
+			return nil, fmt.Errorf("failed to unmarshal device key: %w", err)
+		}
+		keys = append(keys, &key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt != keys[j].CreatedAt {
+			return keys[i].CreatedAt < keys[j].CreatedAt
+		}
+		return keys[i].KeyId < keys[j].KeyId
+	})
+
+	return keys, nil
+}
+
+// HasKeys is true once a key of the device is registered, its requests must
+// then be signed
+func (ds *DeviceKeyServiceImpl) HasKeys(ctx context.Context, deviceId string) (bool, error) {
+	count, err := ds.rdb.HLen(ctx, keysRedisKey(deviceId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to count device keys in Redis: %v", err)
+		return false, fmt.Errorf("failed to count device keys in Redis: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+/**
+ * Authenticate a signed request of the device: the key must be one of the
+ * device, the signature valid, the timestamp within
+ * SIGNED_REQUEST_MAX_SKEW_SECONDS of the server clock and the nonce not used
+ * before. The nonces are kept for as long as their timestamp is accepted:
+ *   media-service:request-nonce:[deviceId]:[nonce]
+ *
+ * The nonce holds the hash of the signed data. The same message delivered
+ * again, by the broker for QoS 1 or to another instance, is authentic again,
+ * the router then replays its idempotent response. Only another message with
+ * the nonce is rejected.
+ *
+ * With AnyTimestamp, for the device asking for the server time, the timestamp
+ * is not checked, only the nonce stops a replay. Its nonce is then kept for
+ * SIGNED_REQUEST_ANY_TIMESTAMP_NONCE_TTL_HOURS instead.
+ *
+ * Returns an Unauthenticated status error when the request is not authentic.
+ */
+func (ds *DeviceKeyServiceImpl) Authenticate(ctx context.Context, deviceId string, request SignedRequest) error {
+	if len(request.Nonce) < constants.SIGNED_REQUEST_MIN_NONCE_LENGTH || len(request.Nonce) > constants.SIGNED_REQUEST_MAX_NONCE_LENGTH {
+		return status.Errorf(codes.Unauthenticated, "invalid nonce length: %d", len(request.Nonce))
+	}
+
+	nonceTTL := 2 * constants.SIGNED_REQUEST_MAX_SKEW_SECONDS * time.Second
+	if request.AnyTimestamp {
+		nonceTTL = constants.SIGNED_REQUEST_ANY_TIMESTAMP_NONCE_TTL_HOURS * time.Hour
+	} else {
+		skew := time.Since(time.Unix(request.Timestamp, 0))
+		if skew > constants.SIGNED_REQUEST_MAX_SKEW_SECONDS*time.Second || skew < -constants.SIGNED_REQUEST_MAX_SKEW_SECONDS*time.Second {
+			return status.Errorf(codes.Unauthenticated, "request timestamp %d is too far from the server time", request.Timestamp)
+		}
+	}
+
+	keyJson, err := ds.rdb.HGet(ctx, keysRedisKey(deviceId), request.KeyId).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return status.Errorf(codes.Unauthenticated, "unknown key %s of device_id %s", request.KeyId, deviceId)
+		}
+
+		log.Error().Msgf("failed to get device key from Redis: %v", err)
+		return fmt.Errorf("failed to get device key from Redis: %w", err)
+	}
+
+	var key Key
+	if err := json.Unmarshal([]byte(keyJson), &key); err != nil {
+		log.Error().Msgf("failed to unmarshal device key: %v", err)
+		return fmt.Errorf("failed to unmarshal device key: %w", err)
+	}
+
+	if !verifySignature(&key, request.SignedData, request.Signature) {
+		return status.Errorf(codes.Unauthenticated, "invalid signature")
+	}
+
+	// Only a valid signature uses up the nonce, a forged request can't burn
+	// the nonce of a real one
+	signedDataSha256 := sha256.Sum256(request.SignedData)
+	signedDataHash := hex.EncodeToString(signedDataSha256[:])
+
+	nonceKey := fmt.Sprintf("media-service:request-nonce:%s:%s", deviceId, hex.EncodeToString(request.Nonce))
+	fresh, err := ds.rdb.SetNX(ctx, nonceKey, signedDataHash, nonceTTL).Result()
+	if err != nil {
+		log.Error().Msgf("failed to set request nonce in Redis: %v", err)
+		return fmt.Errorf("failed to set request nonce in Redis: %w", err)
+	}
+	if fresh {
+		return nil
+	}
+
+	usedBy, err := ds.rdb.Get(ctx, nonceKey).Result()
+	if err != nil && err != redis.Nil {
+		log.Error().Msgf("failed to get request nonce from Redis: %v", err)
+		return fmt.Errorf("failed to get request nonce from Redis: %w", err)
+	}
+	if usedBy != signedDataHash {
+		return status.Errorf(codes.Unauthenticated, "nonce already used")
+	}
+
+	log.Info().Msgf("request of device_id %s with nonce %s delivered again", deviceId, hex.EncodeToString(request.Nonce))
+
+	return nil
+}
+
+func verifySignature(key *Key, signedData, signature []byte) bool {
+	switch key.Algorithm {
+	case ALGORITHM_HMAC_SHA256:
+		mac := hmac.New(sha256.New, key.Key)
+		mac.Write(signedData)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case ALGORITHM_ED25519:
+		return len(key.Key) == ed25519.PublicKeySize && ed25519.Verify(key.Key, signedData, signature)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,54 @@
+package devicekey
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestVerifySignature(t *testing.T) {
+	signedData := []byte("get-server-time/ABCDEF123/key-a")
+
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(signedData)
+	hmacSignature := mac.Sum(nil)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	ed25519Signature := ed25519.Sign(privateKey, signedData)
+
+	otherPublicKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		key        *Key
+		signedData []byte
+		signature  []byte
+		valid      bool
+	}{
+		{"hmac", &Key{Algorithm: ALGORITHM_HMAC_SHA256, Key: secret}, signedData, hmacSignature, true},
+		{"hmac other data", &Key{Algorithm: ALGORITHM_HMAC_SHA256, Key: secret}, []byte("get-server-time/ABCDEF123/key-b"), hmacSignature, false},
+		{"hmac other secret", &Key{Algorithm: ALGORITHM_HMAC_SHA256, Key: []byte("another secret")}, signedData, hmacSignature, false},
+		{"hmac cut signature", &Key{Algorithm: ALGORITHM_HMAC_SHA256, Key: secret}, signedData, hmacSignature[:16], false},
+		{"hmac empty signature", &Key{Algorithm: ALGORITHM_HMAC_SHA256, Key: secret}, signedData, nil, false},
+		{"ed25519", &Key{Algorithm: ALGORITHM_ED25519, Key: publicKey}, signedData, ed25519Signature, true},
+		{"ed25519 other data", &Key{Algorithm: ALGORITHM_ED25519, Key: publicKey}, []byte("get-server-time/ABCDEF123/key-b"), ed25519Signature, false},
+		{"ed25519 other key", &Key{Algorithm: ALGORITHM_ED25519, Key: otherPublicKey}, signedData, ed25519Signature, false},
+		{"ed25519 short key", &Key{Algorithm: ALGORITHM_ED25519, Key: publicKey[:16]}, signedData, ed25519Signature, false},
+		{"ed25519 signature as hmac", &Key{Algorithm: ALGORITHM_ED25519, Key: publicKey}, signedData, hmacSignature, false},
+		{"unknown algorithm", &Key{Algorithm: "rsa", Key: secret}, signedData, hmacSignature, false},
+	}
+
+	for _, tt := range tests {
+		if got := verifySignature(tt.key, tt.signedData, tt.signature); got != tt.valid {
+			t.Errorf("%s: verifySignature is %v, want %v", tt.name, got, tt.valid)
+		}
+	}
+}
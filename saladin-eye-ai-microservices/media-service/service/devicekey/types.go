@@ -0,0 +1,36 @@
+package devicekey
+
+import "context"
+
+const (
+	ALGORITHM_HMAC_SHA256 = "hmac-sha256"
+	ALGORITHM_ED25519     = "ed25519"
+)
+
+// Key is the HMAC secret, or the Ed25519 public key, of a device
+type Key struct {
+	KeyId     string `json:"key_id"`
+	Algorithm string `json:"algorithm"`
+	Key       []byte `json:"key"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// SignedRequest is what a device sent to be authenticated, SignedData is the
+// data the signature is over. AnyTimestamp accepts the request however far
+// its timestamp is from the server clock, for the clock sync of the device.
+type SignedRequest struct {
+	KeyId        string
+	Timestamp    int64
+	Nonce        []byte
+	SignedData   []byte
+	Signature    []byte
+	AnyTimestamp bool
+}
+
+type DeviceKeyServiceIface interface {
+	RegisterKey(ctx context.Context, deviceId, algorithm string, publicKey []byte) (*Key, error)
+	RevokeKey(ctx context.Context, deviceId, keyId string) error
+	ListKeys(ctx context.Context, deviceId string) ([]*Key, error)
+	HasKeys(ctx context.Context, deviceId string) (bool, error)
+	Authenticate(ctx context.Context, deviceId string, request SignedRequest) error
+}
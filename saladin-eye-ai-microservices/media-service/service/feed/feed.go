@@ -0,0 +1,163 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/cache"
+)
+
+const (
+	channelPrefix = "media-service:events:photo-confirmed:"
+
+	// Events buffered per subscriber before the oldest ones are dropped
+	subscriptionBufferSize = 16
+)
+
+// Singleton, the photos are confirmed by the MQTT handler process and watched
+// through the gRPC server process, one Redis subscription per process is
+// shared by all the subscribers
+var (
+	hubOnce sync.Once
+	hubMu   sync.Mutex
+	hubSubs = make(map[*Subscription]bool)
+)
+
+type FeedServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) FeedServiceIface {
+	return &FeedServiceImpl{
+		rdb: rdb,
+	}
+}
+
+/**
+ * Publish the confirmed photo to every watcher, and keep it as the latest
+ * photo of the device.
+ */
+func (fs *FeedServiceImpl) Publish(ctx context.Context, event PhotoConfirmedEvent) error {
+	eventJson, err := json.Marshal(event)
+	if err != nil {
+		log.Error().Msgf("failed to marshal photo confirmed event: %v", err)
+		return fmt.Errorf("failed to marshal photo confirmed event: %w", err)
+	}
+
+	latestKey := fmt.Sprintf("media-service:latest-photo:%s", event.DeviceId)
+	if _, err := fs.rdb.Set(ctx, latestKey, eventJson, 0).Result(); err != nil {
+		log.Error().Msgf("failed to set latest photo in Redis: %v", err)
+		return fmt.Errorf("failed to set latest photo in Redis: %w", err)
+	}
+
+	if _, err := fs.rdb.Publish(ctx, channelPrefix+event.DeviceId, eventJson).Result(); err != nil {
+		log.Error().Msgf("failed to publish photo confirmed event: %v", err)
+		return fmt.Errorf("failed to publish photo confirmed event: %w", err)
+	}
+
+	return nil
+}
+
+/**
+ * Return the latest confirmed photo of the device, nil when there is none.
+ */
+func (fs *FeedServiceImpl) GetLatest(ctx context.Context, deviceId string) (*PhotoConfirmedEvent, error) {
+	latestKey := fmt.Sprintf("media-service:latest-photo:%s", deviceId)
+	eventJson, err := fs.rdb.Get(ctx, latestKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		log.Error().Msgf("failed to get latest photo from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get latest photo from Redis: %w", err)
+	}
+
+	var event PhotoConfirmedEvent
+	if err := json.Unmarshal([]byte(eventJson), &event); err != nil {
+		log.Error().Msgf("failed to unmarshal latest photo: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal latest photo: %w", err)
+	}
+
+	return &event, nil
+}
+
+func (fs *FeedServiceImpl) Subscribe(deviceIds []string) *Subscription {
+	hubOnce.Do(func() {
+		go runHub()
+	})
+
+	events := make(chan PhotoConfirmedEvent, subscriptionBufferSize)
+	sub := &Subscription{
+		C:         events,
+		events:    events,
+		deviceIds: make(map[string]bool),
+	}
+	for _, deviceId := range deviceIds {
+		sub.deviceIds[deviceId] = true
+	}
+
+	hubMu.Lock()
+	hubSubs[sub] = true
+	hubMu.Unlock()
+
+	return sub
+}
+
+func (fs *FeedServiceImpl) Unsubscribe(sub *Subscription) {
+	hubMu.Lock()
+	delete(hubSubs, sub)
+	hubMu.Unlock()
+}
+
+// runHub receives the events of every device from Redis and hands them to the
+// matching subscribers, it runs for the lifetime of the process
+func runHub() {
+	pubsub := cache.PSubscribe(context.Background(), channelPrefix+"*")
+	log.Info().Msg("subscribed to photo confirmed events")
+
+	for msg := range pubsub.Channel() {
+		var event PhotoConfirmedEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			log.Error().Msgf("failed to unmarshal photo confirmed event on %s: %v", msg.Channel, err)
+			continue
+		}
+
+		if event.DeviceId == "" {
+			event.DeviceId = strings.TrimPrefix(msg.Channel, channelPrefix)
+		}
+
+		dispatch(event)
+	}
+}
+
+func dispatch(event PhotoConfirmedEvent) {
+	hubMu.Lock()
+	defer hubMu.Unlock()
+
+	for sub := range hubSubs {
+		if len(sub.deviceIds) > 0 && !sub.deviceIds[event.DeviceId] {
+			continue
+		}
+
+		// Never block the hub on a slow subscriber, make room by dropping the
+		// oldest event, the newest photo is the one that matters.
+		// The hub is the only sender, so there is room after one receive.
+		select {
+		case sub.events <- event:
+		default:
+			select {
+			case <-sub.events:
+				sub.dropped.Add(1)
+			default:
+			}
+			sub.events <- event
+		}
+	}
+}
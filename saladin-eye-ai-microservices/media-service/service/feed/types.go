@@ -0,0 +1,38 @@
+package feed
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type PhotoConfirmedEvent struct {
+	DeviceId     string   `json:"device_id"`
+	PhotoPath    string   `json:"photo_path"`
+	QualityFlags []string `json:"quality_flags"`
+	ConfirmedAt  int64    `json:"confirmed_at"`
+}
+
+// Subscription receives the confirmed photos of the devices it filters on,
+// or of every device when the filter is empty.
+//
+// A subscriber that does not keep up only gets the newest events, the older
+// ones are dropped and counted.
+type Subscription struct {
+	C <-chan PhotoConfirmedEvent
+
+	events    chan PhotoConfirmedEvent
+	deviceIds map[string]bool
+	dropped   atomic.Int64
+}
+
+// Dropped returns the number of events dropped since the last call
+func (sub *Subscription) Dropped() int64 {
+	return sub.dropped.Swap(0)
+}
+
+type FeedServiceIface interface {
+	Publish(ctx context.Context, event PhotoConfirmedEvent) error
+	GetLatest(ctx context.Context, deviceId string) (*PhotoConfirmedEvent, error)
+	Subscribe(deviceIds []string) *Subscription
+	Unsubscribe(sub *Subscription)
+}
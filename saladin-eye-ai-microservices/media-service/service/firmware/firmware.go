@@ -0,0 +1,229 @@
+package firmware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/media-service/internal/objectstorage"
+)
+
+const releasesRedisKey = "media-service:firmware:releases"
+
+var (
+	versionPattern = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z.+-]{0,31}$`)
+	sha256Pattern  = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+type FirmwareServiceImpl struct {
+	objStorage objectstorage.ObjectStorageIface
+	rdb        redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) (FirmwareServiceIface, error) {
+	objs, err := objectstorage.New(objectstorage.ProviderCloudflareR2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firmware service: %w", err)
+	}
+
+	return &FirmwareServiceImpl{
+		objStorage: objs,
+		rdb:        rdb,
+	}, nil
+}
+
+func releaseRedisKey(version string) string {
+	return fmt.Sprintf("media-service:firmware:release:%s", version)
+}
+
+// The images are kept apart from the photos, which are under the device ids.
+// The upload URL can be used again until it expires, so the devices are never
+// served this path.
+func releasePath(version string) string {
+	return fmt.Sprintf("firmware/%s/firmware.bin", version)
+}
+
+// The published image, named by its checksum so it never changes once written
+func imagePath(version, sha256Hex string) string {
+	return fmt.Sprintf("firmware/%s/%s.bin", version, sha256Hex)
+}
+
+/**
+ * Create a release, pending until its image is uploaded to the returned
+ * presigned URL and the release is published:
+ *   firmware/[version]/firmware.bin
+ *
+ * A pending release can be created again, to fix its checksum or size, a
+ * published one can't be replaced.
+ *
+ * Returns the release and the upload URL.
+ */
+func (fs *FirmwareServiceImpl) CreateRelease(ctx context.Context, version, sha256Hex string, size int64, notes string) (*Release, string, error) {
+	if !versionPattern.MatchString(version) {
+		return nil, "", status.Errorf(codes.InvalidArgument, "invalid version: %s", version)
+	}
+
+	sha256Hex = strings.ToLower(strings.TrimSpace(sha256Hex))
+	if !sha256Pattern.MatchString(sha256Hex) {
+		return nil, "", status.Errorf(codes.InvalidArgument, "sha256 must be 64 hex characters")
+	}
+
+	if size <= 0 || size > constants.FIRMWARE_MAX_SIZE_BYTES {
+		return nil, "", status.Errorf(codes.InvalidArgument, "size must be 1 to %d bytes", constants.FIRMWARE_MAX_SIZE_BYTES)
+	}
+
+	existing, err := fs.getRelease(ctx, version)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, "", err
+	}
+	if existing != nil && existing.Status == RELEASE_STATUS_READY {
+		return nil, "", status.Errorf(codes.AlreadyExists, "firmware %s is already published", version)
+	}
+
+	release := &Release{
+		Version:   version,
+		Sha256:    sha256Hex,
+		Size:      size,
+		Status:    RELEASE_STATUS_PENDING_UPLOAD,
+		Notes:     strings.TrimSpace(notes),
+		CreatedAt: time.Now().UTC().Unix(),
+	}
+
+	uploadUrl, err := fs.objStorage.GeneratePresignedUploadUrl(ctx, releasePath(version), constants.FIRMWARE_UPLOAD_URL_EXPIRATION_MINUTES)
+	if err != nil {
+		log.Error().Msgf("failed to generate presigned URL: %v", err)
+		return nil, "", fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+
+	if err := fs.saveRelease(ctx, release); err != nil {
+		return nil, "", err
+	}
+
+	log.Info().Msgf("created firmware release %s", version)
+
+	return release, uploadUrl, nil
+}
+
+/**
+ * Publish the release once its image is uploaded. The image is downloaded
+ * back and must match the size and the checksum of the release, only then can
+ * it be rolled out.
+ *
+ * The checked image is copied to its own path, the one the devices are
+ * served, so writing to the upload path afterwards changes nothing:
+ *   firmware/[version]/[sha256].bin
+ */
+func (fs *FirmwareServiceImpl) PublishRelease(ctx context.Context, version string) (*Release, error) {
+	release, err := fs.getRelease(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if release.Status == RELEASE_STATUS_READY {
+		return release, nil
+	}
+
+	data, err := fs.objStorage.GetObject(ctx, releasePath(version), constants.FIRMWARE_MAX_SIZE_BYTES)
+	if err != nil {
+		log.Error().Msgf("failed to get firmware from object storage: %v", err)
+		return nil, status.Errorf(codes.FailedPrecondition, "firmware %s is not uploaded yet", version)
+	}
+
+	if int64(len(data)) != release.Size {
+		return nil, status.Errorf(codes.FailedPrecondition, "uploaded firmware is %d bytes, expected %d", len(data), release.Size)
+	}
+
+	checksum := sha256.Sum256(data)
+	if hex.EncodeToString(checksum[:]) != release.Sha256 {
+		return nil, status.Errorf(codes.FailedPrecondition, "uploaded firmware does not match sha256 %s", release.Sha256)
+	}
+
+	if err := fs.objStorage.PutObject(ctx, imagePath(version, release.Sha256), data, "application/octet-stream"); err != nil {
+		log.Error().Msgf("failed to put firmware to object storage: %v", err)
+		return nil, fmt.Errorf("failed to put firmware to object storage: %w", err)
+	}
+
+	release.Status = RELEASE_STATUS_READY
+	release.PublishedAt = time.Now().UTC().Unix()
+	if err := fs.saveRelease(ctx, release); err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("published firmware release %s", version)
+
+	return release, nil
+}
+
+// The releases, newest first
+func (fs *FirmwareServiceImpl) ListReleases(ctx context.Context) ([]*Release, error) {
+	versions, err := fs.rdb.ZRevRange(ctx, releasesRedisKey, 0, -1).Result()
+	if err != nil {
+		log.Error().Msgf("failed to list firmware releases from Redis: %v", err)
+		return nil, fmt.Errorf("failed to list firmware releases from Redis: %w", err)
+	}
+
+	releases := make([]*Release, 0, len(versions))
+	for _, version := range versions {
+		release, err := fs.getRelease(ctx, version)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				continue
+			}
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+
+	return releases, nil
+}
+
+func (fs *FirmwareServiceImpl) getRelease(ctx context.Context, version string) (*Release, error) {
+	releaseJson, err := fs.rdb.Get(ctx, releaseRedisKey(version)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, status.Errorf(codes.NotFound, "firmware %s not found", version)
+		}
+
+		log.Error().Msgf("failed to get firmware release from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get firmware release from Redis: %w", err)
+	}
+
+	var release Release
+	if err := json.Unmarshal([]byte(releaseJson), &release); err != nil {
+		log.Error().Msgf("failed to unmarshal firmware release: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal firmware release: %w", err)
+	}
+
+	return &release, nil
+}
+
+func (fs *FirmwareServiceImpl) saveRelease(ctx context.Context, release *Release) error {
+	releaseJson, err := json.Marshal(release)
+	if err != nil {
+		log.Error().Msgf("failed to marshal firmware release: %v", err)
+		return fmt.Errorf("failed to marshal firmware release: %w", err)
+	}
+
+	_, err = fs.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, releaseRedisKey(release.Version), releaseJson, 0)
+		pipe.ZAdd(ctx, releasesRedisKey, redis.Z{Score: float64(release.CreatedAt), Member: release.Version})
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to set firmware release in Redis: %v", err)
+		return fmt.Errorf("failed to set firmware release in Redis: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,550 @@
+package firmware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+const maxReportMessageLength = 128
+
+// Set the status of the device unless its update is finished already, and
+// count it when it finishes, in one step. Returns 0 when the update was
+// finished, a retried or concurrent report then counts only once.
+var reportScript = redis.NewScript(`
+local previous = redis.call('HGET', KEYS[1], ARGV[1])
+if previous then
+	local previousStatus = cjson.decode(previous).status
+	if previousStatus == ARGV[4] or previousStatus == ARGV[5] then
+		return 0
+	end
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+if ARGV[3] == ARGV[4] or ARGV[3] == ARGV[5] then
+	redis.call('HINCRBY', KEYS[2], ARGV[3], 1)
+end
+return 1
+`)
+
+// Set only the given fields of the rollout, and only while it is still in
+// the status it was read in, so the reports and UpdateRollout don't
+// overwrite each other. Returns nil when the status changed meanwhile.
+var updateRolloutScript = redis.NewScript(`
+local rolloutJson = redis.call('GET', KEYS[1])
+if not rolloutJson then
+	return nil
+end
+local rollout = cjson.decode(rolloutJson)
+if rollout.status ~= ARGV[1] then
+	return nil
+end
+for field, value in pairs(cjson.decode(ARGV[2])) do
+	rollout[field] = value
+end
+redis.call('SET', KEYS[1], cjson.encode(rollout))
+return 1
+`)
+
+func rolloutRedisKey(rolloutId string) string {
+	return fmt.Sprintf("media-service:firmware:rollout:%s", rolloutId)
+}
+
+// The status of every device that was offered the update
+func rolloutDevicesRedisKey(rolloutId string) string {
+	return fmt.Sprintf("media-service:firmware:rollout:%s:devices", rolloutId)
+}
+
+// The succeeded and failed counts, apart from the rollout so the reports
+// don't overwrite each other
+func rolloutCountsRedisKey(rolloutId string) string {
+	return fmt.Sprintf("media-service:firmware:rollout:%s:counts", rolloutId)
+}
+
+// The rollout the device is in, a device is in one rollout at a time
+func deviceRolloutRedisKey(deviceId string) string {
+	return fmt.Sprintf("media-service:firmware:device-rollout:%s", deviceId)
+}
+
+/**
+ * Roll the published release out to the devices. Only the percentage of the
+ * devices is offered the update, raised stage by stage with UpdateRollout.
+ * Which devices are in a stage is fixed by the rollout id, a device stays in
+ * as the stage grows.
+ *
+ * The rollout halts by itself once failureThresholdPercent of the finished
+ * updates, and at least minFailures, have failed.
+ */
+func (fs *FirmwareServiceImpl) CreateRollout(ctx context.Context, version string, deviceIds []string, percentage, failureThresholdPercent, minFailures uint32) (*Rollout, error) {
+	release, err := fs.getRelease(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if release.Status != RELEASE_STATUS_READY {
+		return nil, status.Errorf(codes.FailedPrecondition, "firmware %s is not published", version)
+	}
+
+	deviceIds, err = validateDeviceIds(deviceIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if percentage < 1 || percentage > 100 {
+		return nil, status.Errorf(codes.InvalidArgument, "percentage must be 1 to 100")
+	}
+
+	if failureThresholdPercent == 0 {
+		failureThresholdPercent = constants.FIRMWARE_DEFAULT_FAILURE_THRESHOLD_PERCENT
+	}
+	if failureThresholdPercent > 100 {
+		return nil, status.Errorf(codes.InvalidArgument, "failure threshold must be 1 to 100 percent")
+	}
+
+	if minFailures == 0 {
+		minFailures = constants.FIRMWARE_DEFAULT_MIN_FAILURES
+	}
+
+	rolloutIdBytes := make([]byte, 16)
+	if _, err := rand.Read(rolloutIdBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate rollout id: %w", err)
+	}
+
+	now := time.Now().UTC().Unix()
+	rollout := &Rollout{
+		RolloutId:               hex.EncodeToString(rolloutIdBytes),
+		Version:                 version,
+		DeviceIds:               deviceIds,
+		Percentage:              percentage,
+		FailureThresholdPercent: failureThresholdPercent,
+		MinFailures:             minFailures,
+		Status:                  ROLLOUT_STATUS_ACTIVE,
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	}
+
+	rolloutJson, err := json.Marshal(rollout)
+	if err != nil {
+		log.Error().Msgf("failed to marshal firmware rollout: %v", err)
+		return nil, fmt.Errorf("failed to marshal firmware rollout: %w", err)
+	}
+
+	_, err = fs.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, rolloutRedisKey(rollout.RolloutId), rolloutJson, 0)
+		for _, deviceId := range deviceIds {
+			pipe.Set(ctx, deviceRolloutRedisKey(deviceId), rollout.RolloutId, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to set firmware rollout in Redis: %v", err)
+		return nil, fmt.Errorf("failed to set firmware rollout in Redis: %w", err)
+	}
+
+	log.Info().Msgf("created firmware rollout %s of %s to %d devices at %d%%", rollout.RolloutId, version, len(deviceIds), percentage)
+
+	return rollout, nil
+}
+
+/**
+ * Move the rollout to its next stage, or pause and resume it. A halted
+ * rollout is resumed the same way, once the cause of the failures is fixed.
+ */
+func (fs *FirmwareServiceImpl) UpdateRollout(ctx context.Context, rolloutId string, percentage uint32, rolloutStatus string) (*Rollout, error) {
+	rollout, err := fs.getRollout(ctx, rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	if rollout.Status == ROLLOUT_STATUS_COMPLETED {
+		return nil, status.Errorf(codes.FailedPrecondition, "rollout %s is completed", rolloutId)
+	}
+
+	fields := map[string]interface{}{}
+
+	if percentage != 0 {
+		if percentage < rollout.Percentage || percentage > 100 {
+			return nil, status.Errorf(codes.InvalidArgument, "percentage must be %d to 100", rollout.Percentage)
+		}
+		fields["percentage"] = percentage
+	}
+
+	switch rolloutStatus {
+	case "":
+	case ROLLOUT_STATUS_ACTIVE:
+		fields["status"] = ROLLOUT_STATUS_ACTIVE
+		fields["halt_reason"] = ""
+	case ROLLOUT_STATUS_PAUSED:
+		fields["status"] = ROLLOUT_STATUS_PAUSED
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "status must be %s or %s", ROLLOUT_STATUS_ACTIVE, ROLLOUT_STATUS_PAUSED)
+	}
+
+	// A report halted or completed the rollout since it was read
+	updated, err := fs.updateRollout(ctx, rollout, fields)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, status.Errorf(codes.Aborted, "rollout %s changed, try again", rolloutId)
+	}
+
+	rollout, err = fs.getRollout(ctx, rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("firmware rollout %s is %s at %d%%", rolloutId, rollout.Status, rollout.Percentage)
+
+	return rollout, nil
+}
+
+// The rollout, and the devices offered the update by device id
+func (fs *FirmwareServiceImpl) GetRollout(ctx context.Context, rolloutId string) (*Rollout, []*DeviceStatus, error) {
+	rollout, err := fs.getRollout(ctx, rolloutId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	devicesJson, err := fs.rdb.HGetAll(ctx, rolloutDevicesRedisKey(rolloutId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get firmware rollout devices from Redis: %v", err)
+		return nil, nil, fmt.Errorf("failed to get firmware rollout devices from Redis: %w", err)
+	}
+
+	devices := make([]*DeviceStatus, 0, len(devicesJson))
+	for _, deviceJson := range devicesJson {
+		var device DeviceStatus
+		if err := json.Unmarshal([]byte(deviceJson), &device); err != nil {
+			log.Error().Msgf("failed to unmarshal firmware device status: %v", err)
+			return nil, nil, fmt.Errorf("failed to unmarshal firmware device status: %w", err)
+		}
+		devices = append(devices, &device)
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].DeviceId < devices[j].DeviceId
+	})
+
+	return rollout, devices, nil
+}
+
+/**
+ * The update for the device, when its rollout is active, the device is in
+ * the current stage and it doesn't run the version yet. A device whose
+ * update failed is not offered it again in the same rollout.
+ *
+ * Returns nil when there is no update.
+ */
+func (fs *FirmwareServiceImpl) GetUpdate(ctx context.Context, deviceId, currentVersion string) (*Update, error) {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return nil, status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	rolloutId, err := fs.rdb.Get(ctx, deviceRolloutRedisKey(deviceId)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		log.Error().Msgf("failed to get device firmware rollout from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device firmware rollout from Redis: %w", err)
+	}
+
+	rollout, err := fs.getRollout(ctx, rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	if rollout.Status != ROLLOUT_STATUS_ACTIVE || !inStage(rollout, deviceId) || currentVersion == rollout.Version {
+		return nil, nil
+	}
+
+	device, err := fs.getDeviceStatus(ctx, rolloutId, deviceId)
+	if err != nil {
+		return nil, err
+	}
+	if device != nil && (device.Status == DEVICE_STATUS_FAILED || device.Status == DEVICE_STATUS_SUCCEEDED) {
+		return nil, nil
+	}
+
+	release, err := fs.getRelease(ctx, rollout.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	downloadUrl, err := fs.objStorage.GeneratePresignedDownloadUrl(ctx, imagePath(release.Version, release.Sha256), constants.FIRMWARE_DOWNLOAD_URL_EXPIRATION_MINUTES)
+	if err != nil {
+		log.Error().Msgf("failed to generate presigned URL: %v", err)
+		return nil, fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+
+	if device == nil {
+		err := fs.offerDevice(ctx, rolloutId, &DeviceStatus{
+			DeviceId:  deviceId,
+			Status:    DEVICE_STATUS_OFFERED,
+			UpdatedAt: time.Now().UTC().Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	log.Info().Msgf("offering firmware %s of rollout %s to device_id %s", release.Version, rolloutId, deviceId)
+
+	return &Update{
+		RolloutId:   rolloutId,
+		Version:     release.Version,
+		DownloadUrl: downloadUrl,
+		Sha256:      release.Sha256,
+		Size:        release.Size,
+	}, nil
+}
+
+/**
+ * Record the progress the device reports, and halt the rollout when too many
+ * updates failed. The rollout is completed once every device succeeded.
+ *
+ * Returns the rollout, the device stops updating unless it is active.
+ */
+func (fs *FirmwareServiceImpl) Report(ctx context.Context, deviceId string, report Report) (*Rollout, error) {
+	switch report.Status {
+	case DEVICE_STATUS_DOWNLOADING, DEVICE_STATUS_INSTALLING, DEVICE_STATUS_SUCCEEDED, DEVICE_STATUS_FAILED:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid firmware update status: %s", report.Status)
+	}
+
+	if report.ProgressPercent > 100 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid progress: %d%%", report.ProgressPercent)
+	}
+
+	currentRolloutId, err := fs.rdb.Get(ctx, deviceRolloutRedisKey(deviceId)).Result()
+	if err != nil && err != redis.Nil {
+		log.Error().Msgf("failed to get device firmware rollout from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get device firmware rollout from Redis: %w", err)
+	}
+	if currentRolloutId != report.RolloutId {
+		return nil, status.Errorf(codes.FailedPrecondition, "device_id %s is not in rollout %s", deviceId, report.RolloutId)
+	}
+
+	rollout, err := fs.getRollout(ctx, report.RolloutId)
+	if err != nil {
+		return nil, err
+	}
+	if report.Version != rollout.Version {
+		return nil, status.Errorf(codes.InvalidArgument, "rollout %s is of firmware %s, not %s", rollout.RolloutId, rollout.Version, report.Version)
+	}
+
+	message := strings.TrimSpace(report.Message)
+	if len(message) > maxReportMessageLength {
+		message = message[:maxReportMessageLength]
+	}
+
+	deviceJson, err := json.Marshal(&DeviceStatus{
+		DeviceId:        deviceId,
+		Status:          report.Status,
+		ProgressPercent: report.ProgressPercent,
+		Message:         message,
+		UpdatedAt:       time.Now().UTC().Unix(),
+	})
+	if err != nil {
+		log.Error().Msgf("failed to marshal firmware device status: %v", err)
+		return nil, fmt.Errorf("failed to marshal firmware device status: %w", err)
+	}
+
+	// A finished update stays finished, a retried report changes nothing
+	keys := []string{rolloutDevicesRedisKey(rollout.RolloutId), rolloutCountsRedisKey(rollout.RolloutId)}
+	recorded, err := reportScript.Run(ctx, fs.rdb, keys, deviceId, deviceJson, report.Status, DEVICE_STATUS_SUCCEEDED, DEVICE_STATUS_FAILED).Int()
+	if err != nil {
+		log.Error().Msgf("failed to set firmware device status in Redis: %v", err)
+		return nil, fmt.Errorf("failed to set firmware device status in Redis: %w", err)
+	}
+
+	if recorded == 0 || (report.Status != DEVICE_STATUS_SUCCEEDED && report.Status != DEVICE_STATUS_FAILED) {
+		return rollout, nil
+	}
+
+	log.Info().Msgf("firmware update of device_id %s in rollout %s %s", deviceId, rollout.RolloutId, report.Status)
+
+	// Reloaded, for the counts of the other devices too
+	rollout, err = fs.getRollout(ctx, rollout.RolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	finished := rollout.SucceededCount + rollout.FailedCount
+	switch {
+	case rollout.Status == ROLLOUT_STATUS_ACTIVE && rollout.FailedCount >= int64(rollout.MinFailures) && rollout.FailedCount*100 >= int64(rollout.FailureThresholdPercent)*finished:
+		fields = map[string]interface{}{
+			"status":      ROLLOUT_STATUS_HALTED,
+			"halt_reason": fmt.Sprintf("%d of %d updates failed", rollout.FailedCount, finished),
+		}
+	case rollout.Status != ROLLOUT_STATUS_COMPLETED && rollout.SucceededCount >= int64(len(rollout.DeviceIds)):
+		fields = map[string]interface{}{
+			"status": ROLLOUT_STATUS_COMPLETED,
+		}
+	default:
+		return rollout, nil
+	}
+
+	// Otherwise UpdateRollout or another report changed the status first
+	updated, err := fs.updateRollout(ctx, rollout, fields)
+	if err != nil {
+		return nil, err
+	}
+	if updated {
+		if fields["status"] == ROLLOUT_STATUS_HALTED {
+			log.Warn().Msgf("halting firmware rollout %s, %s", rollout.RolloutId, fields["halt_reason"])
+		} else {
+			log.Info().Msgf("firmware rollout %s completed", rollout.RolloutId)
+		}
+	}
+
+	return fs.getRollout(ctx, rollout.RolloutId)
+}
+
+func (fs *FirmwareServiceImpl) getRollout(ctx context.Context, rolloutId string) (*Rollout, error) {
+	rolloutJson, err := fs.rdb.Get(ctx, rolloutRedisKey(rolloutId)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, status.Errorf(codes.NotFound, "rollout %s not found", rolloutId)
+		}
+
+		log.Error().Msgf("failed to get firmware rollout from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get firmware rollout from Redis: %w", err)
+	}
+
+	var rollout Rollout
+	if err := json.Unmarshal([]byte(rolloutJson), &rollout); err != nil {
+		log.Error().Msgf("failed to unmarshal firmware rollout: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal firmware rollout: %w", err)
+	}
+
+	counts, err := fs.rdb.HGetAll(ctx, rolloutCountsRedisKey(rolloutId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get firmware rollout counts from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get firmware rollout counts from Redis: %w", err)
+	}
+	fmt.Sscan(counts[DEVICE_STATUS_SUCCEEDED], &rollout.SucceededCount)
+	fmt.Sscan(counts[DEVICE_STATUS_FAILED], &rollout.FailedCount)
+
+	return &rollout, nil
+}
+
+// Set the fields, by their JSON name, while the rollout is in the status it
+// was read in. Returns false when its status changed meanwhile.
+func (fs *FirmwareServiceImpl) updateRollout(ctx context.Context, rollout *Rollout, fields map[string]interface{}) (bool, error) {
+	fields["updated_at"] = time.Now().UTC().Unix()
+
+	fieldsJson, err := json.Marshal(fields)
+	if err != nil {
+		log.Error().Msgf("failed to marshal firmware rollout fields: %v", err)
+		return false, fmt.Errorf("failed to marshal firmware rollout fields: %w", err)
+	}
+
+	err = updateRolloutScript.Run(ctx, fs.rdb, []string{rolloutRedisKey(rollout.RolloutId)}, rollout.Status, fieldsJson).Err()
+	if err != nil {
+		if err == redis.Nil {
+			return false, nil
+		}
+
+		log.Error().Msgf("failed to update firmware rollout in Redis: %v", err)
+		return false, fmt.Errorf("failed to update firmware rollout in Redis: %w", err)
+	}
+
+	return true, nil
+}
+
+func (fs *FirmwareServiceImpl) getDeviceStatus(ctx context.Context, rolloutId, deviceId string) (*DeviceStatus, error) {
+	deviceJson, err := fs.rdb.HGet(ctx, rolloutDevicesRedisKey(rolloutId), deviceId).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		log.Error().Msgf("failed to get firmware device status from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get firmware device status from Redis: %w", err)
+	}
+
+	var device DeviceStatus
+	if err := json.Unmarshal([]byte(deviceJson), &device); err != nil {
+		log.Error().Msgf("failed to unmarshal firmware device status: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal firmware device status: %w", err)
+	}
+
+	return &device, nil
+}
+
+// Only when the device has no status yet, a report since it was read wins
+func (fs *FirmwareServiceImpl) offerDevice(ctx context.Context, rolloutId string, device *DeviceStatus) error {
+	deviceJson, err := json.Marshal(device)
+	if err != nil {
+		log.Error().Msgf("failed to marshal firmware device status: %v", err)
+		return fmt.Errorf("failed to marshal firmware device status: %w", err)
+	}
+
+	if err := fs.rdb.HSetNX(ctx, rolloutDevicesRedisKey(rolloutId), device.DeviceId, deviceJson).Err(); err != nil {
+		log.Error().Msgf("failed to set firmware device status in Redis: %v", err)
+		return fmt.Errorf("failed to set firmware device status in Redis: %w", err)
+	}
+
+	return nil
+}
+
+// The device is in the stage when its bucket, 0 to 99 and fixed per rollout,
+// is under the percentage
+func inStage(rollout *Rollout, deviceId string) bool {
+	found := false
+	for _, id := range rollout.DeviceIds {
+		if id == deviceId {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	hash := sha256.Sum256([]byte(rollout.RolloutId + "/" + deviceId))
+	bucket := binary.BigEndian.Uint64(hash[:8]) % 100
+
+	return bucket < uint64(rollout.Percentage)
+}
+
+func validateDeviceIds(deviceIds []string) ([]string, error) {
+	if len(deviceIds) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "missing device_ids")
+	}
+	if len(deviceIds) > constants.FIRMWARE_ROLLOUT_MAX_DEVICES {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d device_ids", constants.FIRMWARE_ROLLOUT_MAX_DEVICES)
+	}
+
+	unique := make([]string, 0, len(deviceIds))
+	seen := make(map[string]bool, len(deviceIds))
+	for _, deviceId := range deviceIds {
+		deviceId = strings.TrimSpace(deviceId)
+		if len(deviceId) != 9 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid device_id %s length %d", deviceId, len(deviceId))
+		}
+		if !seen[deviceId] {
+			seen[deviceId] = true
+			unique = append(unique, deviceId)
+		}
+	}
+
+	return unique, nil
+}
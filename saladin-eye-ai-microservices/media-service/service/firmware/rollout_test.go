@@ -0,0 +1,69 @@
+package firmware
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testRollout(percentage uint32) *Rollout {
+	deviceIds := make([]string, 1000)
+	for i := range deviceIds {
+		deviceIds[i] = fmt.Sprintf("DEV%06d", i)
+	}
+
+	return &Rollout{
+		RolloutId:  "0123456789abcdef0123456789abcdef",
+		DeviceIds:  deviceIds,
+		Percentage: percentage,
+	}
+}
+
+func TestInStage(t *testing.T) {
+	tests := []struct {
+		percentage uint32
+		min, max   int
+	}{
+		{1, 1, 25},
+		{10, 70, 130},
+		{50, 450, 550},
+		{100, 1000, 1000},
+	}
+
+	for _, tt := range tests {
+		rollout := testRollout(tt.percentage)
+
+		count := 0
+		for _, deviceId := range rollout.DeviceIds {
+			if inStage(rollout, deviceId) {
+				count++
+			}
+		}
+
+		if count < tt.min || count > tt.max {
+			t.Errorf("%d%% stage has %d of %d devices, want %d to %d", tt.percentage, count, len(rollout.DeviceIds), tt.min, tt.max)
+		}
+	}
+}
+
+func TestInStageKeepsDevicesAsItGrows(t *testing.T) {
+	stages := []uint32{1, 5, 10, 25, 50, 100}
+
+	for i := 1; i < len(stages); i++ {
+		previous := testRollout(stages[i-1])
+		next := testRollout(stages[i])
+
+		for _, deviceId := range previous.DeviceIds {
+			if inStage(previous, deviceId) && !inStage(next, deviceId) {
+				t.Errorf("device_id %s in the %d%% stage left at %d%%", deviceId, stages[i-1], stages[i])
+			}
+		}
+	}
+}
+
+func TestInStageOnlyRolloutDevices(t *testing.T) {
+	rollout := testRollout(100)
+
+	if inStage(rollout, "OTHER0001") {
+		t.Errorf("device_id OTHER0001 not in the rollout is in its stage")
+	}
+}
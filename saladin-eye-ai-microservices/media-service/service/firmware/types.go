@@ -0,0 +1,91 @@
+package firmware
+
+import "context"
+
+// Status of a release
+const (
+	RELEASE_STATUS_PENDING_UPLOAD = "pending_upload"
+	RELEASE_STATUS_READY          = "ready"
+)
+
+// Status of a rollout
+const (
+	ROLLOUT_STATUS_ACTIVE    = "active"
+	ROLLOUT_STATUS_PAUSED    = "paused"
+	ROLLOUT_STATUS_HALTED    = "halted"
+	ROLLOUT_STATUS_COMPLETED = "completed"
+)
+
+// Status of a device in a rollout, offered when it got the download URL,
+// then as reported by the device
+const (
+	DEVICE_STATUS_OFFERED     = "offered"
+	DEVICE_STATUS_DOWNLOADING = "downloading"
+	DEVICE_STATUS_INSTALLING  = "installing"
+	DEVICE_STATUS_SUCCEEDED   = "succeeded"
+	DEVICE_STATUS_FAILED      = "failed"
+)
+
+type Release struct {
+	Version     string `json:"version"`
+	Sha256      string `json:"sha256"`
+	Size        int64  `json:"size"`
+	Status      string `json:"status"`
+	Notes       string `json:"notes"`
+	CreatedAt   int64  `json:"created_at"`
+	PublishedAt int64  `json:"published_at"`
+}
+
+type Rollout struct {
+	RolloutId               string   `json:"rollout_id"`
+	Version                 string   `json:"version"`
+	DeviceIds               []string `json:"device_ids"`
+	Percentage              uint32   `json:"percentage"`
+	FailureThresholdPercent uint32   `json:"failure_threshold_percent"`
+	MinFailures             uint32   `json:"min_failures"`
+	Status                  string   `json:"status"`
+	HaltReason              string   `json:"halt_reason"`
+	SucceededCount          int64    `json:"succeeded_count"`
+	FailedCount             int64    `json:"failed_count"`
+	CreatedAt               int64    `json:"created_at"`
+	UpdatedAt               int64    `json:"updated_at"`
+}
+
+type DeviceStatus struct {
+	DeviceId        string `json:"device_id"`
+	Status          string `json:"status"`
+	ProgressPercent uint32 `json:"progress_percent"`
+	Message         string `json:"message"`
+	UpdatedAt       int64  `json:"updated_at"`
+}
+
+// Update is what a device is offered, nil when it has nothing to install
+type Update struct {
+	RolloutId   string
+	Version     string
+	DownloadUrl string
+	Sha256      string
+	Size        int64
+}
+
+// Report is the progress a device reports
+type Report struct {
+	RolloutId       string
+	Version         string
+	Status          string
+	ProgressPercent uint32
+	Message         string
+}
+
+type FirmwareServiceIface interface {
+	CreateRelease(ctx context.Context, version, sha256 string, size int64, notes string) (*Release, string, error)
+	PublishRelease(ctx context.Context, version string) (*Release, error)
+	ListReleases(ctx context.Context) ([]*Release, error)
+
+	CreateRollout(ctx context.Context, version string, deviceIds []string, percentage, failureThresholdPercent, minFailures uint32) (*Rollout, error)
+	UpdateRollout(ctx context.Context, rolloutId string, percentage uint32, rolloutStatus string) (*Rollout, error)
+	GetRollout(ctx context.Context, rolloutId string) (*Rollout, []*DeviceStatus, error)
+
+	GetUpdate(ctx context.Context, deviceId, currentVersion string) (*Update, error)
+	Report(ctx context.Context, deviceId string, report Report) (*Rollout, error)
+}
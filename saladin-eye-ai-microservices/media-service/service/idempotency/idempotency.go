@@ -0,0 +1,137 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+const (
+	// Longest a request can run before a duplicate may run it again
+	inProgressTTL = 30 * time.Second
+
+	waitRetryDelay = 100 * time.Millisecond
+)
+
+// Delete the in progress marker when it still holds our token. A run that
+// outlived its marker must not delete the marker of the duplicate that took
+// over.
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+type IdempotencyServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) IdempotencyServiceIface {
+	return &IdempotencyServiceImpl{
+		rdb: rdb,
+	}
+}
+
+/**
+ * Run fn once per idempotency key, and return its response. A request sent
+ * again with the same key gets the stored response of the first one, as is,
+ * for IDEMPOTENCY_TTL_MINUTES:
+ *   media-service:idempotency:[scope]:[key]
+ *
+ * A duplicate that arrives while the first request still runs waits for its
+ * response instead of running fn again. Failed requests are not stored, the
+ * next duplicate runs fn again.
+ *
+ * Returns the response, and whether it was replayed. Without key fn just runs.
+ */
+func (is *IdempotencyServiceImpl) Do(ctx context.Context, scope, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, bool, error) {
+	if key == "" {
+		response, err := fn(ctx)
+		return response, false, err
+	}
+
+	responseKey := fmt.Sprintf("media-service:idempotency:%s:%s", scope, key)
+	inProgressKey := fmt.Sprintf("media-service:idempotency:in-progress:%s:%s", scope, key)
+
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, false, fmt.Errorf("failed to generate idempotency token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	deadline := time.Now().Add(constants.IDEMPOTENCY_WAIT_SECONDS * time.Second)
+
+	for {
+		response, err := is.rdb.Get(ctx, responseKey).Bytes()
+		if err == nil {
+			log.Info().Msgf("replaying response of idempotency key %s in %s", key, scope)
+			return response, true, nil
+		}
+		if err != redis.Nil {
+			log.Error().Msgf("failed to get idempotent response from Redis: %v", err)
+			return nil, false, fmt.Errorf("failed to get idempotent response from Redis: %w", err)
+		}
+
+		acquired, err := is.rdb.SetNX(ctx, inProgressKey, token, inProgressTTL).Result()
+		if err != nil {
+			log.Error().Msgf("failed to set idempotency in progress marker in Redis: %v", err)
+			return nil, false, fmt.Errorf("failed to set idempotency in progress marker in Redis: %w", err)
+		}
+
+		if acquired {
+			return is.run(ctx, responseKey, inProgressKey, token, fn)
+		}
+
+		// Another request with the same key is running, wait for its response
+		if time.Now().After(deadline) {
+			log.Warn().Msgf("gave up waiting for idempotency key %s in %s", key, scope)
+			return nil, false, status.Errorf(codes.Aborted, "a request with idempotency key %s is still in progress", key)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(waitRetryDelay):
+		}
+	}
+}
+
+func (is *IdempotencyServiceImpl) run(ctx context.Context, responseKey, inProgressKey, token string, fn func(ctx context.Context) ([]byte, error)) ([]byte, bool, error) {
+	defer func() {
+		if err := releaseScript.Run(context.Background(), is.rdb, []string{inProgressKey}, token).Err(); err != nil && err != redis.Nil {
+			log.Error().Msgf("failed to release idempotency in progress marker in Redis: %v", err)
+		}
+	}()
+
+	// The first request may have finished between the two checks
+	response, err := is.rdb.Get(ctx, responseKey).Bytes()
+	if err == nil {
+		return response, true, nil
+	}
+	if err != redis.Nil {
+		log.Error().Msgf("failed to get idempotent response from Redis: %v", err)
+		return nil, false, fmt.Errorf("failed to get idempotent response from Redis: %w", err)
+	}
+
+	response, err = fn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The request did succeed, a retry will run it again
+	if _, err := is.rdb.Set(ctx, responseKey, response, constants.IDEMPOTENCY_TTL_MINUTES*time.Minute).Result(); err != nil {
+		log.Error().Msgf("failed to set idempotent response in Redis: %v", err)
+	}
+
+	return response, false, nil
+}
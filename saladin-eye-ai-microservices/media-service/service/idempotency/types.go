@@ -0,0 +1,7 @@
+package idempotency
+
+import "context"
+
+type IdempotencyServiceIface interface {
+	Do(ctx context.Context, scope, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, bool, error)
+}
@@ -0,0 +1,312 @@
+package integrity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/media-service/internal/objectstorage"
+)
+
+const (
+	// Hash of the entry before the first one
+	genesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	lockTTL        = 10 * time.Second
+	lockRetries    = 50
+	lockRetryDelay = 100 * time.Millisecond
+)
+
+// Delete the lock when it still holds our token, in one step
+var unlockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+type IntegrityServiceImpl struct {
+	rdb        redis.Cmdable
+	objStorage objectstorage.ObjectStorageIface
+}
+
+func New(rdb redis.Cmdable, objStorage objectstorage.ObjectStorageIface) IntegrityServiceIface {
+	return &IntegrityServiceImpl{
+		rdb:        rdb,
+		objStorage: objStorage,
+	}
+}
+
+/**
+ * Every device has its own hash chain, stored as a Redis list of JSON entries:
+ *   media-service:integrity-chain:[Device ID]
+ *
+ * Each entry holds the SHA-256 of the photo object and the hash of the entry
+ * before it, and its own hash covers both. Changing a photo, or any entry of
+ * the chain, breaks every hash that comes after it.
+ */
+func computeEntryHash(entry *Entry) string {
+	digest := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s|%s|%d", entry.Index, entry.PhotoPath, entry.ObjectSha256, entry.PrevHash, entry.RecordedAt)))
+	return hex.EncodeToString(digest[:])
+}
+
+/**
+ * Append the photo to the hash chain of the device. A photo already in the
+ * chain is not appended again, its existing entry is returned.
+ */
+func (is *IntegrityServiceImpl) Append(ctx context.Context, deviceId, photoPath string, data []byte) (*Entry, error) {
+	chainKey := fmt.Sprintf("media-service:integrity-chain:%s", deviceId)
+	indexKey := fmt.Sprintf("media-service:integrity-chain:index:%s", deviceId)
+
+	// Only one writer per chain, otherwise two entries could point to the same
+	// previous entry
+	unlock, err := is.lock(ctx, deviceId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	existing, err := is.rdb.HGet(ctx, indexKey, photoPath).Int64()
+	if err == nil {
+		log.Info().Msgf("photo %s already in the integrity chain at index %d", photoPath, existing)
+		return is.getEntry(ctx, chainKey, existing)
+	}
+	if err != redis.Nil {
+		log.Error().Msgf("failed to get integrity chain index from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get integrity chain index from Redis: %w", err)
+	}
+
+	prevHash := genesisHash
+	length, err := is.rdb.LLen(ctx, chainKey).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get integrity chain length from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get integrity chain length from Redis: %w", err)
+	}
+
+	if length > 0 {
+		last, err := is.getEntry(ctx, chainKey, length-1)
+		if err != nil {
+			return nil, err
+		}
+		prevHash = last.EntryHash
+	}
+
+	digest := sha256.Sum256(data)
+	entry := &Entry{
+		Index:        length,
+		PhotoPath:    photoPath,
+		ObjectSha256: hex.EncodeToString(digest[:]),
+		PrevHash:     prevHash,
+		RecordedAt:   time.Now().UTC().Unix(),
+	}
+	entry.EntryHash = computeEntryHash(entry)
+
+	entryJson, err := json.Marshal(entry)
+	if err != nil {
+		log.Error().Msgf("failed to marshal integrity chain entry: %v", err)
+		return nil, fmt.Errorf("failed to marshal integrity chain entry: %w", err)
+	}
+
+	pipe := is.rdb.TxPipeline()
+	pipe.RPush(ctx, chainKey, entryJson)
+	pipe.HSet(ctx, indexKey, photoPath, entry.Index)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Error().Msgf("failed to append integrity chain entry in Redis: %v", err)
+		return nil, fmt.Errorf("failed to append integrity chain entry in Redis: %w", err)
+	}
+
+	log.Debug().Msgf("appended %s to the integrity chain of device_id %s at index %d", photoPath, deviceId, entry.Index)
+
+	return entry, nil
+}
+
+/**
+ * Verify a page of the hash chain of the device, from start on, and download
+ * the photos of the page again to compare them with their recorded SHA-256.
+ * The first entry of the page is checked against the hash recorded in the
+ * entry before it, verifying the pages one after the other covers the chain.
+ *
+ * With a photo path, only the entry of that photo is verified.
+ */
+func (is *IntegrityServiceImpl) Verify(ctx context.Context, deviceId, photoPath string, start int64, limit int) (*VerifyResult, error) {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return nil, status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	if start < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid start index: %d", start)
+	}
+
+	if limit <= 0 {
+		limit = constants.INTEGRITY_VERIFY_DEFAULT_LIMIT
+	}
+	limit = min(limit, constants.INTEGRITY_VERIFY_MAX_LIMIT)
+
+	chainKey := fmt.Sprintf("media-service:integrity-chain:%s", deviceId)
+	indexKey := fmt.Sprintf("media-service:integrity-chain:index:%s", deviceId)
+
+	if photoPath != "" {
+		index, err := is.rdb.HGet(ctx, indexKey, photoPath).Int64()
+		if err != nil {
+			if err == redis.Nil {
+				return nil, status.Errorf(codes.NotFound, "photo %s is not in the integrity chain", photoPath)
+			}
+			log.Error().Msgf("failed to get integrity chain index from Redis: %v", err)
+			return nil, fmt.Errorf("failed to get integrity chain index from Redis: %w", err)
+		}
+		start = index
+		limit = 1
+	}
+
+	// One more entry before the page, the hash its first entry points to
+	first := max(start-1, 0)
+	entriesJson, err := is.rdb.LRange(ctx, chainKey, first, start+int64(limit)-1).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get integrity chain from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get integrity chain from Redis: %w", err)
+	}
+
+	result := &VerifyResult{
+		Failures: make([]Failure, 0),
+	}
+
+	prevHash := genesisHash
+	if start > 0 {
+		if len(entriesJson) == 0 {
+			return nil, status.Errorf(codes.OutOfRange, "start index %d is past the end of the integrity chain", start)
+		}
+
+		// Without the previous entry the first entry can't be checked either,
+		// its previous hash then fails to match
+		var prev Entry
+		if err := json.Unmarshal([]byte(entriesJson[0]), &prev); err != nil {
+			result.Failures = append(result.Failures, Failure{Index: start - 1, Reason: "entry is not valid JSON"})
+			prevHash = ""
+		} else {
+			prevHash = prev.EntryHash
+		}
+		entriesJson = entriesJson[1:]
+	}
+
+	length, err := is.rdb.LLen(ctx, chainKey).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get integrity chain length from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get integrity chain length from Redis: %w", err)
+	}
+
+	if next := start + int64(len(entriesJson)); photoPath == "" && next < length {
+		result.NextIndex = next
+	}
+
+	for offset, entryJson := range entriesJson {
+		i := start + int64(offset)
+		result.CheckedEntries++
+
+		var entry Entry
+		if err := json.Unmarshal([]byte(entryJson), &entry); err != nil {
+			result.Failures = append(result.Failures, Failure{Index: i, Reason: "entry is not valid JSON"})
+			continue
+		}
+
+		if entry.Index != i {
+			result.Failures = append(result.Failures, Failure{Index: i, PhotoPath: entry.PhotoPath, Reason: fmt.Sprintf("entry has index %d", entry.Index)})
+		}
+
+		if entry.PrevHash != prevHash {
+			result.Failures = append(result.Failures, Failure{Index: i, PhotoPath: entry.PhotoPath, Reason: "previous hash does not match the previous entry"})
+		}
+
+		if computeEntryHash(&entry) != entry.EntryHash {
+			result.Failures = append(result.Failures, Failure{Index: i, PhotoPath: entry.PhotoPath, Reason: "entry hash does not match the entry content"})
+		}
+
+		// Carry on with the recorded hash, so one broken entry is reported once
+		prevHash = entry.EntryHash
+
+		data, err := is.objStorage.GetObject(ctx, entry.PhotoPath, constants.PHOTO_MAX_SIZE_BYTES)
+		if err != nil {
+			log.Error().Msgf("failed to get photo %s from object storage: %v", entry.PhotoPath, err)
+			result.Failures = append(result.Failures, Failure{Index: i, PhotoPath: entry.PhotoPath, Reason: fmt.Sprintf("failed to download the photo: %v", err)})
+			continue
+		}
+
+		digest := sha256.Sum256(data)
+		if hex.EncodeToString(digest[:]) != entry.ObjectSha256 {
+			result.Failures = append(result.Failures, Failure{Index: i, PhotoPath: entry.PhotoPath, Reason: "photo SHA-256 does not match the recorded one"})
+		}
+	}
+
+	if !result.IsValid() {
+		reasons := make([]string, 0, len(result.Failures))
+		for _, failure := range result.Failures {
+			reasons = append(reasons, fmt.Sprintf("#%d %s: %s", failure.Index, failure.PhotoPath, failure.Reason))
+		}
+		log.Warn().Msgf("integrity chain of device_id %s failed verification: %s", deviceId, strings.Join(reasons, "; "))
+	}
+
+	return result, nil
+}
+
+func (is *IntegrityServiceImpl) getEntry(ctx context.Context, chainKey string, index int64) (*Entry, error) {
+	entryJson, err := is.rdb.LIndex(ctx, chainKey, index).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get integrity chain entry %d from Redis: %v", index, err)
+		return nil, fmt.Errorf("failed to get integrity chain entry %d from Redis: %w", index, err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal([]byte(entryJson), &entry); err != nil {
+		log.Error().Msgf("failed to unmarshal integrity chain entry %d: %v", index, err)
+		return nil, fmt.Errorf("failed to unmarshal integrity chain entry %d: %w", index, err)
+	}
+
+	return &entry, nil
+}
+
+func (is *IntegrityServiceImpl) lock(ctx context.Context, deviceId string) (func(), error) {
+	lockKey := fmt.Sprintf("media-service:integrity-chain:lock:%s", deviceId)
+
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate integrity chain lock token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	for i := 0; i < lockRetries; i++ {
+		acquired, err := is.rdb.SetNX(ctx, lockKey, token, lockTTL).Result()
+		if err != nil {
+			log.Error().Msgf("failed to acquire integrity chain lock in Redis: %v", err)
+			return nil, fmt.Errorf("failed to acquire integrity chain lock in Redis: %w", err)
+		}
+
+		if acquired {
+			return func() {
+				// Only release the lock if it is still ours
+				if err := unlockScript.Run(ctx, is.rdb, []string{lockKey}, token).Err(); err != nil && err != redis.Nil {
+					log.Error().Msgf("failed to release integrity chain lock in Redis: %v", err)
+				}
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryDelay):
+		}
+	}
+
+	return nil, errors.New("timed out waiting for the integrity chain lock")
+}
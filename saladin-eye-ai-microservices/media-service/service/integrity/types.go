@@ -0,0 +1,34 @@
+package integrity
+
+import "context"
+
+type Entry struct {
+	Index        int64  `json:"index"`
+	PhotoPath    string `json:"photo_path"`
+	ObjectSha256 string `json:"object_sha256"`
+	PrevHash     string `json:"prev_hash"`
+	RecordedAt   int64  `json:"recorded_at"`
+	EntryHash    string `json:"entry_hash"`
+}
+
+type Failure struct {
+	Index     int64
+	PhotoPath string
+	Reason    string
+}
+
+type VerifyResult struct {
+	CheckedEntries int64
+	Failures       []Failure
+	// Where the next page of the chain starts, 0 at the end of the chain
+	NextIndex int64
+}
+
+func (result *VerifyResult) IsValid() bool {
+	return len(result.Failures) == 0
+}
+
+type IntegrityServiceIface interface {
+	Append(ctx context.Context, deviceId, photoPath string, data []byte) (*Entry, error)
+	Verify(ctx context.Context, deviceId, photoPath string, start int64, limit int) (*VerifyResult, error)
+}
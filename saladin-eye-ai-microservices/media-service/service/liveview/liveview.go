@@ -0,0 +1,139 @@
+package liveview
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid live view token")
+	ErrExpiredToken = errors.New("expired live view token")
+)
+
+type LiveViewServiceImpl struct {
+	secret  []byte
+	baseUrl string
+}
+
+/**
+ * The live view is served by the HTTP server, and the URLs are handed out by
+ * the gRPC server to the back-end, so both share the token secret:
+ *   LIVE_VIEW_TOKEN_SECRET, the HMAC-SHA256 key of the tokens
+ *   LIVE_VIEW_BASE_URL, where the browsers reach the HTTP server
+ */
+func New() LiveViewServiceIface {
+	return &LiveViewServiceImpl{
+		secret:  []byte(os.Getenv("LIVE_VIEW_TOKEN_SECRET")),
+		baseUrl: strings.TrimSuffix(os.Getenv("LIVE_VIEW_BASE_URL"), "/"),
+	}
+}
+
+/**
+ * Return the live view URL of the device, the browser opens it as is, for
+ * example as the source of an <img>:
+ *   [Base URL]/live/[Device ID]?token=[token]
+ *
+ * The token is [claims].[signature], both base64url encoded, and is only
+ * checked when the stream is opened.
+ */
+func (lvs *LiveViewServiceImpl) IssueUrl(deviceId string, unmasked bool, maxFps int32) (string, *Claims, error) {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return "", nil, status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	if maxFps < 0 {
+		log.Error().Msgf("invalid max_fps %d", maxFps)
+		return "", nil, status.Errorf(codes.InvalidArgument, "invalid max_fps: %d", maxFps)
+	}
+
+	if len(lvs.secret) == 0 || lvs.baseUrl == "" {
+		log.Error().Msg("LIVE_VIEW_TOKEN_SECRET or LIVE_VIEW_BASE_URL environment variable not set")
+		return "", nil, status.Errorf(codes.FailedPrecondition, "live view is not configured")
+	}
+
+	claims := &Claims{
+		DeviceId:  deviceId,
+		Unmasked:  unmasked,
+		MaxFps:    ClampFps(maxFps),
+		ExpiresAt: time.Now().Add(constants.LIVE_VIEW_URL_EXPIRATION_MINUTES * time.Minute).Unix(),
+	}
+
+	claimsJson, err := json.Marshal(claims)
+	if err != nil {
+		log.Error().Msgf("failed to marshal live view claims: %v", err)
+		return "", nil, fmt.Errorf("failed to marshal live view claims: %w", err)
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(claimsJson)
+	token := payload + "." + base64.RawURLEncoding.EncodeToString(lvs.sign(payload))
+
+	return fmt.Sprintf("%s/live/%s?token=%s", lvs.baseUrl, deviceId, url.QueryEscape(token)), claims, nil
+}
+
+func (lvs *LiveViewServiceImpl) VerifyToken(token string, now time.Time) (*Claims, error) {
+	if len(lvs.secret) == 0 {
+		log.Error().Msg("LIVE_VIEW_TOKEN_SECRET environment variable not set")
+		return nil, ErrInvalidToken
+	}
+
+	payload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, ErrInvalidToken
+	}
+
+	signatureBytes, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(signatureBytes, lvs.sign(payload)) {
+		return nil, ErrInvalidToken
+	}
+
+	claimsJson, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(claimsJson, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if now.Unix() > claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return &claims, nil
+}
+
+func (lvs *LiveViewServiceImpl) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, lvs.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// ClampFps returns the frame rate to use for the requested one, the server
+// default when it is not set
+func ClampFps(fps int32) int32 {
+	if fps <= 0 {
+		return constants.LIVE_VIEW_DEFAULT_MAX_FPS
+	}
+
+	if fps > constants.LIVE_VIEW_MAX_FPS {
+		return constants.LIVE_VIEW_MAX_FPS
+	}
+
+	return fps
+}
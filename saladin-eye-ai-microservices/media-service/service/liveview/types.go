@@ -0,0 +1,16 @@
+package liveview
+
+import "time"
+
+// What the bearer of a live view token is allowed to watch
+type Claims struct {
+	DeviceId  string `json:"device_id"`
+	Unmasked  bool   `json:"unmasked"`
+	MaxFps    int32  `json:"max_fps"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+type LiveViewServiceIface interface {
+	IssueUrl(deviceId string, unmasked bool, maxFps int32) (string, *Claims, error)
+	VerifyToken(token string, now time.Time) (*Claims, error)
+}
@@ -0,0 +1,263 @@
+package photo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+	"github.com/andypmw/saladin-eye-ai/media-service/internal/imaging"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/privacy"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/watermark"
+)
+
+const (
+	DerivedKindMasked    = "masked"
+	DerivedKindThumbnail = "thumbnail"
+	DerivedKindExport    = "export"
+
+	thumbnailMaxDimension = 320
+	derivedMarkerTTL      = 7 * 24 * time.Hour
+	shortHashLength       = 12
+
+	// Renders missing from a listing are queued, a full queue drops them and
+	// the next listing queues them again
+	derivedQueueSize     = 256
+	derivedPendingTTL    = 5 * time.Minute
+	derivedRenderTimeout = time.Minute
+)
+
+// What to render on top of the original photo. A nil or empty mask is only
+// used for users allowed to see the unmasked photo.
+type renderOptions struct {
+	mask      *privacy.Mask
+	watermark *watermark.Settings
+}
+
+// A derived image to render in the background
+type renderJob struct {
+	kind      string
+	photoPath string
+	opts      renderOptions
+}
+
+/**
+ * Derived images are rendered from the original photo and stored next to it
+ * in the object storage, outside of the device prefix:
+ *   derived/[Device ID]/[mask]/[watermark]/[kind]/[YYYY-MM-DD]/[HH]/[mm]-[ss].jpg
+ *
+ * The mask part is "v[version]" of the applied privacy mask, or "raw" when no
+ * mask was applied. The watermark part is "w[version]" of the watermark
+ * settings, or "plain". So a settings change never serves an old render, and
+ * the renders of an old mask version are all under one prefix.
+ */
+func derivedPath(kind string, opts renderOptions, photoPath string) string {
+	maskTag := "raw"
+	if !opts.mask.IsEmpty() {
+		maskTag = fmt.Sprintf("v%d", opts.mask.Version)
+	}
+
+	watermarkTag := "plain"
+	if watermarkApplies(kind, opts) {
+		watermarkTag = fmt.Sprintf("w%d", opts.watermark.Version)
+	}
+
+	deviceId, rest, _ := strings.Cut(photoPath, "/")
+
+	return fmt.Sprintf("derived/%s/%s/%s/%s/%s", deviceId, maskTag, watermarkTag, kind, rest)
+}
+
+func derivedMarkerKey(path string) string {
+	return fmt.Sprintf("media-service:derived:%s", path)
+}
+
+// The masked download is the photo itself, only exports and thumbnails
+// (the previews) carry the watermark
+func watermarkApplies(kind string, opts renderOptions) bool {
+	return kind != DerivedKindMasked && opts.watermark.IsEnabled()
+}
+
+/**
+ * Make sure the derived image exists in the object storage, and return its path.
+ * The original photo is downloaded when it is not given.
+ */
+func (ps *PhotoServiceImpl) ensureDerived(ctx context.Context, kind, photoPath string, opts renderOptions, original []byte) (string, error) {
+	path := derivedPath(kind, opts, photoPath)
+	markerKey := derivedMarkerKey(path)
+
+	exists, err := ps.rdb.Exists(ctx, markerKey).Result()
+	if err != nil {
+		log.Error().Msgf("failed to check derived image marker in Redis: %v", err)
+		return "", fmt.Errorf("failed to check derived image marker in Redis: %w", err)
+	}
+
+	if exists > 0 {
+		return path, nil
+	}
+
+	if original == nil {
+		original, err = ps.objStorage.GetObject(ctx, photoPath, constants.PHOTO_MAX_SIZE_BYTES)
+		if err != nil {
+			log.Error().Msgf("failed to get photo from object storage: %v", err)
+			return "", fmt.Errorf("failed to get photo from object storage: %w", err)
+		}
+	}
+
+	img, err := imaging.DecodeJpeg(original)
+	if err != nil {
+		log.Error().Msgf("failed to decode photo %s: %v", photoPath, err)
+		return "", fmt.Errorf("failed to decode photo %s: %w", photoPath, err)
+	}
+
+	rendered := privacy.Apply(img, opts.mask)
+
+	switch kind {
+	case DerivedKindMasked, DerivedKindExport:
+	case DerivedKindThumbnail:
+		rendered = imaging.ToRGBA(imaging.Resize(rendered, thumbnailMaxDimension))
+	default:
+		return "", fmt.Errorf("unknown derived image kind: %s", kind)
+	}
+
+	// The watermark goes on last, so it is not hidden by the mask and keeps
+	// its size on the thumbnails
+	if watermarkApplies(kind, opts) {
+		captureTime, err := captureTimeFromPath(photoPath)
+		if err != nil {
+			log.Error().Msgf("failed to get capture time of %s: %v", photoPath, err)
+			return "", fmt.Errorf("failed to get capture time of %s: %w", photoPath, err)
+		}
+
+		digest := sha256.Sum256(original)
+		shortHash := hex.EncodeToString(digest[:])[:shortHashLength]
+
+		watermark.Apply(rendered, watermark.Text(opts.watermark, captureTime, shortHash))
+	}
+
+	data, err := imaging.EncodeJpeg(rendered)
+	if err != nil {
+		log.Error().Msgf("failed to encode derived image: %v", err)
+		return "", fmt.Errorf("failed to encode derived image: %w", err)
+	}
+
+	if err := ps.objStorage.PutObject(ctx, path, data, "image/jpeg"); err != nil {
+		log.Error().Msgf("failed to put derived image to object storage: %v", err)
+		return "", fmt.Errorf("failed to put derived image to object storage: %w", err)
+	}
+
+	if _, err := ps.rdb.Set(ctx, markerKey, "1", derivedMarkerTTL).Result(); err != nil {
+		log.Error().Msgf("failed to set derived image marker in Redis: %v", err)
+		return "", fmt.Errorf("failed to set derived image marker in Redis: %w", err)
+	}
+
+	log.Debug().Msgf("rendered derived image %s", path)
+
+	return path, nil
+}
+
+// Which of the derived images are rendered, from one pipeline of Redis reads
+func (ps *PhotoServiceImpl) derivedExist(ctx context.Context, paths []string) (map[string]bool, error) {
+	cmds := make([]*redis.IntCmd, len(paths))
+	_, err := ps.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, path := range paths {
+			cmds[i] = pipe.Exists(ctx, derivedMarkerKey(path))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to check derived image markers in Redis: %v", err)
+		return nil, fmt.Errorf("failed to check derived image markers in Redis: %w", err)
+	}
+
+	exist := make(map[string]bool, len(paths))
+	for i, path := range paths {
+		exist[path] = cmds[i].Val() > 0
+	}
+
+	return exist, nil
+}
+
+/**
+ * Queue the render of a derived image that is not there yet. The pending key
+ * makes sure only one instance renders it:
+ *   media-service:derived:pending:[path]
+ */
+func (ps *PhotoServiceImpl) renderInBackground(ctx context.Context, kind, photoPath string, opts renderOptions) {
+	pendingKey := fmt.Sprintf("media-service:derived:pending:%s", derivedPath(kind, opts, photoPath))
+
+	claimed, err := ps.rdb.SetNX(ctx, pendingKey, "1", derivedPendingTTL).Result()
+	if err != nil {
+		log.Error().Msgf("failed to set derived image pending key in Redis: %v", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	select {
+	case ps.renderQueue <- renderJob{kind: kind, photoPath: photoPath, opts: opts}:
+	default:
+		log.Warn().Msgf("render queue is full, dropped %s render of %s", kind, photoPath)
+		ps.rdb.Del(ctx, pendingKey)
+	}
+}
+
+// renderWorker renders the queued derived images one at a time, off the
+// request path
+func (ps *PhotoServiceImpl) renderWorker() {
+	for job := range ps.renderQueue {
+		ctx, cancel := context.WithTimeout(context.Background(), derivedRenderTimeout)
+
+		if _, err := ps.ensureDerived(ctx, job.kind, job.photoPath, job.opts, nil); err != nil {
+			log.Error().Msgf("failed to render %s of %s in the background: %v", job.kind, job.photoPath, err)
+		}
+		ps.rdb.Del(ctx, fmt.Sprintf("media-service:derived:pending:%s", derivedPath(job.kind, job.opts, job.photoPath)))
+
+		cancel()
+	}
+}
+
+/**
+ * Delete the derived images rendered with the privacy mask version, once the
+ * mask has changed they are never served again.
+ */
+func (ps *PhotoServiceImpl) PurgeDerived(ctx context.Context, deviceId string, maskVersion int64) error {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+	if maskVersion <= 0 {
+		return nil
+	}
+
+	prefix := fmt.Sprintf("derived/%s/v%d/", deviceId, maskVersion)
+	deleted, err := ps.objStorage.DeleteObjectsByPrefix(ctx, prefix)
+	if err != nil {
+		log.Error().Msgf("failed to delete derived images under %s: %v", prefix, err)
+		return fmt.Errorf("failed to delete derived images under %s: %w", prefix, err)
+	}
+
+	log.Info().Msgf("deleted %d derived images of privacy mask version %d of device_id %s", deleted, maskVersion, deviceId)
+
+	return nil
+}
+
+// The photo path is [Device ID]/[YYYY-MM-DD]/[HH]/[mm]-[ss].jpg in UTC
+func captureTimeFromPath(photoPath string) (time.Time, error) {
+	parts := strings.Split(photoPath, "/")
+	if len(parts) != 4 {
+		return time.Time{}, fmt.Errorf("invalid photo path %s", photoPath)
+	}
+
+	fileName := strings.TrimSuffix(strings.TrimSuffix(parts[3], ".jpg"), ".JPG")
+
+	return time.Parse("2006-01-02 15 04-05", fmt.Sprintf("%s %s %s", parts[1], parts[2], fileName))
+}
@@ -2,7 +2,6 @@ package photo
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,11 +14,22 @@ import (
 	"github.com/andypmw/saladin-eye-ai/media-service/common/cache"
 	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
 	"github.com/andypmw/saladin-eye-ai/media-service/internal/objectstorage"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/feed"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/integrity"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/privacy"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/quality"
+	"github.com/andypmw/saladin-eye-ai/media-service/service/watermark"
 )
 
 type PhotoServiceImpl struct {
-	objStorage objectstorage.ObjectStorageIface
-	rdb        redis.Cmdable
+	objStorage       objectstorage.ObjectStorageIface
+	rdb              redis.Cmdable
+	qualityService   quality.QualityServiceIface
+	privacyService   privacy.PrivacyServiceIface
+	watermarkService watermark.WatermarkServiceIface
+	integrityService integrity.IntegrityServiceIface
+	feedService      feed.FeedServiceIface
+	renderQueue      chan renderJob
 }
 
 func New() (PhotoServiceIface, error) {
@@ -29,10 +39,21 @@ func New() (PhotoServiceIface, error) {
 		return nil, fmt.Errorf("failed to create photo service: %w", err)
 	}
 
-	return &PhotoServiceImpl{
-		objStorage: objs,
-		rdb:        cache.New(),
-	}, nil
+	rdb := cache.New()
+
+	ps := &PhotoServiceImpl{
+		objStorage:       objs,
+		rdb:              rdb,
+		qualityService:   quality.New(rdb),
+		privacyService:   privacy.New(rdb),
+		watermarkService: watermark.New(rdb),
+		integrityService: integrity.New(rdb, objs),
+		feedService:      feed.New(rdb),
+		renderQueue:      make(chan renderJob, derivedQueueSize),
+	}
+	go ps.renderWorker()
+
+	return ps, nil
 }
 
 /**
@@ -40,31 +61,14 @@ func New() (PhotoServiceIface, error) {
  *   [Device ID]/[YYYY-MM-DD]/[HH]/[mm]-[ss].jpg
  *
  * The date time will be in UTC.
+ *
+ * Returns the presigned upload URL and the photo path it uploads to. Retries
+ * are made idempotent by the handlers, see the idempotency service.
  */
-func (ps *PhotoServiceImpl) GenerateUploadPresignedUrl(ctx context.Context, deviceId, idempotencyKey string) (string, error) {
+func (ps *PhotoServiceImpl) GenerateUploadPresignedUrl(ctx context.Context, deviceId string) (string, string, error) {
 	if len(deviceId) != 9 {
-		msg := fmt.Sprintf("invalid device_id %s length %d", deviceId, len(deviceId))
-		log.Error().Msg(msg)
-		return "", errors.New(msg)
-	}
-
-	// If idempotent key set, check on redis, the key format is deviceId:idempotentKey
-	// - if exists, return error
-	// - if not exists, generate a new presigned URL and store idempotent key marker in redis
-	key := fmt.Sprintf("media-service:generate-upload-presigned-url:idempotent:%s:%s", deviceId, idempotencyKey)
-
-	// Check if the key exists
-	if len(idempotencyKey) > 0 {
-		exists, err := ps.rdb.Exists(ctx, key).Result()
-		if err != nil {
-			log.Error().Msgf("failed to check key exists in Redis: %v", err)
-			return "", fmt.Errorf("failed to check key exists in Redis: %w", err)
-		}
-
-		if exists > 0 {
-			log.Info().Msgf("key already exists in Redis: %s", key)
-			return "", fmt.Errorf("key already exists in Redis: %s", key)
-		}
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return "", "", status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
 	}
 
 	log.Debug().Msgf("GetPhotoUploadUrl for device_id %s", deviceId)
@@ -76,19 +80,91 @@ func (ps *PhotoServiceImpl) GenerateUploadPresignedUrl(ctx context.Context, devi
 	uploadURL, err := ps.objStorage.GeneratePresignedUploadUrl(ctx, fileName, 15)
 	if err != nil {
 		log.Error().Msgf("failed to generate presigned URL: %v", err)
-		return "", fmt.Errorf("failed to generate presigned URL: %w", err)
+		return "", "", fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+
+	return uploadURL, fileName, nil
+}
+
+/**
+ * Called by the device once the upload to the presigned URL has finished.
+ * The photo is downloaded back from the object storage and analyzed.
+ *
+ * Returns the quality flags of the photo, empty when the photo looks fine.
+ */
+func (ps *PhotoServiceImpl) ConfirmUpload(ctx context.Context, deviceId, photoPath string) ([]string, error) {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return nil, status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	// Devices can only confirm photos in their own prefix
+	parts := strings.Split(photoPath, "/")
+	if len(parts) != 4 || parts[0] != deviceId || !strings.HasSuffix(parts[3], ".jpg") {
+		log.Error().Msgf("invalid photo path %s for device_id %s", photoPath, deviceId)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid photo path %s for device_id %s", photoPath, deviceId)
+	}
+
+	if _, err := time.Parse("2006-01-02", parts[1]); err != nil {
+		log.Error().Msgf("invalid date in photo path %s", photoPath)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid date in photo path %s", photoPath)
 	}
 
-	// Set the idempotent key marker in Redis
-	if len(idempotencyKey) > 0 {
-		_, err = ps.rdb.Set(ctx, key, "1", 15*time.Minute).Result()
+	log.Debug().Msgf("ConfirmUpload for device_id %s, photo_path %s", deviceId, photoPath)
+
+	data, err := ps.objStorage.GetObject(ctx, photoPath, constants.PHOTO_MAX_SIZE_BYTES)
+	if err != nil {
+		log.Error().Msgf("failed to get photo from object storage: %v", err)
+		return nil, fmt.Errorf("failed to get photo from object storage: %w", err)
+	}
+
+	// Record the photo as it is now, before anything else looks at it
+	if _, err := ps.integrityService.Append(ctx, deviceId, photoPath, data); err != nil {
+		log.Error().Msgf("failed to append photo to the integrity chain: %v", err)
+		return nil, fmt.Errorf("failed to append photo to the integrity chain: %w", err)
+	}
+
+	report, err := ps.qualityService.Analyze(ctx, deviceId, photoPath, data)
+	if err != nil {
+		log.Error().Msgf("failed to analyze photo quality: %v", err)
+		return nil, fmt.Errorf("failed to analyze photo quality: %w", err)
+	}
+
+	// Render the derived images while the photo is at hand, listing the
+	// photos never renders them.
+	// The upload itself is fine even when this fails, a listing queues the
+	// missing images to be rendered in the background.
+	if !report.HasFlag(quality.FlagCorrupt) {
+		opts, err := ps.renderOptions(ctx, deviceId, false)
 		if err != nil {
-			log.Error().Msgf("failed to set idempotent key marker in Redis: %v", err)
-			return "", fmt.Errorf("failed to set idempotent key marker in Redis: %w", err)
+			log.Error().Msgf("failed to get render options: %v", err)
+		} else {
+			if !opts.mask.IsEmpty() {
+				if _, err := ps.ensureDerived(ctx, DerivedKindMasked, photoPath, opts, data); err != nil {
+					log.Error().Msgf("failed to render masked photo: %v", err)
+				}
+			}
+
+			if _, err := ps.ensureDerived(ctx, DerivedKindThumbnail, photoPath, opts, data); err != nil {
+				log.Error().Msgf("failed to render thumbnail: %v", err)
+			}
 		}
 	}
 
-	return uploadURL, nil
+	// Let the watchers know about every photo that can be displayed
+	if !report.HasFlag(quality.FlagCorrupt) {
+		err = ps.feedService.Publish(ctx, feed.PhotoConfirmedEvent{
+			DeviceId:     deviceId,
+			PhotoPath:    photoPath,
+			QualityFlags: report.Flags,
+			ConfirmedAt:  time.Now().UTC().Unix(),
+		})
+		if err != nil {
+			log.Error().Msgf("failed to publish photo confirmed event: %v", err)
+		}
+	}
+
+	return report.Flags, nil
 }
 
 func (ps *PhotoServiceImpl) ListDate(ctx context.Context, deviceId string) ([]string, error) {
@@ -193,8 +269,12 @@ func (ps *PhotoServiceImpl) ListHourByDate(ctx context.Context, deviceId, date s
 
 /**
  * The returned file names
+ *
+ * When the device has a privacy mask, the download and thumbnail URLs point to
+ * the masked derived images, unless unmasked is set. The caller is responsible
+ * for checking that the user is allowed to see unmasked photos.
  */
-func (ps *PhotoServiceImpl) ListObjectsByDateHour(ctx context.Context, deviceId string, date string, hour int32) ([]ObjectFile, error) {
+func (ps *PhotoServiceImpl) ListObjectsByDateHour(ctx context.Context, deviceId string, date string, hour int32, unmasked bool) ([]ObjectFile, error) {
 	// Initialize array to store filenames from cache or object storage API
 	filenames := make([]string, 0)
 
@@ -302,21 +382,255 @@ func (ps *PhotoServiceImpl) ListObjectsByDateHour(ctx context.Context, deviceId
 		}
 	}
 
+	// Quality flags of the analyzed photos
+	qualityFlags, err := ps.qualityService.GetFlagsByDateHour(ctx, deviceId, date, hour, filenames)
+	if err != nil {
+		log.Error().Msgf("failed to get quality flags: %v", err)
+		return nil, fmt.Errorf("failed to get quality flags: %w", err)
+	}
+
+	// Privacy mask and watermark to apply on every served image
+	opts, err := ps.renderOptions(ctx, deviceId, unmasked)
+	if err != nil {
+		log.Error().Msgf("failed to get render options: %v", err)
+		return nil, fmt.Errorf("failed to get render options: %w", err)
+	}
+
 	// Build the result from filenames
-	result := make([]ObjectFile, 0)
-	for _, filename := range filenames {
-		fullpath := fmt.Sprintf("%s/%s", prefix, filename)
-		downloadURL, err := ps.objStorage.GeneratePresignedDownloadUrl(ctx, fullpath, constants.PHOTO_SERVICE_EXPIRATION_MINUTES)
+	fullpaths := make([]string, len(filenames))
+	for i, filename := range filenames {
+		fullpaths[i] = fmt.Sprintf("%s/%s", prefix, filename)
+	}
+
+	objectFiles, err := ps.buildObjectFiles(ctx, fullpaths, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ObjectFile, 0, len(objectFiles))
+	for _, objectFile := range objectFiles {
+		objectFile.QualityFlags = qualityFlags[objectFile.Name]
+		result = append(result, *objectFile)
+	}
+
+	return result, nil
+}
+
+/**
+ * Return the photo the same way ListObjectsByDateHour lists it, with the quality
+ * flags given by the caller.
+ */
+func (ps *PhotoServiceImpl) GetObjectFile(ctx context.Context, deviceId, photoPath string, qualityFlags []string, unmasked bool) (*ObjectFile, error) {
+	opts, err := ps.renderOptions(ctx, deviceId, unmasked)
+	if err != nil {
+		log.Error().Msgf("failed to get render options: %v", err)
+		return nil, fmt.Errorf("failed to get render options: %w", err)
+	}
+
+	objectFiles, err := ps.buildObjectFiles(ctx, []string{photoPath}, opts)
+	if err != nil {
+		return nil, err
+	}
+	objectFiles[0].QualityFlags = qualityFlags
+
+	return objectFiles[0], nil
+}
+
+/**
+ * Build the listed files from the photo paths. The derived images are never
+ * rendered here, a file whose image is not rendered yet is listed without its
+ * URL and the image is queued to be rendered in the background.
+ *
+ * Never fall back to the original photo when the masked one is missing.
+ */
+func (ps *PhotoServiceImpl) buildObjectFiles(ctx context.Context, fullpaths []string, opts renderOptions) ([]*ObjectFile, error) {
+	masked := !opts.mask.IsEmpty()
+
+	derivedPaths := make([]string, 0, 2*len(fullpaths))
+	for _, fullpath := range fullpaths {
+		if masked {
+			derivedPaths = append(derivedPaths, derivedPath(DerivedKindMasked, opts, fullpath))
+		}
+		derivedPaths = append(derivedPaths, derivedPath(DerivedKindThumbnail, opts, fullpath))
+	}
+
+	rendered, err := ps.derivedExist(ctx, derivedPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	objectFiles := make([]*ObjectFile, 0, len(fullpaths))
+	for _, fullpath := range fullpaths {
+		downloadPath := fullpath
+		if masked {
+			downloadPath = derivedPath(DerivedKindMasked, opts, fullpath)
+			if !rendered[downloadPath] {
+				ps.renderInBackground(ctx, DerivedKindMasked, fullpath, opts)
+				downloadPath = ""
+			}
+		}
+
+		thumbnailPath := derivedPath(DerivedKindThumbnail, opts, fullpath)
+		if !rendered[thumbnailPath] {
+			ps.renderInBackground(ctx, DerivedKindThumbnail, fullpath, opts)
+			thumbnailPath = ""
+		}
+
+		downloadURL, err := ps.presignedDownloadUrl(ctx, downloadPath)
+		if err != nil {
+			return nil, err
+		}
+
+		thumbnailURL, err := ps.presignedDownloadUrl(ctx, thumbnailPath)
 		if err != nil {
-			log.Error().Msgf("failed to generate presigned URL: %v", err)
-			return nil, fmt.Errorf("failed to generate presigned URL: %w", err)
+			return nil, err
 		}
 
-		result = append(result, ObjectFile{
-			Name:        filename,
-			DownloadUrl: downloadURL,
+		objectFiles = append(objectFiles, &ObjectFile{
+			Name:         fullpath[strings.LastIndex(fullpath, "/")+1:],
+			DownloadUrl:  downloadURL,
+			ThumbnailUrl: thumbnailURL,
+			Masked:       masked,
 		})
 	}
 
+	return objectFiles, nil
+}
+
+// An empty path has no URL
+func (ps *PhotoServiceImpl) presignedDownloadUrl(ctx context.Context, path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	url, err := ps.objStorage.GeneratePresignedDownloadUrl(ctx, path, constants.PHOTO_SERVICE_EXPIRATION_MINUTES)
+	if err != nil {
+		log.Error().Msgf("failed to generate presigned URL: %v", err)
+		return "", fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+
+	return url, nil
+}
+
+/**
+ * Return the JPEG of the photo for the live view, with the privacy mask unless
+ * unmasked is set. Like the listing, the original photo is never returned when
+ * the masked one can't be rendered.
+ */
+func (ps *PhotoServiceImpl) GetFrame(ctx context.Context, deviceId, photoPath string, unmasked bool) ([]byte, error) {
+	opts, err := ps.renderOptions(ctx, deviceId, unmasked)
+	if err != nil {
+		log.Error().Msgf("failed to get render options: %v", err)
+		return nil, fmt.Errorf("failed to get render options: %w", err)
+	}
+
+	framePath := photoPath
+	if !opts.mask.IsEmpty() {
+		framePath, err = ps.ensureDerived(ctx, DerivedKindMasked, photoPath, opts, nil)
+		if err != nil {
+			log.Error().Msgf("failed to render masked photo %s: %v", photoPath, err)
+			return nil, fmt.Errorf("failed to render masked photo %s: %w", photoPath, err)
+		}
+	}
+
+	data, err := ps.objStorage.GetObject(ctx, framePath, constants.PHOTO_MAX_SIZE_BYTES)
+	if err != nil {
+		log.Error().Msgf("failed to get photo from object storage: %v", err)
+		return nil, fmt.Errorf("failed to get photo from object storage: %w", err)
+	}
+
+	return data, nil
+}
+
+/**
+ * Render a full size copy of the photo to hand out, for example to a third
+ * party. The export gets the privacy mask unless unmasked is set, and the
+ * watermark when it is enabled for the device.
+ */
+func (ps *PhotoServiceImpl) ExportPhoto(ctx context.Context, deviceId, date string, hour int32, fileName string, unmasked bool) (*ExportedFile, error) {
+	// Validations
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return nil, status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		log.Error().Msgf("invalid date format %s", date)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid date format: %s", date)
+	}
+
+	if hour < 0 || hour > 23 {
+		log.Error().Msgf("invalid hour %d", hour)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid hour: %d", hour)
+	}
+
+	if strings.Contains(fileName, "/") || !strings.HasSuffix(strings.ToLower(fileName), ".jpg") {
+		log.Error().Msgf("invalid file name %s", fileName)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid file name: %s", fileName)
+	}
+
+	log.Debug().Msgf("ExportPhoto for device_id %s, date %s, hour %d, file_name %s", deviceId, date, hour, fileName)
+
+	opts, err := ps.renderOptions(ctx, deviceId, unmasked)
+	if err != nil {
+		log.Error().Msgf("failed to get render options: %v", err)
+		return nil, fmt.Errorf("failed to get render options: %w", err)
+	}
+
+	photoPath := fmt.Sprintf("%s/%s/%02d/%s", deviceId, date, hour, fileName)
+	exportPath, err := ps.ensureDerived(ctx, DerivedKindExport, photoPath, opts, nil)
+	if err != nil {
+		log.Error().Msgf("failed to render export: %v", err)
+		return nil, fmt.Errorf("failed to render export: %w", err)
+	}
+
+	downloadURL, err := ps.objStorage.GeneratePresignedDownloadUrl(ctx, exportPath, constants.PHOTO_SERVICE_EXPIRATION_MINUTES)
+	if err != nil {
+		log.Error().Msgf("failed to generate presigned URL: %v", err)
+		return nil, fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+
+	return &ExportedFile{
+		Name:        fileName,
+		DownloadUrl: downloadURL,
+		Masked:      !opts.mask.IsEmpty(),
+		Watermarked: watermarkApplies(DerivedKindExport, opts),
+	}, nil
+}
+
+/**
+ * Verify the integrity chain of the device, see integrity.Verify.
+ */
+func (ps *PhotoServiceImpl) VerifyIntegrity(ctx context.Context, deviceId, photoPath string, start int64, limit int) (*integrity.VerifyResult, error) {
+	log.Debug().Msgf("VerifyIntegrity for device_id %s, photo_path %s, start %d", deviceId, photoPath, start)
+
+	result, err := ps.integrityService.Verify(ctx, deviceId, photoPath, start, limit)
+	if err != nil {
+		log.Error().Msgf("failed to verify integrity chain: %v", err)
+		return nil, err
+	}
+
 	return result, nil
 }
+
+func (ps *PhotoServiceImpl) renderOptions(ctx context.Context, deviceId string, unmasked bool) (renderOptions, error) {
+	opts := renderOptions{
+		mask: &privacy.Mask{},
+	}
+
+	if !unmasked {
+		mask, err := ps.privacyService.GetMask(ctx, deviceId)
+		if err != nil {
+			return opts, fmt.Errorf("failed to get privacy mask: %w", err)
+		}
+		opts.mask = mask
+	}
+
+	settings, err := ps.watermarkService.GetSettings(ctx, deviceId)
+	if err != nil {
+		return opts, fmt.Errorf("failed to get watermark settings: %w", err)
+	}
+	opts.watermark = settings
+
+	return opts, nil
+}
@@ -1,15 +1,35 @@
 package photo
 
-import "context"
+import (
+	"context"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/service/integrity"
+)
 
 type ObjectFile struct {
+	Name         string
+	DownloadUrl  string
+	ThumbnailUrl string
+	Masked       bool
+	QualityFlags []string
+}
+
+type ExportedFile struct {
 	Name        string
 	DownloadUrl string
+	Masked      bool
+	Watermarked bool
 }
 
 type PhotoServiceIface interface {
-	GenerateUploadPresignedUrl(ctx context.Context, deviceId, idempotentKey string) (string, error)
+	GenerateUploadPresignedUrl(ctx context.Context, deviceId string) (string, string, error)
+	ConfirmUpload(ctx context.Context, deviceId, photoPath string) ([]string, error)
 	ListDate(ctx context.Context, deviceId string) ([]string, error)
 	ListHourByDate(ctx context.Context, deviceId, date string) ([]string, error)
-	ListObjectsByDateHour(ctx context.Context, deviceId string, date string, hour int32) ([]ObjectFile, error)
+	ListObjectsByDateHour(ctx context.Context, deviceId string, date string, hour int32, unmasked bool) ([]ObjectFile, error)
+	GetObjectFile(ctx context.Context, deviceId, photoPath string, qualityFlags []string, unmasked bool) (*ObjectFile, error)
+	GetFrame(ctx context.Context, deviceId, photoPath string, unmasked bool) ([]byte, error)
+	ExportPhoto(ctx context.Context, deviceId, date string, hour int32, fileName string, unmasked bool) (*ExportedFile, error)
+	VerifyIntegrity(ctx context.Context, deviceId, photoPath string, start int64, limit int) (*integrity.VerifyResult, error)
+	PurgeDerived(ctx context.Context, deviceId string, maskVersion int64) error
 }
@@ -0,0 +1,146 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+const (
+	maxPolygons         = 16
+	maxPointsPerPolygon = 64
+)
+
+// Set the version and the mask together, only when the version is the next
+// one of the stored version. Returns 0 when another change got it first.
+//
+//	KEYS[1] the version, KEYS[2] the mask
+//	ARGV[1] the new version, ARGV[2] the mask JSON
+var setMaskScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+if current + 1 ~= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('SET', KEYS[2], ARGV[2])
+return 1
+`)
+
+type PrivacyServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) PrivacyServiceIface {
+	return &PrivacyServiceImpl{
+		rdb: rdb,
+	}
+}
+
+/**
+ * Return the privacy mask of the device.
+ * A device without configured mask gets an empty mask with version 0.
+ */
+func (ps *PrivacyServiceImpl) GetMask(ctx context.Context, deviceId string) (*Mask, error) {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return nil, status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	redisKey := fmt.Sprintf("media-service:privacy-mask:%s", deviceId)
+	maskJson, err := ps.rdb.Get(ctx, redisKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return &Mask{Polygons: make([]Polygon, 0)}, nil
+		}
+
+		log.Error().Msgf("failed to get privacy mask from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get privacy mask from Redis: %w", err)
+	}
+
+	var mask Mask
+	if err := json.Unmarshal([]byte(maskJson), &mask); err != nil {
+		log.Error().Msgf("failed to unmarshal privacy mask: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal privacy mask: %w", err)
+	}
+
+	return &mask, nil
+}
+
+/**
+ * Replace the privacy mask of the device, an empty polygon list removes it.
+ *
+ * Every change gets a new version, the derived images are stored per mask
+ * version so the old renders are never served again.
+ */
+func (ps *PrivacyServiceImpl) SetMask(ctx context.Context, deviceId string, polygons []Polygon) (*Mask, error) {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return nil, status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	if len(polygons) > maxPolygons {
+		return nil, status.Errorf(codes.InvalidArgument, "too many polygons: %d, max %d", len(polygons), maxPolygons)
+	}
+
+	for i, polygon := range polygons {
+		if len(polygon.Points) < 3 || len(polygon.Points) > maxPointsPerPolygon {
+			return nil, status.Errorf(codes.InvalidArgument, "polygon %d must have between 3 and %d points", i, maxPointsPerPolygon)
+		}
+
+		for _, point := range polygon.Points {
+			if point.X < 0 || point.X > 1 || point.Y < 0 || point.Y > 1 {
+				return nil, status.Errorf(codes.InvalidArgument, "polygon %d has point (%f, %f) outside of the frame", i, point.X, point.Y)
+			}
+		}
+	}
+
+	versionKey := fmt.Sprintf("media-service:privacy-mask:version:%s", deviceId)
+	redisKey := fmt.Sprintf("media-service:privacy-mask:%s", deviceId)
+
+	var mask *Mask
+	for attempt := 0; ; attempt++ {
+		if attempt == constants.PRIVACY_MASK_SET_MAX_ATTEMPTS {
+			log.Error().Msgf("privacy mask of device_id %s changed concurrently %d times", deviceId, attempt)
+			return nil, status.Errorf(codes.Aborted, "privacy mask changed concurrently, try again")
+		}
+
+		current, err := ps.rdb.Get(ctx, versionKey).Int64()
+		if err != nil && err != redis.Nil {
+			log.Error().Msgf("failed to get privacy mask version from Redis: %v", err)
+			return nil, fmt.Errorf("failed to get privacy mask version from Redis: %w", err)
+		}
+
+		mask = &Mask{
+			Version:   current + 1,
+			Polygons:  polygons,
+			UpdatedAt: time.Now().UTC().Unix(),
+		}
+
+		maskJson, err := json.Marshal(mask)
+		if err != nil {
+			log.Error().Msgf("failed to marshal privacy mask: %v", err)
+			return nil, fmt.Errorf("failed to marshal privacy mask: %w", err)
+		}
+
+		stored, err := setMaskScript.Run(ctx, ps.rdb, []string{versionKey, redisKey}, mask.Version, maskJson).Int()
+		if err != nil {
+			log.Error().Msgf("failed to set privacy mask in Redis: %v", err)
+			return nil, fmt.Errorf("failed to set privacy mask in Redis: %w", err)
+		}
+		if stored == 1 {
+			break
+		}
+	}
+
+	log.Info().Msgf("privacy mask of device_id %s set to version %d with %d polygons", deviceId, mask.Version, len(polygons))
+
+	return mask, nil
+}
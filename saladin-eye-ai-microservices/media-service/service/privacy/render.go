@@ -0,0 +1,68 @@
+package privacy
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/internal/imaging"
+)
+
+var maskColor = color.RGBA{R: 0, G: 0, B: 0, A: 255}
+
+// Apply returns a copy of the image with every polygon of the mask painted
+// over. The original image is not modified.
+func Apply(img image.Image, mask *Mask) *image.RGBA {
+	rgba := imaging.ToRGBA(img)
+	if mask.IsEmpty() {
+		return rgba
+	}
+
+	width := rgba.Bounds().Dx()
+	height := rgba.Bounds().Dy()
+
+	for _, polygon := range mask.Polygons {
+		fillPolygon(rgba, polygon, width, height)
+	}
+
+	return rgba
+}
+
+// fillPolygon uses a scanline even-odd fill, each row is sampled at its
+// center. Partially covered pixels at the left and right edges are painted too.
+func fillPolygon(rgba *image.RGBA, polygon Polygon, width, height int) {
+	n := len(polygon.Points)
+	if n < 3 {
+		return
+	}
+
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i, point := range polygon.Points {
+		xs[i] = point.X * float64(width)
+		ys[i] = point.Y * float64(height)
+	}
+
+	crossings := make([]float64, 0, n)
+	for y := 0; y < height; y++ {
+		scanY := float64(y) + 0.5
+
+		crossings = crossings[:0]
+		for i := 0; i < n; i++ {
+			j := (i + 1) % n
+			if (ys[i] <= scanY && ys[j] > scanY) || (ys[j] <= scanY && ys[i] > scanY) {
+				crossings = append(crossings, xs[i]+(scanY-ys[i])/(ys[j]-ys[i])*(xs[j]-xs[i]))
+			}
+		}
+		sort.Float64s(crossings)
+
+		for k := 0; k+1 < len(crossings); k += 2 {
+			x0 := max(int(crossings[k]), 0)
+			x1 := min(int(math.Ceil(crossings[k+1])), width)
+			for x := x0; x < x1; x++ {
+				rgba.SetRGBA(x, y, maskColor)
+			}
+		}
+	}
+}
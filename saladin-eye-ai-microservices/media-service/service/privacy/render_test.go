@@ -0,0 +1,146 @@
+package privacy
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// painted returns the pixels of the image painted with the mask color, as
+// rows of '#' and '.'
+func painted(rgba *image.RGBA) []string {
+	bounds := rgba.Bounds()
+
+	rows := make([]string, 0, bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row := make([]byte, 0, bounds.Dx())
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if rgba.RGBAAt(x, y) == maskColor {
+				row = append(row, '#')
+			} else {
+				row = append(row, '.')
+			}
+		}
+		rows = append(rows, string(row))
+	}
+
+	return rows
+}
+
+func whiteImage(width, height int) *image.RGBA {
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			rgba.SetRGBA(x, y, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		}
+	}
+	return rgba
+}
+
+func TestFillPolygon(t *testing.T) {
+	tests := []struct {
+		name    string
+		polygon Polygon
+		rows    []string
+	}{
+		{
+			"rectangle",
+			Polygon{Points: []Point{{0.25, 0.25}, {0.75, 0.25}, {0.75, 0.75}, {0.25, 0.75}}},
+			[]string{
+				"........",
+				"........",
+				"..####..",
+				"..####..",
+				"..####..",
+				"..####..",
+				"........",
+				"........",
+			},
+		},
+		{
+			"whole frame",
+			Polygon{Points: []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}}},
+			[]string{
+				"########",
+				"########",
+				"########",
+				"########",
+				"########",
+				"########",
+				"########",
+				"########",
+			},
+		},
+		{
+			// The partially covered pixels at the edges are painted too
+			"triangle",
+			Polygon{Points: []Point{{0, 0}, {1, 1}, {0, 1}}},
+			[]string{
+				"#.......",
+				"##......",
+				"###.....",
+				"####....",
+				"#####...",
+				"######..",
+				"#######.",
+				"########",
+			},
+		},
+		{
+			// Even-odd, the notch of the U is not painted
+			"concave",
+			Polygon{Points: []Point{{0, 0}, {0.25, 0}, {0.25, 0.5}, {0.75, 0.5}, {0.75, 0}, {1, 0}, {1, 1}, {0, 1}}},
+			[]string{
+				"##....##",
+				"##....##",
+				"##....##",
+				"##....##",
+				"########",
+				"########",
+				"########",
+				"########",
+			},
+		},
+		{
+			"two points",
+			Polygon{Points: []Point{{0, 0}, {1, 1}}},
+			[]string{
+				"........",
+				"........",
+				"........",
+				"........",
+				"........",
+				"........",
+				"........",
+				"........",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		rgba := whiteImage(8, 8)
+		fillPolygon(rgba, tt.polygon, 8, 8)
+
+		got := painted(rgba)
+		for y := range tt.rows {
+			if got[y] != tt.rows[y] {
+				t.Errorf("%s: row %d is %s, want %s", tt.name, y, got[y], tt.rows[y])
+			}
+		}
+	}
+}
+
+func TestApplyLeavesOriginal(t *testing.T) {
+	original := whiteImage(8, 8)
+
+	masked := Apply(original, &Mask{Version: 1, Polygons: []Polygon{
+		{Points: []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}}},
+	}})
+
+	if masked.RGBAAt(4, 4) != maskColor {
+		t.Errorf("masked pixel is %v, want %v", masked.RGBAAt(4, 4), maskColor)
+	}
+	if original.RGBAAt(4, 4) == maskColor {
+		t.Errorf("original image was painted")
+	}
+}
@@ -0,0 +1,29 @@
+package privacy
+
+import "context"
+
+// Point in normalized image coordinates, from 0.0 (left/top) to 1.0 (right/bottom)
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type Polygon struct {
+	Points []Point `json:"points"`
+}
+
+type Mask struct {
+	Version   int64     `json:"version"`
+	Polygons  []Polygon `json:"polygons"`
+	UpdatedAt int64     `json:"updated_at"`
+}
+
+// IsEmpty is true when there is nothing to hide on the device frames
+func (mask *Mask) IsEmpty() bool {
+	return mask == nil || len(mask.Polygons) == 0
+}
+
+type PrivacyServiceIface interface {
+	GetMask(ctx context.Context, deviceId string) (*Mask, error)
+	SetMask(ctx context.Context, deviceId string, polygons []Polygon) (*Mask, error)
+}
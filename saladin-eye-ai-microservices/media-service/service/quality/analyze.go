@@ -0,0 +1,160 @@
+package quality
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"math"
+	"math/bits"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+// The analysis works on a downsampled luminance plane, so a 1600x1200 UXGA
+// frame from the ESP32 camera costs about the same as a QVGA one.
+const analysisMaxDimension = 320
+
+// analyzeImage decodes the JPEG and fills the per-frame metrics and flags.
+// The scene change flag needs the previous frame, so it is set by the caller.
+func analyzeImage(data []byte) *Report {
+	report := &Report{
+		Flags: make([]string, 0),
+	}
+
+	// A complete JPEG always ends with the EOI marker, uploads cut short by the
+	// SD card or the network will miss it
+	if len(data) < 2 || data[len(data)-2] != 0xFF || data[len(data)-1] != 0xD9 {
+		report.Flags = append(report.Flags, FlagTruncated)
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		report.Flags = append(report.Flags, FlagCorrupt)
+		return report
+	}
+
+	bounds := img.Bounds()
+	report.Width = bounds.Dx()
+	report.Height = bounds.Dy()
+
+	luma, width, height := luminancePlane(img)
+	if width < 3 || height < 3 {
+		report.Flags = append(report.Flags, FlagCorrupt)
+		return report
+	}
+
+	report.MeanLuminance, report.LuminanceStdDev = meanStdDev(luma)
+	report.LaplacianVariance = laplacianVariance(luma, width, height)
+	report.Fingerprint = averageHash(luma, width, height)
+
+	if report.MeanLuminance < constants.QUALITY_DARK_MEAN_LUMINANCE {
+		report.Flags = append(report.Flags, FlagDark)
+	}
+
+	if report.MeanLuminance > constants.QUALITY_OVEREXPOSED_MEAN_LUMINANCE {
+		report.Flags = append(report.Flags, FlagOverexposed)
+	}
+
+	// A covered lens gives an almost uniform frame, which also has no edges at
+	// all. Only report blur when there is something in the frame to be blurred.
+	if report.LuminanceStdDev < constants.QUALITY_OBSTRUCTED_LUMINANCE_STDDEV {
+		report.Flags = append(report.Flags, FlagObstructed)
+	} else if report.LaplacianVariance < constants.QUALITY_BLURRED_LAPLACIAN_VARIANCE {
+		report.Flags = append(report.Flags, FlagBlurred)
+	}
+
+	return report
+}
+
+// luminancePlane samples the image into a grayscale plane (ITU-R BT.601)
+// no larger than analysisMaxDimension on its longest side.
+func luminancePlane(img image.Image) ([]float64, int, int) {
+	bounds := img.Bounds()
+
+	step := 1
+	for bounds.Dx()/step > analysisMaxDimension || bounds.Dy()/step > analysisMaxDimension {
+		step++
+	}
+
+	width := bounds.Dx() / step
+	height := bounds.Dy() / step
+	luma := make([]float64, width*height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x*step, bounds.Min.Y+y*step).RGBA()
+			luma[y*width+x] = (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 257
+		}
+	}
+
+	return luma, width, height
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var sqDiff float64
+	for _, v := range values {
+		sqDiff += (v - mean) * (v - mean)
+	}
+
+	return mean, math.Sqrt(sqDiff / float64(len(values)))
+}
+
+// laplacianVariance is the variance of the 4-neighbour Laplacian, a sharp
+// frame has strong edges and therefore a high variance.
+func laplacianVariance(luma []float64, width, height int) float64 {
+	laplacian := make([]float64, 0, (width-2)*(height-2))
+
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			i := y*width + x
+			laplacian = append(laplacian, luma[i-width]+luma[i+width]+luma[i-1]+luma[i+1]-4*luma[i])
+		}
+	}
+
+	_, stdDev := meanStdDev(laplacian)
+	return stdDev * stdDev
+}
+
+// averageHash reduces the frame to 8x8 cells and sets one bit per cell that is
+// brighter than the frame average. Frames of the same scene have hashes that
+// differ in only a few bits, whatever the exposure.
+func averageHash(luma []float64, width, height int) uint64 {
+	var cells [64]float64
+	var counts [64]int
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cell := (y*8/height)*8 + x*8/width
+			cells[cell] += luma[y*width+x]
+			counts[cell]++
+		}
+	}
+
+	var mean float64
+	for i := range cells {
+		if counts[i] > 0 {
+			cells[i] /= float64(counts[i])
+		}
+		mean += cells[i]
+	}
+	mean /= 64
+
+	var hash uint64
+	for i, cell := range cells {
+		if cell > mean {
+			hash |= 1 << uint(i)
+		}
+	}
+
+	return hash
+}
+
+func hammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,99 @@
+package quality
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"reflect"
+	"testing"
+)
+
+const testFrameSize = 64
+
+// encodeFrame encodes a gray frame with the luminance of every pixel
+func encodeFrame(t *testing.T, luminance func(x, y int) uint8) []byte {
+	t.Helper()
+
+	img := image.NewGray(image.Rect(0, 0, testFrameSize, testFrameSize))
+	for y := 0; y < testFrameSize; y++ {
+		for x := 0; x < testFrameSize; x++ {
+			img.SetGray(x, y, color.Gray{Y: luminance(x, y)})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("failed to encode frame: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func uniform(value uint8) func(x, y int) uint8 {
+	return func(x, y int) uint8 {
+		return value
+	}
+}
+
+// Squares of 8 pixels, sharp edges all over the frame
+func checkerboard(x, y int) uint8 {
+	if (x/8+y/8)%2 == 0 {
+		return 64
+	}
+	return 192
+}
+
+// A left to right ramp, contrast without any edge
+func gradient(x, y int) uint8 {
+	return uint8(x * 255 / (testFrameSize - 1))
+}
+
+func TestAnalyzeImage(t *testing.T) {
+	sharp := encodeFrame(t, checkerboard)
+
+	tests := []struct {
+		name  string
+		data  []byte
+		flags []string
+	}{
+		{"sharp", sharp, []string{}},
+		{"dark", encodeFrame(t, uniform(10)), []string{FlagDark, FlagObstructed}},
+		{"overexposed", encodeFrame(t, uniform(250)), []string{FlagOverexposed, FlagObstructed}},
+		{"obstructed", encodeFrame(t, uniform(128)), []string{FlagObstructed}},
+		{"blurred", encodeFrame(t, gradient), []string{FlagBlurred}},
+		{"truncated", sharp[:len(sharp)-2], []string{FlagTruncated, FlagCorrupt}},
+		{"not a jpeg", []byte("not a jpeg"), []string{FlagTruncated, FlagCorrupt}},
+		{"empty", []byte{}, []string{FlagTruncated, FlagCorrupt}},
+	}
+
+	for _, tt := range tests {
+		report := analyzeImage(tt.data)
+		if !reflect.DeepEqual(report.Flags, tt.flags) {
+			t.Errorf("%s: flags %v, want %v", tt.name, report.Flags, tt.flags)
+		}
+	}
+}
+
+func TestAnalyzeImageFingerprint(t *testing.T) {
+	sharp := analyzeImage(encodeFrame(t, checkerboard))
+	if sharp.Width != testFrameSize || sharp.Height != testFrameSize {
+		t.Errorf("size %dx%d, want %dx%d", sharp.Width, sharp.Height, testFrameSize, testFrameSize)
+	}
+
+	// The same scene, brighter
+	brighter := analyzeImage(encodeFrame(t, func(x, y int) uint8 {
+		return checkerboard(x, y) + 40
+	}))
+	if distance := hammingDistance(sharp.Fingerprint, brighter.Fingerprint); distance != 0 {
+		t.Errorf("brighter frame of the same scene at distance %d, want 0", distance)
+	}
+
+	// Another scene
+	inverted := analyzeImage(encodeFrame(t, func(x, y int) uint8 {
+		return 255 - checkerboard(x, y)
+	}))
+	if distance := hammingDistance(sharp.Fingerprint, inverted.Fingerprint); distance != 64 {
+		t.Errorf("inverted frame at distance %d, want 64", distance)
+	}
+}
@@ -0,0 +1,254 @@
+package quality
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/andypmw/saladin-eye-ai/media-service/common/constants"
+)
+
+const (
+	// Redis stream consumed by whoever watches the camera fleet
+	DeviceHealthEventStream = "media-service:events:device-health"
+
+	deviceHealthEventStreamMaxLen = 10000
+	fingerprintTTL                = 1 * time.Hour
+)
+
+type QualityServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) QualityServiceIface {
+	return &QualityServiceImpl{
+		rdb: rdb,
+	}
+}
+
+/**
+ * Analyze the photo content, store the report next to the other photos of the
+ * same date, and keep track of consecutive bad frames of the device.
+ *
+ * The photo path is in the object storage format:
+ *   [Device ID]/[YYYY-MM-DD]/[HH]/[mm]-[ss].jpg
+ *
+ * A photo is only analyzed once, confirming it again returns the stored
+ * report without counting it in the bad frame streak again.
+ */
+func (qs *QualityServiceImpl) Analyze(ctx context.Context, deviceId, photoPath string, data []byte) (*Report, error) {
+	parts := strings.SplitN(photoPath, "/", 3)
+	if len(parts) != 3 || parts[0] != deviceId {
+		log.Error().Msgf("invalid photo path %s for device_id %s", photoPath, deviceId)
+		return nil, fmt.Errorf("invalid photo path %s for device_id %s", photoPath, deviceId)
+	}
+	date := parts[1]
+	field := parts[2]
+	reportKey := fmt.Sprintf("media-service:photo-quality:%s:%s", deviceId, date)
+
+	existing, err := qs.getReport(ctx, reportKey, field)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	report := analyzeImage(data)
+	report.PhotoPath = photoPath
+	report.AnalyzedAt = time.Now().UTC().Unix()
+
+	// Compare good frames with the previous good frame, a big jump means the
+	// camera has been moved or something was put in front of it
+	fingerprintKey := fmt.Sprintf("media-service:photo-quality:fingerprint:%s", deviceId)
+	goodFrame := len(report.Flags) == 0
+	if goodFrame {
+		previous, err := qs.rdb.Get(ctx, fingerprintKey).Result()
+		if err != nil && err != redis.Nil {
+			log.Error().Msgf("failed to get previous fingerprint from Redis: %v", err)
+			return nil, fmt.Errorf("failed to get previous fingerprint from Redis: %w", err)
+		}
+
+		if previousFingerprint, err := strconv.ParseUint(previous, 10, 64); err == nil {
+			report.SceneDistance = hammingDistance(previousFingerprint, report.Fingerprint)
+			if report.SceneDistance >= constants.QUALITY_SCENE_CHANGE_HAMMING_DISTANCE {
+				report.Flags = append(report.Flags, FlagSceneChange)
+			}
+		}
+	}
+
+	// Store the report
+	reportJson, err := json.Marshal(report)
+	if err != nil {
+		log.Error().Msgf("failed to marshal quality report: %v", err)
+		return nil, fmt.Errorf("failed to marshal quality report: %w", err)
+	}
+
+	// The daily hash goes once its last photo is old enough. Only the first
+	// of two concurrent confirmations stores its report and counts it.
+	var stored *redis.BoolCmd
+	_, err = qs.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		stored = pipe.HSetNX(ctx, reportKey, field, reportJson)
+		pipe.Expire(ctx, reportKey, constants.QUALITY_REPORT_RETENTION_DAYS*24*time.Hour)
+		return nil
+	})
+	if err != nil {
+		log.Error().Msgf("failed to store quality report in Redis: %v", err)
+		return nil, fmt.Errorf("failed to store quality report in Redis: %w", err)
+	}
+	if !stored.Val() {
+		return qs.getReport(ctx, reportKey, field)
+	}
+
+	// Only the confirmation that stored the report moves the fingerprint on,
+	// a concurrent one must not compare the next frame with its own
+	if goodFrame {
+		_, err = qs.rdb.Set(ctx, fingerprintKey, strconv.FormatUint(report.Fingerprint, 10), fingerprintTTL).Result()
+		if err != nil {
+			log.Error().Msgf("failed to set fingerprint in Redis: %v", err)
+			return nil, fmt.Errorf("failed to set fingerprint in Redis: %w", err)
+		}
+	}
+
+	if err := qs.trackHealth(ctx, deviceId, report); err != nil {
+		return nil, err
+	}
+
+	log.Debug().Msgf("quality report for %s: flags %v", photoPath, report.Flags)
+
+	return report, nil
+}
+
+// The stored report of the photo, nil when it was never analyzed
+func (qs *QualityServiceImpl) getReport(ctx context.Context, reportKey, field string) (*Report, error) {
+	reportJson, err := qs.rdb.HGet(ctx, reportKey, field).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		log.Error().Msgf("failed to get quality report from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get quality report from Redis: %w", err)
+	}
+
+	var report Report
+	if err := json.Unmarshal([]byte(reportJson), &report); err != nil {
+		log.Error().Msgf("failed to unmarshal quality report: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal quality report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// trackHealth counts consecutive bad frames per device. A health event is
+// raised once when the streak reaches the threshold, and on every scene change.
+func (qs *QualityServiceImpl) trackHealth(ctx context.Context, deviceId string, report *Report) error {
+	streakKey := fmt.Sprintf("media-service:photo-quality:bad-frame-streak:%s", deviceId)
+
+	badFlags := make([]string, 0)
+	sceneChanged := false
+	for _, flag := range report.Flags {
+		if flag == FlagSceneChange {
+			sceneChanged = true
+		} else {
+			badFlags = append(badFlags, flag)
+		}
+	}
+
+	if len(badFlags) == 0 {
+		if _, err := qs.rdb.Del(ctx, streakKey).Result(); err != nil {
+			log.Error().Msgf("failed to reset bad frame streak in Redis: %v", err)
+			return fmt.Errorf("failed to reset bad frame streak in Redis: %w", err)
+		}
+	} else {
+		streak, err := qs.rdb.Incr(ctx, streakKey).Result()
+		if err != nil {
+			log.Error().Msgf("failed to increment bad frame streak in Redis: %v", err)
+			return fmt.Errorf("failed to increment bad frame streak in Redis: %w", err)
+		}
+
+		if streak == constants.QUALITY_BAD_FRAME_STREAK_THRESHOLD {
+			if err := qs.publishHealthEvent(ctx, deviceId, "bad_frames", streak, badFlags, report.PhotoPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	if sceneChanged {
+		if err := qs.publishHealthEvent(ctx, deviceId, "scene_change", 0, []string{FlagSceneChange}, report.PhotoPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (qs *QualityServiceImpl) publishHealthEvent(ctx context.Context, deviceId, reason string, streak int64, flags []string, photoPath string) error {
+	log.Warn().Msgf("device health event for device_id %s: %s %v", deviceId, reason, flags)
+
+	_, err := qs.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: DeviceHealthEventStream,
+		MaxLen: deviceHealthEventStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"device_id":  deviceId,
+			"reason":     reason,
+			"streak":     streak,
+			"flags":      strings.Join(flags, ","),
+			"photo_path": photoPath,
+			"timestamp":  time.Now().UTC().Unix(),
+		},
+	}).Result()
+	if err != nil {
+		log.Error().Msgf("failed to publish device health event: %v", err)
+		return fmt.Errorf("failed to publish device health event: %w", err)
+	}
+
+	return nil
+}
+
+/**
+ * Return the quality flags of the given files, keyed by file name.
+ * Files that were never analyzed are not in the result.
+ */
+func (qs *QualityServiceImpl) GetFlagsByDateHour(ctx context.Context, deviceId, date string, hour int32, fileNames []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	if len(fileNames) == 0 {
+		return result, nil
+	}
+
+	fields := make([]string, 0, len(fileNames))
+	for _, fileName := range fileNames {
+		fields = append(fields, fmt.Sprintf("%02d/%s", hour, fileName))
+	}
+
+	reportKey := fmt.Sprintf("media-service:photo-quality:%s:%s", deviceId, date)
+	values, err := qs.rdb.HMGet(ctx, reportKey, fields...).Result()
+	if err != nil {
+		log.Error().Msgf("failed to get quality reports from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get quality reports from Redis: %w", err)
+	}
+
+	for i, value := range values {
+		reportJson, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var report Report
+		if err := json.Unmarshal([]byte(reportJson), &report); err != nil {
+			log.Error().Msgf("failed to unmarshal quality report %s: %v", fields[i], err)
+			continue
+		}
+
+		result[fileNames[i]] = report.Flags
+	}
+
+	return result, nil
+}
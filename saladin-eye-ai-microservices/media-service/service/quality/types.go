@@ -0,0 +1,40 @@
+package quality
+
+import "context"
+
+const (
+	FlagCorrupt     = "corrupt"
+	FlagTruncated   = "truncated"
+	FlagDark        = "dark"
+	FlagOverexposed = "overexposed"
+	FlagBlurred     = "blurred"
+	FlagObstructed  = "obstructed"
+	FlagSceneChange = "scene_change"
+)
+
+type Report struct {
+	PhotoPath         string   `json:"photo_path"`
+	Flags             []string `json:"flags"`
+	Width             int      `json:"width"`
+	Height            int      `json:"height"`
+	MeanLuminance     float64  `json:"mean_luminance"`
+	LuminanceStdDev   float64  `json:"luminance_stddev"`
+	LaplacianVariance float64  `json:"laplacian_variance"`
+	SceneDistance     int      `json:"scene_distance"`
+	Fingerprint       uint64   `json:"fingerprint"`
+	AnalyzedAt        int64    `json:"analyzed_at"`
+}
+
+func (report *Report) HasFlag(flag string) bool {
+	for _, f := range report.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+type QualityServiceIface interface {
+	Analyze(ctx context.Context, deviceId, photoPath string, data []byte) (*Report, error)
+	GetFlagsByDateHour(ctx context.Context, deviceId, date string, hour int32, fileNames []string) (map[string][]string, error)
+}
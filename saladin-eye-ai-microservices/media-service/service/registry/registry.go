@@ -0,0 +1,148 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RegistryServiceImpl struct {
+	rdb               redis.Cmdable
+	allowUnregistered bool
+}
+
+/**
+ * The device registry is kept by camera-service, a hash per device:
+ *   saladin-eye:camera-service:device:[deviceId]
+ *
+ * Only its enabled field is read here. allowUnregistered lets the devices not
+ * registered yet through, while the registry is being filled.
+ */
+func New(rdb redis.Cmdable, allowUnregistered bool) RegistryServiceIface {
+	return &RegistryServiceImpl{
+		rdb:               rdb,
+		allowUnregistered: allowUnregistered,
+	}
+}
+
+func deviceRedisKey(deviceId string) string {
+	return fmt.Sprintf("saladin-eye:camera-service:device:%s", deviceId)
+}
+
+// Every registered device id, a sorted set of score 0
+const devicesRedisKey = "saladin-eye:camera-service:devices"
+
+// The devices read from Redis at once when listing
+const listBatchSize = 500
+
+/**
+ * Check the device is registered and enabled.
+ *
+ * Returns a NotFound status error for a device not registered, and a
+ * PermissionDenied one for a disabled device.
+ */
+func (rs *RegistryServiceImpl) Check(ctx context.Context, deviceId string) error {
+	return rs.check(ctx, deviceId, false)
+}
+
+// CheckRegistered lets the disabled devices through too, for managing them
+func (rs *RegistryServiceImpl) CheckRegistered(ctx context.Context, deviceId string) error {
+	return rs.check(ctx, deviceId, true)
+}
+
+func (rs *RegistryServiceImpl) check(ctx context.Context, deviceId string, allowDisabled bool) error {
+	enabled, err := rs.rdb.HGet(ctx, deviceRedisKey(deviceId), "enabled").Result()
+	if err != nil {
+		if err == redis.Nil {
+			if rs.allowUnregistered {
+				log.Warn().Msgf("accepting device_id %s not registered", deviceId)
+				return nil
+			}
+
+			return status.Errorf(codes.NotFound, "device_id %s is not registered", deviceId)
+		}
+
+		log.Error().Msgf("failed to get device from Redis: %v", err)
+		return fmt.Errorf("failed to get device from Redis: %w", err)
+	}
+
+	if enabled != "1" && !allowDisabled {
+		return status.Errorf(codes.PermissionDenied, "device_id %s is disabled", deviceId)
+	}
+
+	return nil
+}
+
+/**
+ * List the enabled devices of the owner, at the location and with the tag.
+ * The filters left empty match every device, at least one must be set.
+ */
+func (rs *RegistryServiceImpl) ListDeviceIds(ctx context.Context, owner, location, tag string) ([]string, error) {
+	if owner == "" && location == "" && tag == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "missing owner, location or tag")
+	}
+
+	deviceIds := []string{}
+	start := "-"
+
+	for {
+		batch, err := rs.rdb.ZRangeByLex(ctx, devicesRedisKey, &redis.ZRangeBy{
+			Min:   start,
+			Max:   "+",
+			Count: listBatchSize,
+		}).Result()
+		if err != nil {
+			log.Error().Msgf("failed to list devices from Redis: %v", err)
+			return nil, fmt.Errorf("failed to list devices from Redis: %w", err)
+		}
+		if len(batch) == 0 {
+			return deviceIds, nil
+		}
+
+		cmds := make([]*redis.SliceCmd, 0, len(batch))
+		_, err = rs.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, deviceId := range batch {
+				cmds = append(cmds, pipe.HMGet(ctx, deviceRedisKey(deviceId), "owner", "location", "tags", "enabled"))
+			}
+			return nil
+		})
+		if err != nil {
+			log.Error().Msgf("failed to get devices from Redis: %v", err)
+			return nil, fmt.Errorf("failed to get devices from Redis: %w", err)
+		}
+
+		for i, cmd := range cmds {
+			// Missing fields, or the device deleted in between, are nil
+			fields := make([]string, 4)
+			for j, value := range cmd.Val() {
+				fields[j], _ = value.(string)
+			}
+
+			if fields[3] != "1" {
+				continue
+			}
+			if owner != "" && fields[0] != owner {
+				continue
+			}
+			if location != "" && fields[1] != location {
+				continue
+			}
+			if tag != "" && !slices.Contains(strings.Split(fields[2], ","), tag) {
+				continue
+			}
+
+			deviceIds = append(deviceIds, batch[i])
+		}
+
+		if len(batch) < listBatchSize {
+			return deviceIds, nil
+		}
+		start = "(" + batch[len(batch)-1]
+	}
+}
@@ -0,0 +1,9 @@
+package registry
+
+import "context"
+
+type RegistryServiceIface interface {
+	Check(ctx context.Context, deviceId string) error
+	CheckRegistered(ctx context.Context, deviceId string) error
+	ListDeviceIds(ctx context.Context, owner, location, tag string) ([]string, error)
+}
@@ -0,0 +1,99 @@
+package watermark
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// Width in pixels covered by one scale step of the 7x13 bitmap font,
+	// a UXGA frame gets the text three times bigger than a VGA one
+	pixelsPerScale = 480
+	paddingPixels  = 3
+)
+
+var (
+	bandColor = color.RGBA{R: 0, G: 0, B: 0, A: 160}
+	textColor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// Text returns the watermark line, for example:
+//
+//	Front Door  2024-08-01 19:04:05 WIB  #3f2a9c01b7e4
+func Text(settings *Settings, captureTime time.Time, shortHash string) string {
+	location, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	parts := make([]string, 0, 3)
+	if settings.DeviceName != "" {
+		parts = append(parts, settings.DeviceName)
+	}
+	parts = append(parts, captureTime.In(location).Format("2006-01-02 15:04:05 MST"))
+	if shortHash != "" {
+		parts = append(parts, fmt.Sprintf("#%s", shortHash))
+	}
+
+	return strings.Join(parts, "  ")
+}
+
+// Apply draws the watermark text on a translucent band at the bottom of the
+// image. The bitmap font is scaled up with the image width so the text stays
+// readable on full size exports, and on thumbnails too narrow for the whole
+// line every part of the text gets its own line.
+func Apply(img *image.RGBA, text string) {
+	face := basicfont.Face7x13
+	bounds := img.Bounds()
+	drawer := &font.Drawer{Face: face}
+
+	lines := []string{text}
+	if drawer.MeasureString(text).Ceil()+2*paddingPixels > bounds.Dx() {
+		lines = strings.Split(text, "  ")
+	}
+
+	textWidth := 0
+	for _, line := range lines {
+		textWidth = max(textWidth, drawer.MeasureString(line).Ceil())
+	}
+	textHeight := face.Height * len(lines)
+
+	scale := max(bounds.Dx()/pixelsPerScale, 1)
+	for scale > 1 && (textWidth+2*paddingPixels)*scale > bounds.Dx() {
+		scale--
+	}
+
+	// Render the text at the font size first, then copy it scaled up
+	glyphs := image.NewAlpha(image.Rect(0, 0, textWidth, textHeight))
+	drawer.Dst = glyphs
+	drawer.Src = image.Opaque
+	for i, line := range lines {
+		drawer.Dot = fixed.P(0, face.Ascent+i*face.Height)
+		drawer.DrawString(line)
+	}
+
+	bandHeight := (textHeight + 2*paddingPixels) * scale
+	band := image.Rect(bounds.Min.X, bounds.Max.Y-bandHeight, bounds.Max.X, bounds.Max.Y)
+	draw.Draw(img, band, image.NewUniform(bandColor), image.Point{}, draw.Over)
+
+	originX := bounds.Min.X + paddingPixels*scale
+	originY := band.Min.Y + paddingPixels*scale
+	for y := 0; y < textHeight; y++ {
+		for x := 0; x < textWidth; x++ {
+			if glyphs.AlphaAt(x, y).A < 128 {
+				continue
+			}
+
+			cell := image.Rect(originX+x*scale, originY+y*scale, originX+(x+1)*scale, originY+(y+1)*scale)
+			draw.Draw(img, cell.Intersect(bounds), image.NewUniform(textColor), image.Point{}, draw.Src)
+		}
+	}
+}
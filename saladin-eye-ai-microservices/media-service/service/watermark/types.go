@@ -0,0 +1,21 @@
+package watermark
+
+import "context"
+
+type Settings struct {
+	Enabled    bool   `json:"enabled"`
+	DeviceName string `json:"device_name"`
+	Timezone   string `json:"timezone"`
+	Version    int64  `json:"version"`
+	UpdatedAt  int64  `json:"updated_at"`
+}
+
+// IsEnabled is true when the derived images of the device get a watermark
+func (settings *Settings) IsEnabled() bool {
+	return settings != nil && settings.Enabled
+}
+
+type WatermarkServiceIface interface {
+	GetSettings(ctx context.Context, deviceId string) (*Settings, error)
+	SetSettings(ctx context.Context, deviceId string, settings Settings) (*Settings, error)
+}
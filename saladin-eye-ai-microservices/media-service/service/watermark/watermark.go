@@ -0,0 +1,110 @@
+package watermark
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	// The service container does not always ship the IANA time zone database
+	_ "time/tzdata"
+)
+
+const maxDeviceNameLength = 64
+
+type WatermarkServiceImpl struct {
+	rdb redis.Cmdable
+}
+
+func New(rdb redis.Cmdable) WatermarkServiceIface {
+	return &WatermarkServiceImpl{
+		rdb: rdb,
+	}
+}
+
+/**
+ * Return the watermark settings of the device.
+ * A device without settings gets disabled settings with version 0.
+ */
+func (ws *WatermarkServiceImpl) GetSettings(ctx context.Context, deviceId string) (*Settings, error) {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return nil, status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	redisKey := fmt.Sprintf("media-service:watermark:%s", deviceId)
+	settingsJson, err := ws.rdb.Get(ctx, redisKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return &Settings{Timezone: "UTC"}, nil
+		}
+
+		log.Error().Msgf("failed to get watermark settings from Redis: %v", err)
+		return nil, fmt.Errorf("failed to get watermark settings from Redis: %w", err)
+	}
+
+	var settings Settings
+	if err := json.Unmarshal([]byte(settingsJson), &settings); err != nil {
+		log.Error().Msgf("failed to unmarshal watermark settings: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal watermark settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+/**
+ * Replace the watermark settings of the device.
+ *
+ * Like the privacy mask, every change gets a new version that is part of the
+ * derived image path.
+ */
+func (ws *WatermarkServiceImpl) SetSettings(ctx context.Context, deviceId string, settings Settings) (*Settings, error) {
+	if len(deviceId) != 9 {
+		log.Error().Msgf("invalid device_id %s length %d", deviceId, len(deviceId))
+		return nil, status.Errorf(codes.InvalidArgument, "invalid device_id length: %d", len(deviceId))
+	}
+
+	settings.DeviceName = strings.TrimSpace(settings.DeviceName)
+	if len(settings.DeviceName) > maxDeviceNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "device name is longer than %d characters", maxDeviceNameLength)
+	}
+
+	settings.Timezone = strings.TrimSpace(settings.Timezone)
+	if settings.Timezone == "" {
+		settings.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(settings.Timezone); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown timezone: %s", settings.Timezone)
+	}
+
+	version, err := ws.rdb.Incr(ctx, fmt.Sprintf("media-service:watermark:version:%s", deviceId)).Result()
+	if err != nil {
+		log.Error().Msgf("failed to increment watermark settings version in Redis: %v", err)
+		return nil, fmt.Errorf("failed to increment watermark settings version in Redis: %w", err)
+	}
+
+	settings.Version = version
+	settings.UpdatedAt = time.Now().UTC().Unix()
+
+	settingsJson, err := json.Marshal(settings)
+	if err != nil {
+		log.Error().Msgf("failed to marshal watermark settings: %v", err)
+		return nil, fmt.Errorf("failed to marshal watermark settings: %w", err)
+	}
+
+	redisKey := fmt.Sprintf("media-service:watermark:%s", deviceId)
+	if _, err := ws.rdb.Set(ctx, redisKey, settingsJson, 0).Result(); err != nil {
+		log.Error().Msgf("failed to set watermark settings in Redis: %v", err)
+		return nil, fmt.Errorf("failed to set watermark settings in Redis: %w", err)
+	}
+
+	log.Info().Msgf("watermark settings of device_id %s set to version %d", deviceId, version)
+
+	return &settings, nil
+}
@@ -0,0 +1,53 @@
+package mqttconn
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// CleanSessionFromEnv is MQTT_CLEAN_SESSION, the session is persistent unless
+// it is "true"
+func CleanSessionFromEnv() bool {
+	return os.Getenv("MQTT_CLEAN_SESSION") == "true"
+}
+
+/**
+ * Every instance needs its own client id, the broker disconnects a client
+ * when another one connects with the same id. The id is the MQTT_CLIENT_ID
+ * prefix, defaultPrefix when not set, and the instance MQTT_INSTANCE_ID.
+ *
+ * The broker keeps a persistent session by client id, so with
+ * MQTT_CLEAN_SESSION not "true" MQTT_INSTANCE_ID must be set, and stay the
+ * same when the instance restarts. A host name changes on every deploy in
+ * most orchestrators, each one would leave a session behind that queues
+ * requests nobody reads. With a clean session, the host name is used when
+ * MQTT_INSTANCE_ID is not set.
+ */
+func ClientIdFromEnv(defaultPrefix string) (string, error) {
+	prefix := os.Getenv("MQTT_CLIENT_ID")
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+
+	instanceId := os.Getenv("MQTT_INSTANCE_ID")
+	if instanceId == "" {
+		if !CleanSessionFromEnv() {
+			return "", errors.New("MQTT_INSTANCE_ID must be set to a stable id per instance for a persistent MQTT session, or MQTT_CLEAN_SESSION to true")
+		}
+
+		hostname, err := os.Hostname()
+		if err != nil {
+			randomBytes := make([]byte, 4)
+			if _, err := rand.Read(randomBytes); err != nil {
+				return "", fmt.Errorf("failed to generate a random instance id: %w", err)
+			}
+			hostname = hex.EncodeToString(randomBytes)
+		}
+		instanceId = hostname
+	}
+
+	return fmt.Sprintf("%s-%s", prefix, instanceId), nil
+}
@@ -0,0 +1,3 @@
+module github.com/andypmw/saladin-eye-ai/mqttconn
+
+go 1.22
@@ -0,0 +1,64 @@
+// Package mqttconn reads the MQTT broker connection settings the services
+// share from the environment.
+package mqttconn
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+/**
+ * The TLS config of the broker connection, from the environment:
+ *
+ *	MQTT_TLS_CA_FILE      CA of the broker certificate, else the system CAs
+ *	MQTT_TLS_CERT_FILE    client certificate, for mTLS
+ *	MQTT_TLS_KEY_FILE     client key, for mTLS
+ *	MQTT_TLS_SERVER_NAME  when the broker certificate is not for its address
+ *
+ * Nil when none is set, the connection is then only TLS with an ssl://, tls://
+ * or mqtts:// broker address.
+ */
+func TLSConfigFromEnv() (*tls.Config, error) {
+	caFile := os.Getenv("MQTT_TLS_CA_FILE")
+	certFile := os.Getenv("MQTT_TLS_CERT_FILE")
+	keyFile := os.Getenv("MQTT_TLS_KEY_FILE")
+	serverName := os.Getenv("MQTT_TLS_SERVER_NAME")
+
+	if caFile == "" && certFile == "" && keyFile == "" && serverName == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+
+	if caFile != "" {
+		caPem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MQTT_TLS_CA_FILE: %w", err)
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caPem) {
+			return nil, fmt.Errorf("no certificate found in MQTT_TLS_CA_FILE %s", caFile)
+		}
+	}
+
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("MQTT_TLS_CERT_FILE and MQTT_TLS_KEY_FILE must be set together")
+		}
+
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load MQTT client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}